@@ -19,3 +19,56 @@ func TestSetNoFilePanics(t *testing.T) {
 
 	assert.Panics(t, p)
 }
+
+func TestLinkKeys(t *testing.T) {
+	c := config{LabelKeyLink: "app"}
+
+	assert.Equal(t, []string{"app"}, c.LinkKeys("any"))
+
+	c.LabelKeysLink = []string{"app.kubernetes.io/name", "app"}
+	c.NamespaceLabelKeysLink = map[string][]string{
+		"kube-system": {"k8s-app"},
+		"monitoring":  {"app.kubernetes.io/name", "name"},
+	}
+
+	assert.Equal(t, []string{"app.kubernetes.io/name", "app"}, c.LinkKeys("default"))
+	assert.Equal(t, []string{"k8s-app"}, c.LinkKeys("kube-system"))
+	assert.Equal(t, []string{"app.kubernetes.io/name", "app", "k8s-app", "name"}, c.AllLinkKeys())
+}
+
+func TestLinkKeysEmpty(t *testing.T) {
+	c := config{}
+
+	assert.Empty(t, c.LinkKeys("default"))
+	assert.Empty(t, c.AllLinkKeys())
+}
+
+func TestRedactionRules(t *testing.T) {
+	c := config{}
+
+	assert.Equal(t, DefaultRedaction, c.RedactionRules())
+	assert.Nil(t, DefaultRedaction.validate())
+
+	c.Redaction = &Redaction{}
+
+	assert.Empty(t, c.RedactionRules().Names)
+	assert.Empty(t, c.RedactionRules().Values)
+}
+
+func TestRedactionInvalidRule(t *testing.T) {
+	err := Redaction{Values: []string{"(unclosed"}}.validate()
+
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "(unclosed")
+}
+
+func TestJSONLogKeys(t *testing.T) {
+	c := config{}
+
+	assert.Equal(t, DefaultJSONLogs, c.JSONLogKeys())
+
+	c.JSONLogs = JSONLogs{MessageKeys: []string{"event"}}
+
+	assert.Equal(t, []string{"event"}, c.JSONLogKeys().MessageKeys)
+	assert.Equal(t, DefaultJSONLogs.LevelKeys, c.JSONLogKeys().LevelKeys)
+}
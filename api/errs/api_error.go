@@ -46,6 +46,14 @@ func ValidationError(err string) *APIError {
 	}
 }
 
+// NotFound returns 404/Message status/message
+func NotFound(err string) *APIError {
+	return &APIError{
+		Code:    http.StatusNotFound,
+		Message: fmt.Sprintf("\n%s: %s\n", http.StatusText(http.StatusNotFound), err),
+	}
+}
+
 // SerializationError returns 500/Message status/message
 func SerializationError(err string) *APIError {
 	return &APIError{
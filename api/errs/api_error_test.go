@@ -31,6 +31,12 @@ func TestValidationError(t *testing.T) {
 	assert.Equal(t, "\nBad Request: test\n", r.Message)
 }
 
+func TestNotFound(t *testing.T) {
+	r := NotFound("test")
+	assert.Equal(t, http.StatusNotFound, r.Code)
+	assert.Equal(t, "\nNot Found: test\n", r.Message)
+}
+
 func TestSerializationError(t *testing.T) {
 	r := SerializationError("test")
 	assert.Equal(t, http.StatusInternalServerError, r.Code)
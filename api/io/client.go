@@ -2,9 +2,11 @@ package io
 
 import (
 	"bytes"
+	"context"
 	"log"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -52,8 +54,19 @@ type client struct {
 	conn *websocket.Conn
 	// Buffered channel of outbound messages.
 	send chan []byte
-	// pod is the pod name to key with the connection
-	pod string
+	// mu serializes writes, a connection supports one concurrent writer.
+	mu sync.Mutex
+	// cancel stops the log streams of the connection once the peer is gone.
+	cancel context.CancelFunc
+}
+
+// write writes a log line to the peer.
+func (c *client) write(line []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.conn.SetWriteDeadline(time.Now().Add(writeDeadline))
+	return c.conn.WriteMessage(logStream, line)
 }
 
 // readPump pumps messages from the websocket connection to the Factory.
@@ -63,6 +76,9 @@ type client struct {
 // reads from this goroutine.
 func (c *client) readPump() {
 	defer func() {
+		if c.cancel != nil {
+			c.cancel()
+		}
 		c.factory.unregister <- c
 		c.conn.Close()
 	}()
@@ -97,9 +113,9 @@ func (c *client) readPump() {
 
 // writePump pumps messages from the Factory to the websocket connection.
 //
-// A goroutine running writePump is started for each connection. The
-// application ensures that there is at most one writer to a connection by
-// executing all writes from this goroutine.
+// A goroutine running writePump is started for each connection. Log lines
+// are written by the log streams, so every write holds mu to ensure there is
+// at most one writer to a connection.
 func (c *client) writePump() {
 	ticker := time.NewTicker(pingPeriod)
 	defer func() {
@@ -109,35 +125,48 @@ func (c *client) writePump() {
 	for {
 		select {
 		case message, ok := <-c.send:
-			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
-			if !ok {
-				// The hub closed the channel.
-				c.conn.WriteMessage(websocket.CloseMessage, []byte{})
+			if !c.writeQueued(message, ok) {
 				return
 			}
+		case <-ticker.C:
+			c.mu.Lock()
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			err := c.conn.WriteMessage(websocket.PingMessage, nil)
+			c.mu.Unlock()
 
-			w, err := c.conn.NextWriter(websocket.TextMessage)
 			if err != nil {
 				return
 			}
+		}
+	}
+}
+
+// writeQueued writes a message and the messages queued after it as one websocket message,
+// returning false once the connection can't be written to.
+func (c *client) writeQueued(message []byte, ok bool) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+	if !ok {
+		// The hub closed the channel.
+		c.conn.WriteMessage(websocket.CloseMessage, []byte{})
+		return false
+	}
 
-			w.Write(message)
+	w, err := c.conn.NextWriter(websocket.TextMessage)
+	if err != nil {
+		return false
+	}
 
-			// Add queued chat messages to the current websocket message.
-			n := len(c.send)
-			for i := 0; i < n; i++ {
-				w.Write(newline)
-				w.Write(<-c.send)
-			}
+	w.Write(message)
 
-			if err := w.Close(); err != nil {
-				return
-			}
-		case <-ticker.C:
-			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
-			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
-				return
-			}
-		}
+	// Add queued chat messages to the current websocket message.
+	n := len(c.send)
+	for i := 0; i < n; i++ {
+		w.Write(newline)
+		w.Write(<-c.send)
 	}
+
+	return w.Close() == nil
 }
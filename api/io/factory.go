@@ -2,6 +2,7 @@ package io
 
 import (
 	"bufio"
+	"context"
 	"io"
 	"net/http"
 	"strings"
@@ -70,16 +71,8 @@ func (f *Factory) Run() {
 func (f *Factory) Register(k8Client k8sv1.Clienter, w http.ResponseWriter, r *http.Request) {
 	l := klog.MustFromContext(r.Context())
 
-	conn, err := upgrader.Upgrade(w, r, nil)
-
-	if err != nil {
-		l.Errorf("WebSocket Upgrader Error : %s", err.Error())
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte(http.StatusText(http.StatusInternalServerError)))
-		return
-	}
-
 	// "/io/{pod}/logs?namespace=ns" = []string{"", "io", "pod", "logs"}
+	// "/io/logs?namespace=ns&linkedName=name" = []string{"", "io", "logs"}
 	p := strings.Split(r.URL.Path, "/")
 	ns := r.URL.Query().Get("namespace")
 
@@ -89,19 +82,83 @@ func (f *Factory) Register(k8Client k8sv1.Clienter, w http.ResponseWriter, r *ht
 		w.Write([]byte(http.StatusText(http.StatusBadRequest)))
 		return
 	}
-	pod := p[2]
 
-	// support multiple containers in a pod.
-	var containerName string
-	httpreq.NewParsingMapPre(1).
-		ToString("container", &containerName).
+	// support multiple containers in a pod, following every linked or selected pod, and filtering lines by text, level and fields.
+	var linkedName, labelSelector string
+	var previous bool
+
+	options := k8sv1.LogOptions{
+		Logger:    l,
+		Namespace: ns,
+	}
+
+	err := httpreq.NewParsingMapPre(11).
+		ToString("container", &options.ContainerName).
+		ToString("linkedName", &linkedName).
+		ToString("labelSelector", &labelSelector).
+		ToBool("previous", &previous).
+		ToString("include", &options.Include).
+		ToString("exclude", &options.Exclude).
+		ToBool("ignoreCase", &options.IgnoreCase).
+		ToInt("before", &options.Before).
+		ToInt("after", &options.After).
+		ToString("level", &options.Level).
+		ToCommaList("field", &options.Fields).
 		Parse(r.URL.Query())
 
+	if err != nil {
+		l.Errorf("WebSocket Validation Error : %s", err.Error())
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(http.StatusText(http.StatusBadRequest)))
+		return
+	}
+
+	filter, apiErr := k8sv1.NewLogFilter(options)
+
+	if apiErr != nil {
+		l.Errorf("WebSocket Validation Error : %s", apiErr.Message)
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(http.StatusText(http.StatusBadRequest)))
+		return
+	}
+
+	linked := len(p) == 3
+
+	if linked {
+		if len(linkedName) == 0 && len(labelSelector) == 0 {
+			l.Error(`WebSocket Validation Error : Query string param "linkedName" or "labelSelector" must be provided.`)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(http.StatusText(http.StatusBadRequest)))
+			return
+		}
+
+		// linked pods are followed as containers start, previous instances have stopped.
+		if previous {
+			l.Error(`WebSocket Validation Error : Query string param "previous" is only supported for a pod.`)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(http.StatusText(http.StatusBadRequest)))
+			return
+		}
+	}
+
+	// validated before upgrading, so a bad request is answered with a plain 400
+	conn, err := upgrader.Upgrade(w, r, nil)
+
+	if err != nil {
+		l.Errorf("WebSocket Upgrader Error : %s", err.Error())
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(http.StatusText(http.StatusInternalServerError)))
+		return
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
 	c := &client{
 		factory: f,
 		conn:    conn,
 		send:    make(chan []byte, 256),
-		pod:     pod,
+		cancel:  cancel,
 	}
 
 	c.factory.register <- c
@@ -110,16 +167,34 @@ func (f *Factory) Register(k8Client k8sv1.Clienter, w http.ResponseWriter, r *ht
 	go c.readPump()
 	go c.writePump()
 
-	// get stream
-	stream, apiErr := k8Client.ReadLogs(k8sv1.LogOptions{
-		Logger:        l,
-		PodName:       pod,
-		ContainerName: containerName,
-		Namespace:     ns,
-		Tail:          1,
-		Follow:        true,
-		Context:       r.Context(),
-	})
+	if linked {
+		pods := k8sv1.PodOptions{
+			Logger:      l,
+			LinkedName:  linkedName,
+			Namespace:   ns,
+			ListOptions: k8sv1.ListOptions{LabelSelector: labelSelector},
+			Context:     ctx,
+		}
+
+		watcher, apiErr := k8Client.WatchPods(pods)
+
+		if apiErr != nil {
+			l.Errorf("WebSocket WatchPods Error : %d - %s", apiErr.Code, apiErr.Message)
+			klog.Trace()
+			return
+		}
+
+		newTailer(k8Client, pods, options, c.write).run(ctx, watcher)
+		return
+	}
+
+	// get stream, starting at the last line, or the last lines of the previous instance.
+	options.PodName = p[2]
+	options.Follow = true
+	options.Previous = previous
+	options.Context = ctx
+
+	stream, apiErr := k8Client.ReadLogs(options)
 
 	if apiErr != nil {
 		l.Errorf("WebSocket LogStream Error : %d - %s", apiErr.Code, apiErr.Message)
@@ -127,11 +202,11 @@ func (f *Factory) Register(k8Client k8sv1.Clienter, w http.ResponseWriter, r *ht
 		return
 	}
 
-	f.streamLogs(l, stream, pod)
+	streamLogs(l, stream, c, filter)
 }
 
-// streamLogs infinitely loops for as long as the reader is open
-func (f *Factory) streamLogs(l klog.Logger, stream io.ReadCloser, podName string) {
+// streamLogs infinitely loops for as long as the reader is open, writing the lines kept by filter.
+func streamLogs(l klog.Logger, stream io.ReadCloser, c *client, filter *k8sv1.LogFilter) {
 	// close stream after connection drops
 	defer stream.Close()
 
@@ -162,13 +237,9 @@ func (f *Factory) streamLogs(l klog.Logger, stream io.ReadCloser, podName string
 			lines = lines[:(length - 1)]
 		}
 
-		// for every line, go through every connected client listening to the given pod logs.
 		for _, line := range lines {
-			for client := range f.clients {
-				if client.pod == podName {
-					client.conn.SetWriteDeadline(time.Now().Add(writeDeadline))
-					client.conn.WriteMessage(logStream, []byte(line))
-				}
+			for _, kept := range filter.Next(k8sv1.ParseJSONLog(k8sv1.LogLine{Text: line})) {
+				c.write([]byte(kept.Text))
 			}
 		}
 	}
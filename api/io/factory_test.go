@@ -107,3 +107,75 @@ func TestFactoryWriteReadForbiddenHost(t *testing.T) {
 
 	assert.Equal(t, "websocket: bad handshake", err.Error())
 }
+
+func TestFactoryValidation(t *testing.T) {
+	config.Set("../config/config.json")
+
+	wsFactory := New()
+
+	go wsFactory.Run()
+
+	setup()
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		dctx := klog.NewContext(r.Context(), "", &logfakes.Logger{})
+		r = r.WithContext(dctx)
+
+		wsFactory.Register(&k8fakes.K8sV1{}, w, r)
+	}))
+
+	defer s.Close()
+
+	for _, target := range []string{
+		"/io/test-pod/logs",
+		"/io/test-pod/logs?namespace=default&before=x",
+		"/io/test-pod/logs?namespace=default&level=loud",
+		"/io/logs?namespace=default",
+		"/io/logs?namespace=default&linkedName=test&previous=true",
+	} {
+		u := "ws" + strings.TrimPrefix(s.URL+target, "http")
+
+		// the handshake is refused, not upgraded and closed
+		_, resp, err := websocket.DefaultDialer.Dial(u, nil)
+
+		assert.Equal(t, websocket.ErrBadHandshake, err, target)
+		if assert.NotNil(t, resp, target) {
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode, target)
+		}
+	}
+}
+
+func TestFactoryLinkedLogs(t *testing.T) {
+	config.Set("../config/config.json")
+
+	wsFactory := New()
+
+	go wsFactory.Run()
+
+	setup()
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		dctx := klog.NewContext(r.Context(), "", &logfakes.Logger{})
+		r = r.WithContext(dctx)
+
+		wsFactory.Register(&k8fakes.K8sV1{}, w, r)
+	}))
+
+	defer s.Close()
+
+	u := "ws" + strings.TrimPrefix(fmt.Sprintf("%s/io/logs?namespace=default&linkedName=test", s.URL), "http")
+
+	ws, _, err := websocket.DefaultDialer.Dial(u, nil)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer ws.Close()
+
+	// the fake watch adds pod "test" with a running container "test"
+	_, p, err := ws.ReadMessage()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	assert.Equal(t, "[test/test] message", string(p))
+}
@@ -0,0 +1,261 @@
+package io
+
+import (
+	"bufio"
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	k8sv1 "github.com/kubelens/kubelens/api/k8sv1"
+	klog "github.com/kubelens/kubelens/api/log"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// maxLineSize - Maximum size of a log line, longer lines end the stream of a container.
+const maxLineSize int = 1024 * 1024
+
+// rewatchDelay and maxRewatchDelay - Initial and maximum wait before re-establishing an ended pod watch,
+// doubled every time it can't be re-established.
+var (
+	rewatchDelay    = time.Second
+	maxRewatchDelay = 30 * time.Second
+)
+
+// tailer follows the logs of every container of the pods seen by a pod watch, attaching
+// to a container once it's running and detaching from a pod once it's deleted. A container
+// that started after the tailer is followed from its start, one that was running already from
+// its last line.
+type tailer struct {
+	k8Client k8sv1.Clienter
+	// the linked or selected pods, watched again when the watch ends
+	pods k8sv1.PodOptions
+	// the namespace, the container to follow if set and the filter of every container
+	options k8sv1.LogOptions
+	// write sends a line to the peer
+	write func(line []byte) error
+
+	// started is when the tailer was created
+	started time.Time
+
+	mu sync.Mutex
+	// attached is the cancel func of every followed container by pod, keyed by
+	// name and container id so a restarted container is attached to again.
+	attached map[string]map[string]context.CancelFunc
+	// dropped is when the api server ended the stream of a container, by pod and key,
+	// so the container is followed from there when it's attached to again.
+	dropped map[string]map[string]time.Time
+	wg      sync.WaitGroup
+}
+
+func newTailer(k8Client k8sv1.Clienter, pods k8sv1.PodOptions, options k8sv1.LogOptions, write func(line []byte) error) *tailer {
+	return &tailer{
+		k8Client: k8Client,
+		pods:     pods,
+		options:  options,
+		write:    write,
+		started:  time.Now(),
+		attached: make(map[string]map[string]context.CancelFunc),
+		dropped:  make(map[string]map[string]time.Time),
+	}
+}
+
+// run attaches and detaches as pods come and go until ctx is done. The api server ends watches,
+// so an ended watch is re-established, keeping the followed containers; the new watch starts with
+// an added event for every existing pod, attaching to what started in between.
+func (t *tailer) run(ctx context.Context, w watch.Interface) {
+	defer func() {
+		w.Stop()
+		t.detachAll()
+		t.wg.Wait()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-w.ResultChan():
+			if !ok {
+				w.Stop()
+
+				next := t.rewatch(ctx)
+				if next == nil {
+					return
+				}
+
+				w = next
+				continue
+			}
+
+			pod, ok := e.Object.(*v1.Pod)
+			if !ok {
+				if e.Type == watch.Error {
+					t.options.Logger.Errorf("WebSocket WatchPods Error : %v", e.Object)
+				}
+				continue
+			}
+
+			switch e.Type {
+			case watch.Added, watch.Modified:
+				t.attach(ctx, pod)
+			case watch.Deleted:
+				t.detach(pod.Name)
+			}
+		}
+	}
+}
+
+// rewatch re-establishes the pod watch, backing off while it can't be, until ctx is done.
+func (t *tailer) rewatch(ctx context.Context) watch.Interface {
+	delay := rewatchDelay
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(delay):
+		}
+
+		w, apiErr := t.k8Client.WatchPods(t.pods)
+
+		if apiErr == nil {
+			return w
+		}
+
+		t.options.Logger.Errorf("WebSocket WatchPods Error : %d - %s", apiErr.Code, apiErr.Message)
+
+		if delay *= 2; delay > maxRewatchDelay {
+			delay = maxRewatchDelay
+		}
+	}
+}
+
+// attach follows every running init and app container of a pod not already followed.
+func (t *tailer) attach(ctx context.Context, pod *v1.Pod) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	statuses := append(append([]v1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+
+	for _, cs := range statuses {
+		if cs.State.Running == nil || (len(t.options.ContainerName) > 0 && cs.Name != t.options.ContainerName) {
+			continue
+		}
+
+		containers, ok := t.attached[pod.Name]
+		if !ok {
+			containers = make(map[string]context.CancelFunc)
+			t.attached[pod.Name] = containers
+		}
+
+		key := cs.Name + "/" + cs.ContainerID
+		if _, ok := containers[key]; ok {
+			continue
+		}
+
+		var since *time.Time
+		if dropped, ok := t.dropped[pod.Name][key]; ok {
+			since = &dropped
+		} else if started := cs.State.Running.StartedAt.Time; started.After(t.started) {
+			since = &started
+		}
+
+		sctx, cancel := context.WithCancel(ctx)
+		containers[key] = cancel
+
+		t.wg.Add(1)
+
+		go func(podName, container, key string) {
+			defer t.wg.Done()
+			defer cancel()
+			// the api server ended the stream, attach to the container again if it's still running
+			defer t.drop(sctx, podName, key)
+
+			t.follow(sctx, podName, container, since)
+		}(pod.Name, cs.Name, key)
+	}
+}
+
+// drop forgets a followed container once its stream ends, unless it was detached.
+func (t *tailer) drop(ctx context.Context, pod, key string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if ctx.Err() != nil {
+		return
+	}
+
+	delete(t.attached[pod], key)
+
+	if _, ok := t.dropped[pod]; !ok {
+		t.dropped[pod] = make(map[string]time.Time)
+	}
+	t.dropped[pod][key] = time.Now()
+}
+
+// detach stops following the containers of a pod.
+func (t *tailer) detach(pod string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, cancel := range t.attached[pod] {
+		cancel()
+	}
+	delete(t.attached, pod)
+	delete(t.dropped, pod)
+}
+
+// detachAll stops following every container.
+func (t *tailer) detachAll() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for pod, containers := range t.attached {
+		for _, cancel := range containers {
+			cancel()
+		}
+		delete(t.attached, pod)
+	}
+}
+
+// follow writes every line a container logs kept by the filter, prefixed with its pod/container,
+// until the stream ends or the peer is gone. The stream starts at since if it's set, otherwise
+// at the last line.
+func (t *tailer) follow(ctx context.Context, pod, container string, since *time.Time) {
+	options := t.options
+	options.PodName = pod
+	options.ContainerName = container
+	options.Follow = true
+	options.SinceTime = since
+	options.Context = ctx
+
+	stream, apiErr := t.k8Client.ReadLogs(options)
+
+	if apiErr != nil {
+		options.Logger.Errorf("WebSocket LogStream Error : %d - %s", apiErr.Code, apiErr.Message)
+		klog.Trace()
+		return
+	}
+
+	// validated when the connection was registered
+	filter, _ := k8sv1.NewLogFilter(options)
+
+	// close stream after the container is detached
+	defer stream.Close()
+
+	prefix := k8sv1.LogPrefix(pod, container)
+
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 4096), maxLineSize)
+
+	for scanner.Scan() {
+		for _, line := range strings.Split(scanner.Text(), "\r") {
+			for _, kept := range filter.Next(k8sv1.ParseJSONLog(k8sv1.LogLine{Text: line})) {
+				if err := t.write([]byte(prefix + kept.Text)); err != nil {
+					return
+				}
+			}
+		}
+	}
+}
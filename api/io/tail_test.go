@@ -0,0 +1,344 @@
+package io
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kubelens/kubelens/api/errs"
+	k8sv1 "github.com/kubelens/kubelens/api/k8sv1"
+	k8fakes "github.com/kubelens/kubelens/api/k8sv1/fakes"
+	logfakes "github.com/kubelens/kubelens/api/log/fakes"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// followK8sV1 logs "message" and keeps the stream open until it's cancelled, unless ended is set.
+type followK8sV1 struct {
+	k8fakes.K8sV1
+
+	ended bool
+
+	mu sync.Mutex
+	// since is the start of every stream read, by pod/container
+	since map[string][]*time.Time
+}
+
+func (m *followK8sV1) ReadLogs(options k8sv1.LogOptions) (io.ReadCloser, *errs.APIError) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.since == nil {
+		m.since = map[string][]*time.Time{}
+	}
+	key := options.PodName + "/" + options.ContainerName
+	m.since[key] = append(m.since[key], options.SinceTime)
+
+	if m.ended {
+		return ioutil.NopCloser(strings.NewReader("message\n")), nil
+	}
+
+	return ioutil.NopCloser(io.MultiReader(strings.NewReader("message\n"), openStream{options.Context})), nil
+}
+
+func (m *followK8sV1) streams(key string) []*time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.since[key]
+}
+
+// openStream blocks until its context is done.
+type openStream struct {
+	ctx context.Context
+}
+
+func (s openStream) Read(p []byte) (int, error) {
+	<-s.ctx.Done()
+	return 0, io.EOF
+}
+
+func tailTestPod(name string, statuses ...v1.ContainerStatus) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Status:     v1.PodStatus{ContainerStatuses: statuses},
+	}
+}
+
+func running(name, id string) v1.ContainerStatus {
+	return v1.ContainerStatus{Name: name, ContainerID: id, State: v1.ContainerState{Running: &v1.ContainerStateRunning{}}}
+}
+
+func TestTailerAttachDetach(t *testing.T) {
+	lines := make(chan string, 10)
+
+	tl := newTailer(&followK8sV1{}, k8sv1.PodOptions{Namespace: "default"}, k8sv1.LogOptions{Logger: &logfakes.Logger{}, Namespace: "default"}, func(line []byte) error {
+		lines <- string(line)
+		return nil
+	})
+
+	w := watch.NewFake()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan struct{})
+	go func() {
+		tl.run(ctx, w)
+		close(done)
+	}()
+
+	next := func() string {
+		select {
+		case line := <-lines:
+			return line
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for a line")
+		}
+		return ""
+	}
+
+	waiting := v1.ContainerStatus{Name: "proxy", State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{}}}
+
+	w.Add(tailTestPod("web-1", running("web", "1"), waiting))
+	assert.Equal(t, "[web-1/web] message", next())
+
+	// already attached, the waiting container isn't
+	w.Modify(tailTestPod("web-1", running("web", "1"), waiting))
+
+	// restarted, a new container id
+	w.Modify(tailTestPod("web-1", running("web", "2"), running("proxy", "3")))
+	assert.ElementsMatch(t, []string{"[web-1/web] message", "[web-1/proxy] message"}, []string{next(), next()})
+
+	w.Delete(tailTestPod("web-1"))
+	w.Add(tailTestPod("web-2", running("web", "4")))
+	assert.Equal(t, "[web-2/web] message", next())
+
+	cancel()
+	<-done
+
+	assert.Len(t, lines, 0)
+	assert.Len(t, tl.attached, 0)
+}
+
+func TestTailerContainer(t *testing.T) {
+	lines := make(chan string, 10)
+
+	tl := newTailer(&followK8sV1{}, k8sv1.PodOptions{Namespace: "default"}, k8sv1.LogOptions{Logger: &logfakes.Logger{}, Namespace: "default", ContainerName: "proxy"}, func(line []byte) error {
+		lines <- string(line)
+		return nil
+	})
+
+	w := watch.NewFake()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go func() {
+		w.Add(tailTestPod("web-1", running("web", "1"), running("proxy", "2")))
+		// the re-established watch adds pod "test" with container "test"
+		w.Stop()
+		cancel()
+	}()
+
+	tl.run(ctx, w)
+
+	assert.Equal(t, "[web-1/proxy] message", <-lines)
+	assert.Len(t, lines, 0)
+}
+
+func TestTailerFilter(t *testing.T) {
+	lines := make(chan string, 10)
+
+	// the fake logs "message"
+	tl := newTailer(&followK8sV1{}, k8sv1.PodOptions{Namespace: "default"}, k8sv1.LogOptions{Logger: &logfakes.Logger{}, Namespace: "default", Exclude: "MESSAGE", IgnoreCase: true}, func(line []byte) error {
+		lines <- string(line)
+		return nil
+	})
+
+	w := watch.NewFake()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go func() {
+		w.Add(tailTestPod("web-1", running("web", "1")))
+		w.Stop()
+		cancel()
+	}()
+
+	tl.run(ctx, w)
+
+	assert.Len(t, lines, 0)
+}
+
+func TestTailerRewatch(t *testing.T) {
+	defer func(delay time.Duration) { rewatchDelay = delay }(rewatchDelay)
+	rewatchDelay = time.Millisecond
+
+	lines := make(chan string, 10)
+
+	tl := newTailer(&followK8sV1{}, k8sv1.PodOptions{Namespace: "default"}, k8sv1.LogOptions{Logger: &logfakes.Logger{}, Namespace: "default"}, func(line []byte) error {
+		lines <- string(line)
+		return nil
+	})
+
+	w := watch.NewFake()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan struct{})
+	go func() {
+		tl.run(ctx, w)
+		close(done)
+	}()
+
+	w.Add(tailTestPod("web-1", running("web", "1")))
+	assert.Equal(t, "[web-1/web] message", <-lines)
+
+	// the re-established watch adds pod "test" with container "test"
+	w.Stop()
+
+	select {
+	case line := <-lines:
+		assert.Equal(t, "[test/test] message", line)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the watch to be re-established")
+	}
+
+	tl.mu.Lock()
+	assert.Contains(t, tl.attached, "web-1")
+	assert.Contains(t, tl.attached, "test")
+	tl.mu.Unlock()
+
+	cancel()
+	<-done
+
+	assert.Len(t, lines, 0)
+}
+
+func TestTailerRewatchError(t *testing.T) {
+	defer func(delay time.Duration) { rewatchDelay = delay }(rewatchDelay)
+	rewatchDelay = time.Millisecond
+
+	// the fake can't watch namespace "bad"
+	tl := newTailer(&followK8sV1{}, k8sv1.PodOptions{Namespace: "bad"}, k8sv1.LogOptions{Logger: &logfakes.Logger{}, Namespace: "bad"}, func(line []byte) error {
+		return nil
+	})
+
+	w := watch.NewFake()
+	w.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	tl.run(ctx, w)
+
+	assert.Equal(t, context.DeadlineExceeded, ctx.Err())
+}
+
+func TestTailerSince(t *testing.T) {
+	lines := make(chan string, 10)
+	k8Client := &followK8sV1{}
+
+	tl := newTailer(k8Client, k8sv1.PodOptions{Namespace: "default"}, k8sv1.LogOptions{Logger: &logfakes.Logger{}, Namespace: "default"}, func(line []byte) error {
+		lines <- string(line)
+		return nil
+	})
+
+	w := watch.NewFake()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go func() {
+		earlier := running("web", "1")
+		earlier.State.Running.StartedAt = metav1.NewTime(tl.started.Add(-time.Minute))
+
+		later := running("web", "2")
+		later.State.Running.StartedAt = metav1.NewTime(tl.started.Add(time.Minute))
+
+		w.Add(tailTestPod("web-1", earlier))
+		w.Add(tailTestPod("web-2", later))
+		w.Stop()
+		cancel()
+	}()
+
+	tl.run(ctx, w)
+
+	assert.Len(t, lines, 2)
+
+	// running before the tailer, from the last line
+	assert.Equal(t, []*time.Time{nil}, k8Client.streams("web-1/web"))
+
+	// started after, from its start
+	if since := k8Client.streams("web-2/web"); assert.Len(t, since, 1) && assert.NotNil(t, since[0]) {
+		assert.True(t, since[0].Equal(tl.started.Add(time.Minute)))
+	}
+}
+
+func TestTailerReattach(t *testing.T) {
+	lines := make(chan string, 10)
+	// every stream is ended by the api server
+	k8Client := &followK8sV1{ended: true}
+
+	tl := newTailer(k8Client, k8sv1.PodOptions{Namespace: "default"}, k8sv1.LogOptions{Logger: &logfakes.Logger{}, Namespace: "default"}, func(line []byte) error {
+		lines <- string(line)
+		return nil
+	})
+
+	w := watch.NewFake()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan struct{})
+	go func() {
+		tl.run(ctx, w)
+		close(done)
+	}()
+
+	pod := tailTestPod("web-1", running("web", "1"))
+
+	w.Add(pod)
+	assert.Equal(t, "[web-1/web] message", <-lines)
+
+	assert.Eventually(t, func() bool {
+		tl.mu.Lock()
+		defer tl.mu.Unlock()
+		return len(tl.attached["web-1"]) == 0
+	}, 5*time.Second, time.Millisecond)
+
+	// still running, attached to again from where the stream ended
+	w.Modify(pod)
+	assert.Equal(t, "[web-1/web] message", <-lines)
+
+	cancel()
+	<-done
+
+	if since := k8Client.streams("web-1/web"); assert.Len(t, since, 2) {
+		assert.Nil(t, since[0])
+		assert.NotNil(t, since[1])
+	}
+}
+
+func TestTailerInitContainers(t *testing.T) {
+	lines := make(chan string, 10)
+
+	tl := newTailer(&followK8sV1{}, k8sv1.PodOptions{Namespace: "default"}, k8sv1.LogOptions{Logger: &logfakes.Logger{}, Namespace: "default"}, func(line []byte) error {
+		lines <- string(line)
+		return nil
+	})
+
+	w := watch.NewFake()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go func() {
+		pod := tailTestPod("web-1", v1.ContainerStatus{Name: "web", State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{}}})
+		pod.Status.InitContainerStatuses = []v1.ContainerStatus{running("migrate", "1")}
+
+		w.Add(pod)
+		w.Stop()
+		cancel()
+	}()
+
+	tl.run(ctx, w)
+
+	assert.Equal(t, "[web-1/migrate] message", <-lines)
+	assert.Len(t, lines, 0)
+}
@@ -0,0 +1,828 @@
+package k8sv1
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	batchlisters "k8s.io/client-go/listers/batch/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	discoverylisters "k8s.io/client-go/listers/discovery/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// the kinds watched by the informer cache
+const (
+	kindConfigMaps             = "configmaps"
+	kindCronJobs               = "cronjobs"
+	kindDaemonSets             = "daemonsets"
+	kindDeployments            = "deployments"
+	kindEndpointSlices         = "endpointslices"
+	kindEvents                 = "events"
+	kindJobs                   = "jobs"
+	kindNamespaces             = "namespaces"
+	kindNodes                  = "nodes"
+	kindPods                   = "pods"
+	kindReplicaSets            = "replicasets"
+	kindServices               = "services"
+	kindStatefulSets           = "statefulsets"
+	kindPersistentVolumeClaims = "persistentvolumeclaims"
+)
+
+// CacheStatus holds the sync status of the informer cache.
+type CacheStatus struct {
+	// true if reads are served from the informer cache
+	Enabled bool `json:"enabled"`
+	// true once every watched kind has synced, optional kinds aside
+	Synced bool `json:"synced"`
+	// the sync status of each watched kind, reads for a kind that hasn't
+	// synced yet are served by the api server.
+	Kinds map[string]bool `json:"kinds,omitempty"`
+	// the kinds that aren't watched with the reason, reads for them
+	// are always served by the api server.
+	Disabled map[string]string `json:"disabled,omitempty"`
+}
+
+// informerCache holds a watched local copy of each kind the Client reads.
+type informerCache struct {
+	synced   map[string]cache.InformerSynced
+	disabled map[string]string
+
+	configMaps             corelisters.ConfigMapLister
+	cronJobs               batchlisters.CronJobLister
+	daemonSets             appslisters.DaemonSetLister
+	deployments            appslisters.DeploymentLister
+	endpointSlices         discoverylisters.EndpointSliceLister
+	events                 corelisters.EventLister
+	jobs                   batchlisters.JobLister
+	namespaces             corelisters.NamespaceLister
+	nodes                  corelisters.NodeLister
+	pods                   corelisters.PodLister
+	replicaSets            appslisters.ReplicaSetLister
+	services               corelisters.ServiceLister
+	statefulSets           appslisters.StatefulSetLister
+	persistentVolumeClaims corelisters.PersistentVolumeClaimLister
+}
+
+// NewCached returns a new instance of Client that serves reads from shared informers rather
+// than listing from the api server on every call. The informers run until stop is closed.
+func NewCached(w Wrapper, resync time.Duration, stop <-chan struct{}) (Clienter, error) {
+	clientset, err := w.GetClientSet()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		wrapper: w,
+		cache:   newInformerCache(clientset, resync, stop),
+	}, nil
+}
+
+// cachedKinds holds the resource of each kind the informer cache watches.
+var cachedKinds = map[string]schema.GroupVersionResource{
+	kindConfigMaps:             v1.SchemeGroupVersion.WithResource(kindConfigMaps),
+	kindCronJobs:               batchv1.SchemeGroupVersion.WithResource(kindCronJobs),
+	kindDaemonSets:             appsv1.SchemeGroupVersion.WithResource(kindDaemonSets),
+	kindDeployments:            appsv1.SchemeGroupVersion.WithResource(kindDeployments),
+	kindEndpointSlices:         discoveryv1.SchemeGroupVersion.WithResource(kindEndpointSlices),
+	kindEvents:                 v1.SchemeGroupVersion.WithResource(kindEvents),
+	kindJobs:                   batchv1.SchemeGroupVersion.WithResource(kindJobs),
+	kindNamespaces:             v1.SchemeGroupVersion.WithResource(kindNamespaces),
+	kindNodes:                  v1.SchemeGroupVersion.WithResource(kindNodes),
+	kindPods:                   v1.SchemeGroupVersion.WithResource(kindPods),
+	kindReplicaSets:            appsv1.SchemeGroupVersion.WithResource(kindReplicaSets),
+	kindServices:               v1.SchemeGroupVersion.WithResource(kindServices),
+	kindStatefulSets:           appsv1.SchemeGroupVersion.WithResource(kindStatefulSets),
+	kindPersistentVolumeClaims: v1.SchemeGroupVersion.WithResource(kindPersistentVolumeClaims),
+}
+
+// optionalKinds aren't waited for before the cache is synced. batch/v1 cronjobs and
+// discovery/v1 endpointslices are only served since kubernetes 1.21.
+var optionalKinds = map[string]bool{
+	kindCronJobs:       true,
+	kindEndpointSlices: true,
+}
+
+// disabledKinds returns the kinds the api server doesn't serve or the service account
+// can't list, with the reason. If discovery or the access review fails the kind is
+// watched anyway.
+func disabledKinds(clientset kubernetes.Interface) map[string]string {
+	disabled := make(map[string]string)
+	served := make(map[schema.GroupVersion]*metav1.APIResourceList)
+
+	for kind, gvr := range cachedKinds {
+		gv := gvr.GroupVersion()
+
+		resources, ok := served[gv]
+
+		if !ok {
+			var err error
+
+			if resources, err = clientset.Discovery().ServerResourcesForGroupVersion(gv.String()); err != nil {
+				if apierrors.IsNotFound(err) {
+					disabled[kind] = fmt.Sprintf("%s isn't served by the api server", gv.String())
+					continue
+				}
+				resources = nil
+			}
+
+			served[gv] = resources
+		}
+
+		if resources != nil && !hasResource(resources, gvr.Resource) {
+			disabled[kind] = fmt.Sprintf("%s isn't served by the api server", gvr.Resource)
+			continue
+		}
+
+		review, err := clientset.AuthorizationV1().SelfSubjectAccessReviews().Create(context.Background(), &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Verb:     "list",
+					Group:    gvr.Group,
+					Resource: gvr.Resource,
+				},
+			},
+		}, metav1.CreateOptions{})
+
+		if err == nil && !review.Status.Allowed {
+			disabled[kind] = fmt.Sprintf("the service account can't list %s", gvr.Resource)
+		}
+	}
+
+	return disabled
+}
+
+// hasResource returns true if the resource is in the list, subresources aside.
+func hasResource(list *metav1.APIResourceList, resource string) bool {
+	for _, r := range list.APIResources {
+		if r.Name == resource {
+			return true
+		}
+	}
+	return false
+}
+
+// newInformerCache registers an informer for every kind the api server serves and the service
+// account can list, and starts them. Syncing happens in the background, see hasSynced.
+func newInformerCache(clientset kubernetes.Interface, resync time.Duration, stop <-chan struct{}) *informerCache {
+	factory := informers.NewSharedInformerFactory(clientset, resync)
+
+	c := &informerCache{
+		synced:   make(map[string]cache.InformerSynced),
+		disabled: disabledKinds(clientset),
+	}
+
+	apps := factory.Apps().V1()
+	batch := factory.Batch().V1()
+	core := factory.Core().V1()
+	discovery := factory.Discovery().V1()
+
+	if c.watch(kindConfigMaps, core.ConfigMaps().Informer) {
+		c.configMaps = core.ConfigMaps().Lister()
+	}
+	if c.watch(kindCronJobs, batch.CronJobs().Informer) {
+		c.cronJobs = batch.CronJobs().Lister()
+	}
+	if c.watch(kindDaemonSets, apps.DaemonSets().Informer) {
+		c.daemonSets = apps.DaemonSets().Lister()
+	}
+	if c.watch(kindDeployments, apps.Deployments().Informer) {
+		c.deployments = apps.Deployments().Lister()
+	}
+	if c.watch(kindEndpointSlices, discovery.EndpointSlices().Informer) {
+		c.endpointSlices = discovery.EndpointSlices().Lister()
+	}
+	if c.watch(kindEvents, core.Events().Informer) {
+		c.events = core.Events().Lister()
+	}
+	if c.watch(kindJobs, batch.Jobs().Informer) {
+		c.jobs = batch.Jobs().Lister()
+	}
+	if c.watch(kindNamespaces, core.Namespaces().Informer) {
+		c.namespaces = core.Namespaces().Lister()
+	}
+	if c.watch(kindNodes, core.Nodes().Informer) {
+		c.nodes = core.Nodes().Lister()
+	}
+	if c.watch(kindPods, core.Pods().Informer) {
+		c.pods = core.Pods().Lister()
+	}
+	if c.watch(kindReplicaSets, apps.ReplicaSets().Informer) {
+		c.replicaSets = apps.ReplicaSets().Lister()
+	}
+	if c.watch(kindServices, core.Services().Informer) {
+		c.services = core.Services().Lister()
+	}
+	if c.watch(kindStatefulSets, apps.StatefulSets().Informer) {
+		c.statefulSets = apps.StatefulSets().Lister()
+	}
+	if c.watch(kindPersistentVolumeClaims, core.PersistentVolumeClaims().Informer) {
+		c.persistentVolumeClaims = core.PersistentVolumeClaims().Lister()
+	}
+
+	factory.Start(stop)
+
+	return c
+}
+
+// watch registers the informer of the kind unless it's disabled, which leaves reads
+// for the kind to the api server.
+func (c *informerCache) watch(kind string, informer func() cache.SharedIndexInformer) bool {
+	if _, ok := c.disabled[kind]; ok {
+		return false
+	}
+
+	c.synced[kind] = informer().HasSynced
+
+	return true
+}
+
+// hasSynced returns true if reads for the kind can be served from the cache.
+// Safe to call on a nil cache, which is never synced.
+func (c *informerCache) hasSynced(kind string) bool {
+	if c == nil {
+		return false
+	}
+
+	synced, ok := c.synced[kind]
+
+	return ok && synced()
+}
+
+// status returns the sync status of every kind.
+func (c *informerCache) status() (status CacheStatus) {
+	if c == nil {
+		return status
+	}
+
+	status = CacheStatus{
+		Enabled: true,
+		Synced:  true,
+		Kinds:   make(map[string]bool),
+	}
+
+	for kind, synced := range c.synced {
+		status.Kinds[kind] = synced()
+
+		if !optionalKinds[kind] {
+			status.Synced = status.Synced && status.Kinds[kind]
+		}
+	}
+
+	if len(c.disabled) > 0 {
+		status.Disabled = c.disabled
+	}
+
+	return status
+}
+
+// CacheStatus returns the sync status of the informer cache.
+func (k *Client) CacheStatus() (status CacheStatus) {
+	return k.cache.status()
+}
+
+// selector holds parsed label and field selectors from metav1.ListOptions, so
+// the cache filters the same way the api server would.
+type selector struct {
+	labels labels.Selector
+	fields fields.Selector
+}
+
+func newSelector(lo metav1.ListOptions) (s *selector, err error) {
+	s = &selector{
+		labels: labels.Everything(),
+		fields: fields.Everything(),
+	}
+
+	if len(lo.LabelSelector) > 0 {
+		if s.labels, err = labels.Parse(lo.LabelSelector); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(lo.FieldSelector) > 0 {
+		if s.fields, err = fields.ParseSelector(lo.FieldSelector); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// matches returns true if the fields of an object match the field selector. Like the
+// api server, selecting on a field the kind doesn't support is an error.
+func (s *selector) matches(set fields.Set) (bool, error) {
+	for _, r := range s.fields.Requirements() {
+		if _, ok := set[r.Field]; !ok {
+			return false, fmt.Errorf("field label not supported: %s", r.Field)
+		}
+	}
+
+	return s.fields.Matches(set), nil
+}
+
+// objectMetaFields returns the fields every kind can be selected on.
+func objectMetaFields(meta metav1.ObjectMeta) fields.Set {
+	return fields.Set{
+		"metadata.name":      meta.Name,
+		"metadata.namespace": meta.Namespace,
+	}
+}
+
+// podFields returns the fields a pod can be selected on.
+func podFields(pod *v1.Pod) fields.Set {
+	set := objectMetaFields(pod.ObjectMeta)
+	set["spec.nodeName"] = pod.Spec.NodeName
+	set["spec.restartPolicy"] = string(pod.Spec.RestartPolicy)
+	set["spec.schedulerName"] = pod.Spec.SchedulerName
+	set["spec.serviceAccountName"] = pod.Spec.ServiceAccountName
+	set["status.phase"] = string(pod.Status.Phase)
+	set["status.podIP"] = pod.Status.PodIP
+	set["status.nominatedNodeName"] = pod.Status.NominatedNodeName
+	return set
+}
+
+// jobFields returns the fields a job can be selected on.
+func jobFields(job *batchv1.Job) fields.Set {
+	set := objectMetaFields(job.ObjectMeta)
+	set["status.successful"] = fmt.Sprint(job.Status.Succeeded)
+	return set
+}
+
+// replicaSetFields returns the fields a replicaset can be selected on.
+func replicaSetFields(rs *appsv1.ReplicaSet) fields.Set {
+	set := objectMetaFields(rs.ObjectMeta)
+	set["status.replicas"] = fmt.Sprint(rs.Status.Replicas)
+	return set
+}
+
+// less sorts objects the way the api server returns them, by namespace then name.
+func less(a, b metav1.ObjectMeta) bool {
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+	return a.Name < b.Name
+}
+
+// cacheContinue is the continue token of a page from the cache, the last item of the page.
+type cacheContinue struct {
+	Namespace string `json:"ns"`
+	Name      string `json:"n"`
+}
+
+// page narrows a sorted list from the cache to lo.Limit items after the item in lo.Continue,
+// the same way the api server pages. Items can come and go between pages, which only
+// moves the remaining item count.
+func page(list runtime.Object, lo metav1.ListOptions) error {
+	if lo.Limit <= 0 && len(lo.Continue) == 0 {
+		return nil
+	}
+
+	items, err := apimeta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+
+	key := func(i int) metav1.ObjectMeta {
+		m, _ := apimeta.Accessor(items[i])
+		return metav1.ObjectMeta{Namespace: m.GetNamespace(), Name: m.GetName()}
+	}
+
+	start := 0
+
+	if len(lo.Continue) > 0 {
+		last := cacheContinue{}
+
+		b, err := base64.RawURLEncoding.DecodeString(lo.Continue)
+		if err == nil {
+			err = json.Unmarshal(b, &last)
+		}
+		if err != nil {
+			return apierrors.NewResourceExpired("the continue token is no longer valid, start from the beginning")
+		}
+
+		after := metav1.ObjectMeta{Namespace: last.Namespace, Name: last.Name}
+		start = sort.Search(len(items), func(i int) bool { return less(after, key(i)) })
+	}
+
+	end := len(items)
+	if lo.Limit > 0 && int64(end-start) > lo.Limit {
+		end = start + int(lo.Limit)
+	}
+
+	m, err := apimeta.ListAccessor(list)
+	if err != nil {
+		return err
+	}
+
+	if end < len(items) {
+		last := key(end - 1)
+		b, _ := json.Marshal(cacheContinue{Namespace: last.Namespace, Name: last.Name})
+		remaining := int64(len(items) - end)
+
+		m.SetContinue(base64.RawURLEncoding.EncodeToString(b))
+		m.SetRemainingItemCount(&remaining)
+	}
+
+	return apimeta.SetList(list, items[start:end])
+}
+
+// listConfigMaps lists configmaps from the cache. The returned items are copies.
+func (c *informerCache) listConfigMaps(namespace string, lo metav1.ListOptions) (*v1.ConfigMapList, error) {
+	s, err := newSelector(lo)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := c.configMaps.ConfigMaps(namespace).List(s.labels)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &v1.ConfigMapList{Items: []v1.ConfigMap{}}
+
+	for _, item := range items {
+		if ok, err := s.matches(objectMetaFields(item.ObjectMeta)); err != nil {
+			return nil, err
+		} else if ok {
+			list.Items = append(list.Items, *item.DeepCopy())
+		}
+	}
+
+	sort.Slice(list.Items, func(i, j int) bool { return less(list.Items[i].ObjectMeta, list.Items[j].ObjectMeta) })
+
+	return list, page(list, lo)
+}
+
+// listDaemonSets lists daemonsets from the cache. The returned items are copies.
+func (c *informerCache) listDaemonSets(namespace string, lo metav1.ListOptions) (*appsv1.DaemonSetList, error) {
+	s, err := newSelector(lo)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := c.daemonSets.DaemonSets(namespace).List(s.labels)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &appsv1.DaemonSetList{Items: []appsv1.DaemonSet{}}
+
+	for _, item := range items {
+		if ok, err := s.matches(objectMetaFields(item.ObjectMeta)); err != nil {
+			return nil, err
+		} else if ok {
+			list.Items = append(list.Items, *item.DeepCopy())
+		}
+	}
+
+	sort.Slice(list.Items, func(i, j int) bool { return less(list.Items[i].ObjectMeta, list.Items[j].ObjectMeta) })
+
+	return list, page(list, lo)
+}
+
+// listDeployments lists deployments from the cache. The returned items are copies.
+func (c *informerCache) listDeployments(namespace string, lo metav1.ListOptions) (*appsv1.DeploymentList, error) {
+	s, err := newSelector(lo)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := c.deployments.Deployments(namespace).List(s.labels)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &appsv1.DeploymentList{Items: []appsv1.Deployment{}}
+
+	for _, item := range items {
+		if ok, err := s.matches(objectMetaFields(item.ObjectMeta)); err != nil {
+			return nil, err
+		} else if ok {
+			list.Items = append(list.Items, *item.DeepCopy())
+		}
+	}
+
+	sort.Slice(list.Items, func(i, j int) bool { return less(list.Items[i].ObjectMeta, list.Items[j].ObjectMeta) })
+
+	return list, page(list, lo)
+}
+
+// listCronJobs lists cronjobs from the cache. The returned items are copies.
+func (c *informerCache) listCronJobs(namespace string, lo metav1.ListOptions) (*batchv1.CronJobList, error) {
+	s, err := newSelector(lo)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := c.cronJobs.CronJobs(namespace).List(s.labels)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &batchv1.CronJobList{Items: []batchv1.CronJob{}}
+
+	for _, item := range items {
+		if ok, err := s.matches(objectMetaFields(item.ObjectMeta)); err != nil {
+			return nil, err
+		} else if ok {
+			list.Items = append(list.Items, *item.DeepCopy())
+		}
+	}
+
+	sort.Slice(list.Items, func(i, j int) bool { return less(list.Items[i].ObjectMeta, list.Items[j].ObjectMeta) })
+
+	return list, page(list, lo)
+}
+
+// listEndpointSlices lists endpointslices from the cache. The returned items are copies.
+func (c *informerCache) listEndpointSlices(namespace string, lo metav1.ListOptions) (*discoveryv1.EndpointSliceList, error) {
+	s, err := newSelector(lo)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := c.endpointSlices.EndpointSlices(namespace).List(s.labels)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &discoveryv1.EndpointSliceList{Items: []discoveryv1.EndpointSlice{}}
+
+	for _, item := range items {
+		if ok, err := s.matches(objectMetaFields(item.ObjectMeta)); err != nil {
+			return nil, err
+		} else if ok {
+			list.Items = append(list.Items, *item.DeepCopy())
+		}
+	}
+
+	sort.Slice(list.Items, func(i, j int) bool { return less(list.Items[i].ObjectMeta, list.Items[j].ObjectMeta) })
+
+	return list, page(list, lo)
+}
+
+// listEvents lists events from the cache. The returned items are copies.
+func (c *informerCache) listEvents(namespace string, lo metav1.ListOptions) (*v1.EventList, error) {
+	s, err := newSelector(lo)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := c.events.Events(namespace).List(s.labels)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &v1.EventList{Items: []v1.Event{}}
+
+	for _, item := range items {
+		if ok, err := s.matches(eventFields(item)); err != nil {
+			return nil, err
+		} else if ok {
+			list.Items = append(list.Items, *item.DeepCopy())
+		}
+	}
+
+	sort.Slice(list.Items, func(i, j int) bool { return less(list.Items[i].ObjectMeta, list.Items[j].ObjectMeta) })
+
+	return list, page(list, lo)
+}
+
+// listJobs lists jobs from the cache. The returned items are copies.
+func (c *informerCache) listJobs(namespace string, lo metav1.ListOptions) (*batchv1.JobList, error) {
+	s, err := newSelector(lo)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := c.jobs.Jobs(namespace).List(s.labels)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &batchv1.JobList{Items: []batchv1.Job{}}
+
+	for _, item := range items {
+		if ok, err := s.matches(jobFields(item)); err != nil {
+			return nil, err
+		} else if ok {
+			list.Items = append(list.Items, *item.DeepCopy())
+		}
+	}
+
+	sort.Slice(list.Items, func(i, j int) bool { return less(list.Items[i].ObjectMeta, list.Items[j].ObjectMeta) })
+
+	return list, page(list, lo)
+}
+
+// listNamespaces lists namespaces from the cache. The returned items are copies.
+func (c *informerCache) listNamespaces(lo metav1.ListOptions) (*v1.NamespaceList, error) {
+	s, err := newSelector(lo)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := c.namespaces.List(s.labels)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &v1.NamespaceList{Items: []v1.Namespace{}}
+
+	for _, item := range items {
+		set := objectMetaFields(item.ObjectMeta)
+		set["status.phase"] = string(item.Status.Phase)
+
+		if ok, err := s.matches(set); err != nil {
+			return nil, err
+		} else if ok {
+			list.Items = append(list.Items, *item.DeepCopy())
+		}
+	}
+
+	sort.Slice(list.Items, func(i, j int) bool { return less(list.Items[i].ObjectMeta, list.Items[j].ObjectMeta) })
+
+	return list, page(list, lo)
+}
+
+// listNodes lists nodes from the cache. The returned items are copies.
+func (c *informerCache) listNodes(lo metav1.ListOptions) (*v1.NodeList, error) {
+	s, err := newSelector(lo)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := c.nodes.List(s.labels)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &v1.NodeList{Items: []v1.Node{}}
+
+	for _, item := range items {
+		if ok, err := s.matches(nodeFields(item)); err != nil {
+			return nil, err
+		} else if ok {
+			list.Items = append(list.Items, *item.DeepCopy())
+		}
+	}
+
+	sort.Slice(list.Items, func(i, j int) bool { return less(list.Items[i].ObjectMeta, list.Items[j].ObjectMeta) })
+
+	return list, page(list, lo)
+}
+
+// listPods lists pods from the cache. The returned items are copies.
+func (c *informerCache) listPods(namespace string, lo metav1.ListOptions) (*v1.PodList, error) {
+	s, err := newSelector(lo)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := c.pods.Pods(namespace).List(s.labels)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &v1.PodList{Items: []v1.Pod{}}
+
+	for _, item := range items {
+		if ok, err := s.matches(podFields(item)); err != nil {
+			return nil, err
+		} else if ok {
+			list.Items = append(list.Items, *item.DeepCopy())
+		}
+	}
+
+	sort.Slice(list.Items, func(i, j int) bool { return less(list.Items[i].ObjectMeta, list.Items[j].ObjectMeta) })
+
+	return list, page(list, lo)
+}
+
+// listReplicaSets lists replicasets from the cache. The returned items are copies.
+func (c *informerCache) listReplicaSets(namespace string, lo metav1.ListOptions) (*appsv1.ReplicaSetList, error) {
+	s, err := newSelector(lo)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := c.replicaSets.ReplicaSets(namespace).List(s.labels)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &appsv1.ReplicaSetList{Items: []appsv1.ReplicaSet{}}
+
+	for _, item := range items {
+		if ok, err := s.matches(replicaSetFields(item)); err != nil {
+			return nil, err
+		} else if ok {
+			list.Items = append(list.Items, *item.DeepCopy())
+		}
+	}
+
+	sort.Slice(list.Items, func(i, j int) bool { return less(list.Items[i].ObjectMeta, list.Items[j].ObjectMeta) })
+
+	return list, page(list, lo)
+}
+
+// listServices lists services from the cache. The returned items are copies.
+func (c *informerCache) listServices(namespace string, lo metav1.ListOptions) (*v1.ServiceList, error) {
+	s, err := newSelector(lo)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := c.services.Services(namespace).List(s.labels)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &v1.ServiceList{Items: []v1.Service{}}
+
+	for _, item := range items {
+		if ok, err := s.matches(objectMetaFields(item.ObjectMeta)); err != nil {
+			return nil, err
+		} else if ok {
+			list.Items = append(list.Items, *item.DeepCopy())
+		}
+	}
+
+	sort.Slice(list.Items, func(i, j int) bool { return less(list.Items[i].ObjectMeta, list.Items[j].ObjectMeta) })
+
+	return list, page(list, lo)
+}
+
+// listStatefulSets lists statefulsets from the cache. The returned items are copies.
+func (c *informerCache) listStatefulSets(namespace string, lo metav1.ListOptions) (*appsv1.StatefulSetList, error) {
+	s, err := newSelector(lo)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := c.statefulSets.StatefulSets(namespace).List(s.labels)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &appsv1.StatefulSetList{Items: []appsv1.StatefulSet{}}
+
+	for _, item := range items {
+		if ok, err := s.matches(objectMetaFields(item.ObjectMeta)); err != nil {
+			return nil, err
+		} else if ok {
+			list.Items = append(list.Items, *item.DeepCopy())
+		}
+	}
+
+	sort.Slice(list.Items, func(i, j int) bool { return less(list.Items[i].ObjectMeta, list.Items[j].ObjectMeta) })
+
+	return list, page(list, lo)
+}
+
+// listPersistentVolumeClaims lists persistentvolumeclaims from the cache. The returned items are copies.
+func (c *informerCache) listPersistentVolumeClaims(namespace string, lo metav1.ListOptions) (*v1.PersistentVolumeClaimList, error) {
+	s, err := newSelector(lo)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := c.persistentVolumeClaims.PersistentVolumeClaims(namespace).List(s.labels)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &v1.PersistentVolumeClaimList{Items: []v1.PersistentVolumeClaim{}}
+
+	for _, item := range items {
+		if ok, err := s.matches(objectMetaFields(item.ObjectMeta)); err != nil {
+			return nil, err
+		} else if ok {
+			list.Items = append(list.Items, *item.DeepCopy())
+		}
+	}
+
+	sort.Slice(list.Items, func(i, j int) bool { return less(list.Items[i].ObjectMeta, list.Items[j].ObjectMeta) })
+
+	return list, page(list, lo)
+}
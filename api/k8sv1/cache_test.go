@@ -0,0 +1,288 @@
+package k8sv1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kubelens/kubelens/api/config"
+	logfakes "github.com/kubelens/kubelens/api/log/fakes"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	metrics "k8s.io/metrics/pkg/client/clientset/versioned"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+)
+
+type fakeWrapper struct {
+	clientset kubernetes.Interface
+	// metrics defaults to a metrics api without any metrics
+	metrics metrics.Interface
+}
+
+func (f *fakeWrapper) GetClientSet() (clientset kubernetes.Interface, err error) {
+	return f.clientset, nil
+}
+
+func (f *fakeWrapper) GetMetricsClientSet() (clientset metrics.Interface, err error) {
+	if f.metrics == nil {
+		return metricsfake.NewSimpleClientset(), nil
+	}
+	return f.metrics, nil
+}
+
+// setupCachedClient returns a cached client for the objects once every kind has synced.
+func setupCachedClient(t *testing.T, objects ...runtime.Object) Clienter {
+	return setupCachedClientset(t, newCacheClientset(objects...))
+}
+
+// newCacheClientset returns a clientset for the objects, serving every kind and allowing
+// the service account to list them.
+func newCacheClientset(objects ...runtime.Object) *fake.Clientset {
+	clientset := fake.NewSimpleClientset(objects...)
+	clientset.Resources = cacheTestResources(nil)
+
+	clientset.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		review.Status.Allowed = true
+		return true, review, nil
+	})
+
+	return clientset
+}
+
+// setupCachedClientset returns a cached client for the clientset once every watched kind has synced.
+func setupCachedClientset(t *testing.T, clientset *fake.Clientset) Clienter {
+	config.Set("../testdata/mock_config.json")
+
+	stop := make(chan struct{})
+	t.Cleanup(func() { close(stop) })
+
+	c, err := NewCached(&fakeWrapper{clientset: clientset}, 0, stop)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	synced := func() bool {
+		status := c.CacheStatus()
+		for _, s := range status.Kinds {
+			status.Synced = status.Synced && s
+		}
+		return status.Synced
+	}
+
+	for i := 0; !synced(); i++ {
+		if i > 50 {
+			t.Fatal("timed out waiting for the informer cache to sync")
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	return c
+}
+
+// cacheTestResources returns the discovery of every cached kind except the given ones.
+func cacheTestResources(except map[string]bool) []*metav1.APIResourceList {
+	served := make(map[string]*metav1.APIResourceList)
+	lists := []*metav1.APIResourceList{}
+
+	for kind, gvr := range cachedKinds {
+		gv := gvr.GroupVersion().String()
+
+		if served[gv] == nil {
+			served[gv] = &metav1.APIResourceList{GroupVersion: gv}
+			lists = append(lists, served[gv])
+		}
+
+		if !except[kind] {
+			served[gv].APIResources = append(served[gv].APIResources, metav1.APIResource{Name: gvr.Resource})
+		}
+	}
+
+	return lists
+}
+
+func cacheTestObjects() []runtime.Object {
+	lbl := map[string]string{"app": "cached"}
+
+	return []runtime.Object{
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "one"}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "two"}},
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "cached-b", Namespace: "one", Labels: lbl},
+			Spec:       v1.PodSpec{NodeName: "node-1"},
+			Status:     v1.PodStatus{Phase: v1.PodRunning},
+		},
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "cached-a", Namespace: "one", Labels: lbl},
+			Spec:       v1.PodSpec{NodeName: "node-2"},
+			Status:     v1.PodStatus{Phase: v1.PodPending},
+		},
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "two", Labels: map[string]string{"app": "other"}}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "cached", Namespace: "one", Labels: lbl}},
+		&v1.Service{ObjectMeta: metav1.ObjectMeta{Name: "cached", Namespace: "one", Labels: lbl}},
+	}
+}
+
+func TestNewCachedClientSetError(t *testing.T) {
+	_, err := NewCached(&mockWrapper{fail: true}, 0, nil)
+
+	assert.NotNil(t, err)
+}
+
+func TestCacheStatusDisabled(t *testing.T) {
+	c := New(&mockWrapper{})
+
+	status := c.CacheStatus()
+
+	assert.False(t, status.Enabled)
+	assert.False(t, status.Synced)
+}
+
+func TestCacheStatusSynced(t *testing.T) {
+	c := setupCachedClient(t, cacheTestObjects()...)
+
+	status := c.CacheStatus()
+
+	assert.True(t, status.Enabled)
+	assert.True(t, status.Kinds[kindPods])
+	assert.True(t, status.Kinds[kindNamespaces])
+	assert.Empty(t, status.Disabled)
+}
+
+func TestCacheStatusDisabledKinds(t *testing.T) {
+	clientset := fake.NewSimpleClientset(cacheTestObjects()...)
+
+	// batch/v1 without cronjobs, as served before 1.21
+	clientset.Resources = cacheTestResources(map[string]bool{kindCronJobs: true})
+
+	clientset.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		review.Status.Allowed = review.Spec.ResourceAttributes.Resource != kindEndpointSlices
+		return true, review, nil
+	})
+
+	c := setupCachedClientset(t, clientset)
+
+	status := c.CacheStatus()
+
+	assert.True(t, status.Synced)
+	assert.True(t, status.Kinds[kindJobs])
+	assert.NotContains(t, status.Kinds, kindCronJobs)
+	assert.NotContains(t, status.Kinds, kindEndpointSlices)
+	assert.Equal(t, map[string]string{
+		kindCronJobs:       "cronjobs isn't served by the api server",
+		kindEndpointSlices: "the service account can't list endpointslices",
+	}, status.Disabled)
+
+	// reads for a disabled kind go to the api server
+	r, _, err := c.CronJobs(CronJobOptions{
+		Logger:    &logfakes.Logger{},
+		Namespace: "one",
+		Context:   context.Background(),
+	})
+
+	assert.Nil(t, err)
+	assert.Empty(t, r)
+}
+
+func TestCachedPods(t *testing.T) {
+	c := setupCachedClient(t, cacheTestObjects()...)
+
+	r, _, err := c.Pods(PodOptions{
+		Logger:     &logfakes.Logger{},
+		Namespace:  "one",
+		LinkedName: "cached",
+		Context:    context.Background(),
+	})
+
+	assert.Nil(t, err)
+	assert.Len(t, r, 2)
+	// sorted the same as the api server
+	assert.Equal(t, "cached-a", r[0].Name)
+	assert.Equal(t, "cached-b", r[1].Name)
+}
+
+func TestCachedPod(t *testing.T) {
+	c := setupCachedClient(t, cacheTestObjects()...)
+
+	r, err := c.Pod(PodOptions{
+		Logger:    &logfakes.Logger{},
+		Namespace: "two",
+		Name:      "other",
+		Context:   context.Background(),
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "other", r.Name)
+	assert.Equal(t, "other", r.LinkedName)
+}
+
+func TestCachedPodsAreCopies(t *testing.T) {
+	c := setupCachedClient(t, cacheTestObjects()...)
+
+	options := PodOptions{
+		Logger:    &logfakes.Logger{},
+		Namespace: "two",
+		Name:      "other",
+		Context:   context.Background(),
+	}
+
+	r, _ := c.Pod(options)
+	r.Pod.Labels["app"] = "changed"
+
+	r, _ = c.Pod(options)
+	assert.Equal(t, "other", r.Pod.Labels["app"])
+}
+
+func TestCacheListPodsFieldSelector(t *testing.T) {
+	c := setupCachedClient(t, cacheTestObjects()...).(*Client)
+
+	list, err := c.cache.listPods("", metav1.ListOptions{FieldSelector: "status.phase=Running"})
+
+	assert.Nil(t, err)
+	assert.Len(t, list.Items, 1)
+	assert.Equal(t, "cached-b", list.Items[0].Name)
+
+	list, err = c.cache.listPods("", metav1.ListOptions{FieldSelector: "spec.nodeName=node-2"})
+
+	assert.Nil(t, err)
+	assert.Len(t, list.Items, 1)
+	assert.Equal(t, "cached-a", list.Items[0].Name)
+}
+
+func TestCacheListUnsupportedFieldSelector(t *testing.T) {
+	c := setupCachedClient(t, cacheTestObjects()...).(*Client)
+
+	_, err := c.cache.listDeployments("", metav1.ListOptions{FieldSelector: "status.phase=Running"})
+
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "field label not supported")
+}
+
+func TestCacheListInvalidLabelSelector(t *testing.T) {
+	c := setupCachedClient(t, cacheTestObjects()...).(*Client)
+
+	_, err := c.cache.listServices("", metav1.ListOptions{LabelSelector: "app in (a"})
+
+	assert.NotNil(t, err)
+}
+
+func TestCachedOverviews(t *testing.T) {
+	c := setupCachedClient(t, cacheTestObjects()...)
+
+	r, _, err := c.Overviews(OverviewOptions{
+		Logger:  &logfakes.Logger{},
+		Context: context.Background(),
+	})
+
+	assert.Nil(t, err)
+	assert.Len(t, r, 2)
+}
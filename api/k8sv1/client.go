@@ -28,6 +28,7 @@ import (
 	"io"
 
 	"github.com/kubelens/kubelens/api/errs"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 const defaultErrorMessage = "Error retrieving container info, please contact your admin."
@@ -41,43 +42,77 @@ type Clienter interface {
 	// For each namespace, Kubernetes Kinds are searched for the type of application, e.g. Service, DaemonSet, etc.
 	Overview(options OverviewOptions) (overviews *Overview, apiErr *errs.APIError)
 	// Overviews returns an list of application overviews with high level info such as name & namespace
-	Overviews(options OverviewOptions) (overviews []Overview, apiErr *errs.APIError)
+	Overviews(options OverviewOptions) (overviews []Overview, meta ListMeta, apiErr *errs.APIError)
+	// Graph returns the objects related to a linked name through ownerReferences and service selectors.
+	Graph(options GraphOptions) (graph *Graph, apiErr *errs.APIError)
 	// Pod returns the pod found by name or labels.
 	Pod(options PodOptions) (overview *PodOverview, apiErr *errs.APIError)
 	// Pods returns a list of pods given filter options
-	Pods(options PodOptions) (overviews []PodOverview, apiErr *errs.APIError)
+	Pods(options PodOptions) (overviews []PodOverview, meta ListMeta, apiErr *errs.APIError)
+	// WatchPods watches the linked or selected pods, starting with an added event for every existing pod
+	WatchPods(options PodOptions) (w watch.Interface, apiErr *errs.APIError)
+	// PodDiagnosis returns the problems found with a pod, most severe first.
+	PodDiagnosis(options PodOptions) (diagnosis *Diagnosis, apiErr *errs.APIError)
 	// Service returns the service found by name or labels.
 	Service(options ServiceOptions) (overview *ServiceOverview, apiErr *errs.APIError)
 	// Services returns a list of services given filter options
-	Services(options ServiceOptions) (overviews []ServiceOverview, apiErr *errs.APIError)
+	Services(options ServiceOptions) (overviews []ServiceOverview, meta ListMeta, apiErr *errs.APIError)
 	// Deployment returns the deployment found by name or labels.
 	Deployment(options DeploymentOptions) (overview *DeploymentOverview, apiErr *errs.APIError)
 	// Deployments returns a list of deployments given filter options
-	Deployments(options DeploymentOptions) (overviews []DeploymentOverview, apiErr *errs.APIError)
+	Deployments(options DeploymentOptions) (overviews []DeploymentOverview, meta ListMeta, apiErr *errs.APIError)
+	// DeploymentHistory returns the revisions of a deployment reconstructed from its replicasets, newest first.
+	DeploymentHistory(options DeploymentOptions) (history []DeploymentRevision, apiErr *errs.APIError)
+	// DeploymentDiff returns the difference between the pod templates of two revisions of a deployment.
+	DeploymentDiff(options DeploymentDiffOptions) (diff *DeploymentDiff, apiErr *errs.APIError)
 	// DaemonSet returns the daemonset found by name or labels.
 	DaemonSet(options DaemonSetOptions) (overview *DaemonSetOverview, apiErr *errs.APIError)
 	// DaemonSets returns a list of daemonsets given filter options
-	DaemonSets(options DaemonSetOptions) (overviews []DaemonSetOverview, apiErr *errs.APIError)
+	DaemonSets(options DaemonSetOptions) (overviews []DaemonSetOverview, meta ListMeta, apiErr *errs.APIError)
+	// StatefulSet returns the statefulset found by name or labels.
+	StatefulSet(options StatefulSetOptions) (overview *StatefulSetOverview, apiErr *errs.APIError)
+	// StatefulSets returns a list of statefulsets given filter options
+	StatefulSets(options StatefulSetOptions) (overviews []StatefulSetOverview, meta ListMeta, apiErr *errs.APIError)
 	// Job returns the job found by name or labels.
 	Job(options JobOptions) (overview *JobOverview, apiErr *errs.APIError)
 	// Jobs returns a list of jobs given filter options
-	Jobs(options JobOptions) (overviews []JobOverview, apiErr *errs.APIError)
+	Jobs(options JobOptions) (overviews []JobOverview, meta ListMeta, apiErr *errs.APIError)
+	// CronJob returns the cronjob found by name or labels.
+	CronJob(options CronJobOptions) (overview *CronJobOverview, apiErr *errs.APIError)
+	// CronJobs returns a list of cronjobs given filter options
+	CronJobs(options CronJobOptions) (overviews []CronJobOverview, meta ListMeta, apiErr *errs.APIError)
 	// ReplicaSet returns the replicaset found by name or labels.
 	ReplicaSet(options ReplicaSetOptions) (overview *ReplicaSetOverview, apiErr *errs.APIError)
 	// ReplicaSets returns a list of replicasets given filter options
-	ReplicaSets(options ReplicaSetOptions) (overviews []ReplicaSetOverview, apiErr *errs.APIError)
+	ReplicaSets(options ReplicaSetOptions) (overviews []ReplicaSetOverview, meta ListMeta, apiErr *errs.APIError)
+	// ConfigMap returns the configmap found by name or labels.
+	ConfigMap(options ConfigMapOptions) (overview *ConfigMapOverview, apiErr *errs.APIError)
+	// ConfigMaps returns a list of configmaps given filter options
+	ConfigMaps(options ConfigMapOptions) (overviews []ConfigMapOverview, meta ListMeta, apiErr *errs.APIError)
+	// Events returns a list of events given filter options, most recent first.
+	Events(options EventOptions) (overviews []EventOverview, meta ListMeta, apiErr *errs.APIError)
+	// Node returns the node found by name.
+	Node(options NodeOptions) (overview *NodeOverview, apiErr *errs.APIError)
+	// Nodes returns a list of nodes
+	Nodes(options NodeOptions) (overviews []NodeOverview, meta ListMeta, apiErr *errs.APIError)
 	// Logs returns a list of all logs for pods
 	Logs(options LogOptions) (logs Log, apiErr *errs.APIError)
+	// LinkedLogs returns the logs of every container of the linked or selected pods, each line prefixed with its pod/container
+	LinkedLogs(options LogOptions) (logs Log, apiErr *errs.APIError)
 	// ReadLogs returns an io.ReadCloser to live stream logs for a pod
 	ReadLogs(options LogOptions) (rc io.ReadCloser, apiErr *errs.APIError)
+	// CacheStatus returns the sync status of the informer cache, see NewCached.
+	CacheStatus() (status CacheStatus)
 }
 
 // Client is the wrapper for kubernetes go client commands
 type Client struct {
 	wrapper Wrapper
+	// cache is nil unless created with NewCached
+	cache *informerCache
 }
 
 // New returns a new instance of Client
 func New(w Wrapper) Clienter {
-	return &Client{w}
+	return &Client{wrapper: w}
 }
@@ -1,6 +1,11 @@
 package k8sv1
 
-import "github.com/kubelens/kubelens/api/config"
+import (
+	"github.com/kubelens/kubelens/api/config"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	metrics "k8s.io/metrics/pkg/client/clientset/versioned"
+)
 
 func setupClient(ns, n string, fail, innerFail bool) Clienter {
 	config.Set("../testdata/mock_config.json")
@@ -13,3 +18,16 @@ func setupClient(ns, n string, fail, innerFail bool) Clienter {
 	}
 	return New(w)
 }
+
+// setupFakeClient returns a client reading the objects from a fake clientset.
+func setupFakeClient(objects ...runtime.Object) Clienter {
+	return setupFakeMetricsClient(nil, objects...)
+}
+
+// setupFakeMetricsClient returns a client reading the objects from a fake clientset and
+// usage from m, or from a metrics api without any metrics if nil.
+func setupFakeMetricsClient(m metrics.Interface, objects ...runtime.Object) Clienter {
+	config.Set("../testdata/mock_config.json")
+
+	return New(&fakeWrapper{clientset: fake.NewSimpleClientset(objects...), metrics: m})
+}
@@ -0,0 +1,143 @@
+package k8sv1
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/kubelens/kubelens/api/errs"
+)
+
+// clusterCheckTimeout is how long a cluster has to respond to a reachability check.
+const clusterCheckTimeout = 5 * time.Second
+
+type contextKey string
+
+var clusterKey = contextKey("cluster")
+
+// Cluster is a named kubernetes cluster and the client used to query it.
+type Cluster struct {
+	// the name of the cluster, used in the /clusters/{name}/ route prefix
+	Name string
+	// the client for the cluster
+	Client Clienter
+}
+
+// ClusterStatus holds the reachability of a registered cluster.
+type ClusterStatus struct {
+	// the name of the cluster
+	Name string `json:"name"`
+	// true if this is the cluster served by routes without the cluster prefix
+	Default bool `json:"default"`
+	// true if the cluster responded to a sanity check
+	Reachable bool `json:"reachable"`
+	// the sanity check error if the cluster isn't reachable
+	Error string `json:"error,omitempty"`
+}
+
+// Registry interfaces registry
+type Registry interface {
+	// Get returns the client for the named cluster. An empty name returns the default cluster.
+	Get(name string) (k8Client Clienter, ok bool)
+	// Clusters returns the reachability status of every registered cluster, in registration order.
+	Clusters() (statuses []ClusterStatus)
+	// Names returns the names of every registered cluster, in registration order.
+	Names() (names []string)
+}
+
+// registry holds a set of named clusters.
+type registry struct {
+	names   []string
+	clients map[string]Clienter
+}
+
+// NewRegistry returns a new instance of registry. The first cluster is the default cluster.
+func NewRegistry(clusters ...Cluster) Registry {
+	r := &registry{
+		names:   []string{},
+		clients: make(map[string]Clienter),
+	}
+
+	for _, c := range clusters {
+		if _, ok := r.clients[c.Name]; ok {
+			continue
+		}
+		r.names = append(r.names, c.Name)
+		r.clients[c.Name] = c.Client
+	}
+
+	return r
+}
+
+// Get returns the client for the named cluster. An empty name returns the default cluster.
+func (r *registry) Get(name string) (k8Client Clienter, ok bool) {
+	if len(name) == 0 {
+		if len(r.names) == 0 {
+			return nil, false
+		}
+		name = r.names[0]
+	}
+
+	k8Client, ok = r.clients[name]
+
+	return k8Client, ok
+}
+
+// Names returns a copy of the names, the first is the default cluster.
+func (r *registry) Names() (names []string) {
+	return append([]string{}, r.names...)
+}
+
+// Clusters checks every cluster concurrently, so one unreachable cluster
+// only holds up the response by clusterCheckTimeout.
+func (r *registry) Clusters() (statuses []ClusterStatus) {
+	statuses = make([]ClusterStatus, len(r.names))
+
+	wg := sync.WaitGroup{}
+
+	wg.Add(len(r.names))
+
+	for i, name := range r.names {
+		go func(index int, name string) {
+			defer wg.Done()
+
+			statuses[index] = ClusterStatus{
+				Name:    name,
+				Default: index == 0,
+			}
+
+			done := make(chan *errs.APIError, 1)
+
+			go func() {
+				done <- r.clients[name].SanityCheck()
+			}()
+
+			select {
+			case apiErr := <-done:
+				if apiErr != nil {
+					statuses[index].Error = apiErr.Message
+					return
+				}
+				statuses[index].Reachable = true
+			case <-time.After(clusterCheckTimeout):
+				statuses[index].Error = "timed out waiting for a response from the cluster"
+			}
+		}(i, name)
+	}
+
+	wg.Wait()
+
+	return statuses
+}
+
+// NewClusterContext adds the name of the cluster a request is scoped to to context
+func NewClusterContext(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, clusterKey, name)
+}
+
+// ClusterFromContext retrieves the name of the cluster a request is scoped to,
+// an empty string is returned if the request isn't scoped to a cluster.
+func ClusterFromContext(ctx context.Context) string {
+	name, _ := ctx.Value(clusterKey).(string)
+	return name
+}
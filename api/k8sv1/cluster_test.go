@@ -0,0 +1,68 @@
+package k8sv1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistryGet(t *testing.T) {
+	one := setupClient("default", "test", true, false)
+	two := setupClient("default", "test", true, false)
+
+	r := NewRegistry(Cluster{Name: "one", Client: one}, Cluster{Name: "two", Client: two})
+
+	c, ok := r.Get("")
+	assert.True(t, ok)
+	assert.Equal(t, one, c)
+
+	c, ok = r.Get("two")
+	assert.True(t, ok)
+	assert.Equal(t, two, c)
+
+	_, ok = r.Get("three")
+	assert.False(t, ok)
+}
+
+func TestRegistryGetEmpty(t *testing.T) {
+	r := NewRegistry()
+
+	_, ok := r.Get("")
+	assert.False(t, ok)
+}
+
+func TestRegistryNames(t *testing.T) {
+	r := NewRegistry(
+		Cluster{Name: "one", Client: setupClient("default", "test", true, false)},
+		Cluster{Name: "two", Client: setupClient("default", "test", true, false)},
+		Cluster{Name: "one", Client: setupClient("default", "test", true, false)},
+	)
+
+	assert.Equal(t, []string{"one", "two"}, r.Names())
+}
+
+func TestRegistryClusters(t *testing.T) {
+	r := NewRegistry(
+		Cluster{Name: "up", Client: setupClient("default", "test", false, false)},
+		Cluster{Name: "down", Client: setupClient("default", "test", true, false)},
+	)
+
+	statuses := r.Clusters()
+
+	assert.Len(t, statuses, 2)
+	assert.Equal(t, "up", statuses[0].Name)
+	assert.True(t, statuses[0].Default)
+	assert.True(t, statuses[0].Reachable)
+	assert.Equal(t, "down", statuses[1].Name)
+	assert.False(t, statuses[1].Default)
+	assert.False(t, statuses[1].Reachable)
+	assert.Contains(t, statuses[1].Error, "GetClientSet Test Error")
+}
+
+func TestClusterContext(t *testing.T) {
+	ctx := context.Background()
+
+	assert.Equal(t, "", ClusterFromContext(ctx))
+	assert.Equal(t, "other", ClusterFromContext(NewClusterContext(ctx, "other")))
+}
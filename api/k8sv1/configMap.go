@@ -8,18 +8,21 @@ import (
 	klog "github.com/kubelens/kubelens/api/log"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // ConfigMapOptions .
 type ConfigMapOptions struct {
 	/// the name (optional)
 	Name string `json:"name"`
-	// the value from the label "app=NAME", corresponds to config.LabelKeyLink
+	// the value of the first link label the object has, e.g. "app=NAME", see config.LinkKeys
 	LinkedName string `json:"linkedName"`
 	// namespace to filter on
 	Namespace string `json:"namespace"`
 	// the labels to match kinds
 	Labels map[string]string `json:"labels"`
+	// selectors and pagination narrowing a list
+	ListOptions
 	// logger instance
 	Logger klog.Logger
 	// Context .
@@ -29,25 +32,43 @@ type ConfigMapOptions struct {
 type ConfigMapOverview struct {
 	/// the name
 	Name string `json:"name"`
-	// the value from the label "app=NAME", corresponds to config.LabelKeyLink
+	// the value of the first link label the object has, e.g. "app=NAME", see config.LinkKeys
 	LinkedName string `json:"linkedName"`
 	// the namespace
 	Namespace string `json:"namespace"`
+	// the pods referencing the configmap
+	Consumers []ConfigMapConsumer `json:"consumers"`
+	// the number of fields redacted, see config.Redaction
+	Redacted int `json:"redacted,omitempty"`
 	// the full configmap
 	ConfigMap *v1.ConfigMap `json:"configMap,omitempty"`
 }
 
-// ConfigMap returns a configmap given filter options
-func (k *Client) ConfigMap(options ConfigMapOptions) (overview *ConfigMapOverview, apiErr *errs.APIError) {
+// ConfigMapConsumer is a reference to a configmap from a pod.
+type ConfigMapConsumer struct {
+	// the name of the pod
+	Pod string `json:"pod"`
+	// the container referencing the configmap, empty for volumes
+	Container string `json:"container,omitempty"`
+	// how the configmap is referenced, see the ConfigMapRef constants
+	Via string `json:"via"`
+	// the referenced key for valueFrom, or the name of the volume
+	Key string `json:"key,omitempty"`
+	// true if the pod can start without the configmap
+	Optional bool `json:"optional"`
+}
 
-	clientset, err := k.wrapper.GetClientSet()
+// the ways a pod can reference a configmap
+const (
+	ConfigMapRefEnvFrom   = "envFrom"
+	ConfigMapRefValueFrom = "valueFrom"
+	ConfigMapRefVolume    = "volume"
+)
 
-	if err != nil {
-		klog.Trace()
-		return nil, errs.InternalServerError(err.Error())
-	}
+// ConfigMap returns a configmap given filter options
+func (k *Client) ConfigMap(options ConfigMapOptions) (overview *ConfigMapOverview, apiErr *errs.APIError) {
 
-	list, err := clientset.CoreV1().ConfigMaps(options.Namespace).List(options.Context, metav1.ListOptions{
+	list, err := k.listConfigMaps(options.Context, options.Namespace, metav1.ListOptions{
 		FieldSelector: fmt.Sprintf("metadata.name=%s", options.Name),
 	})
 
@@ -57,11 +78,15 @@ func (k *Client) ConfigMap(options ConfigMapOptions) (overview *ConfigMapOvervie
 	}
 
 	if list != nil && len(list.Items) > 0 {
+		pods := k.configMapPods(options.Context, list.Items[0].Namespace)
+
 		for _, item := range list.Items {
 			return &ConfigMapOverview{
 				Name:       item.Name,
-				LinkedName: getLinkedName(item.Labels),
+				LinkedName: getLinkedName(item.Namespace, item.Labels),
 				Namespace:  item.Namespace,
+				Consumers:  configMapConsumers(&item, pods),
+				Redacted:   redact(&item),
 				ConfigMap:  &item,
 			}, nil
 		}
@@ -70,41 +95,138 @@ func (k *Client) ConfigMap(options ConfigMapOptions) (overview *ConfigMapOvervie
 }
 
 // ConfigMaps returns a list ofconfigmaps given filter options
-func (k *Client) ConfigMaps(options ConfigMapOptions) (overviews []ConfigMapOverview, apiErr *errs.APIError) {
+func (k *Client) ConfigMaps(options ConfigMapOptions) (overviews []ConfigMapOverview, meta ListMeta, apiErr *errs.APIError) {
 	overviews = []ConfigMapOverview{}
-	clientset, err := k.wrapper.GetClientSet()
+	lo, apiErr := options.listOptions(objectMetaFields(metav1.ObjectMeta{}))
 
-	if err != nil {
-		klog.Trace()
-		return nil, errs.InternalServerError(err.Error())
+	if apiErr != nil {
+		return nil, meta, apiErr
 	}
 
-	cml := clientset.CoreV1().ConfigMaps(options.Namespace)
-
-	if cml == nil {
-		return nil, nil
-	}
-
-	lo := metav1.ListOptions{}
-	if len(options.LinkedName) > 0 {
-		lo.LabelSelector = generateLabelSelector(options.LinkedName)
-	}
-	list, err := cml.List(options.Context, lo)
+	obj, err := listLinked(options.LinkedName, lo, func(lo metav1.ListOptions) (runtime.Object, error) {
+		return k.listConfigMaps(options.Context, options.Namespace, lo)
+	})
 
 	if err != nil {
 		klog.Trace()
-		return nil, errs.InternalServerError(err.Error())
+		return nil, meta, errs.InternalServerError(err.Error())
 	}
 
+	list := obj.(*v1.ConfigMapList)
+
 	if list != nil && len(list.Items) > 0 {
+		pods := k.configMapPods(options.Context, options.Namespace)
+
 		for _, item := range list.Items {
+			// the overview keeps a pointer to item
+			item := item
+
 			overviews = append(overviews, ConfigMapOverview{
 				Name:       item.Name,
-				LinkedName: getLinkedName(item.Labels),
+				LinkedName: getLinkedName(item.Namespace, item.Labels),
 				Namespace:  item.Namespace,
+				Consumers:  configMapConsumers(&item, pods),
+				Redacted:   redact(&item),
 				ConfigMap:  &item,
 			})
 		}
 	}
-	return overviews, nil
+	return overviews, newListMeta(list.ListMeta), nil
+}
+
+// configMapPods lists the pods in a namespace. Consumers are informational, so
+// if pods can't be listed the configmaps are returned without them.
+func (k *Client) configMapPods(ctx context.Context, namespace string) []v1.Pod {
+	list, err := k.listPods(ctx, namespace, metav1.ListOptions{})
+
+	if err != nil {
+		klog.Trace()
+		return nil
+	}
+
+	return list.Items
+}
+
+// configMapConsumers returns every reference to the configmap from the pods'
+// containers, init containers and volumes, including projected volumes.
+func configMapConsumers(cm *v1.ConfigMap, pods []v1.Pod) (consumers []ConfigMapConsumer) {
+	consumers = []ConfigMapConsumer{}
+
+	for _, pod := range pods {
+		if pod.Namespace != cm.Namespace {
+			continue
+		}
+
+		containers := append(append([]v1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+
+		for _, c := range containers {
+			for _, ef := range c.EnvFrom {
+				if ef.ConfigMapRef != nil && ef.ConfigMapRef.Name == cm.Name {
+					consumers = append(consumers, ConfigMapConsumer{
+						Pod:       pod.Name,
+						Container: c.Name,
+						Via:       ConfigMapRefEnvFrom,
+						Optional:  isOptional(ef.ConfigMapRef.Optional),
+					})
+				}
+			}
+
+			for _, env := range c.Env {
+				if ref := env.ValueFrom; ref != nil && ref.ConfigMapKeyRef != nil && ref.ConfigMapKeyRef.Name == cm.Name {
+					consumers = append(consumers, ConfigMapConsumer{
+						Pod:       pod.Name,
+						Container: c.Name,
+						Via:       ConfigMapRefValueFrom,
+						Key:       ref.ConfigMapKeyRef.Key,
+						Optional:  isOptional(ref.ConfigMapKeyRef.Optional),
+					})
+				}
+			}
+		}
+
+		for _, vol := range pod.Spec.Volumes {
+			if vol.ConfigMap != nil && vol.ConfigMap.Name == cm.Name {
+				consumers = append(consumers, ConfigMapConsumer{
+					Pod:      pod.Name,
+					Via:      ConfigMapRefVolume,
+					Key:      vol.Name,
+					Optional: isOptional(vol.ConfigMap.Optional),
+				})
+			}
+
+			if vol.Projected != nil {
+				for _, src := range vol.Projected.Sources {
+					if src.ConfigMap != nil && src.ConfigMap.Name == cm.Name {
+						consumers = append(consumers, ConfigMapConsumer{
+							Pod:      pod.Name,
+							Via:      ConfigMapRefVolume,
+							Key:      vol.Name,
+							Optional: isOptional(src.ConfigMap.Optional),
+						})
+					}
+				}
+			}
+		}
+	}
+
+	return consumers
+}
+
+func isOptional(optional *bool) bool {
+	return optional != nil && *optional
+}
+
+// listConfigMaps lists configmaps from the cache when it has synced, otherwise from the api server.
+func (k *Client) listConfigMaps(ctx context.Context, namespace string, lo metav1.ListOptions) (*v1.ConfigMapList, error) {
+	if k.cache.hasSynced(kindConfigMaps) {
+		return k.cache.listConfigMaps(namespace, lo)
+	}
+
+	clientset, err := k.wrapper.GetClientSet()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return clientset.CoreV1().ConfigMaps(namespace).List(ctx, lo)
 }
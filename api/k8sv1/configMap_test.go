@@ -0,0 +1,118 @@
+package k8sv1
+
+import (
+	"context"
+	"testing"
+
+	logfakes "github.com/kubelens/kubelens/api/log/fakes"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func configMapTestObjects() []runtime.Object {
+	optional := true
+	ref := v1.LocalObjectReference{Name: "settings"}
+
+	return []runtime.Object{
+		&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "testns", Labels: map[string]string{"app": "settings"}}},
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "testns"},
+			Spec: v1.PodSpec{
+				InitContainers: []v1.Container{
+					{Name: "init", EnvFrom: []v1.EnvFromSource{{ConfigMapRef: &v1.ConfigMapEnvSource{LocalObjectReference: ref}}}},
+				},
+				Containers: []v1.Container{
+					{
+						Name: "app",
+						Env: []v1.EnvVar{
+							{Name: "PLAIN", Value: "x"},
+							{Name: "LEVEL", ValueFrom: &v1.EnvVarSource{ConfigMapKeyRef: &v1.ConfigMapKeySelector{LocalObjectReference: ref, Key: "level", Optional: &optional}}},
+						},
+					},
+				},
+				Volumes: []v1.Volume{
+					{Name: "config", VolumeSource: v1.VolumeSource{ConfigMap: &v1.ConfigMapVolumeSource{LocalObjectReference: ref}}},
+					{Name: "all", VolumeSource: v1.VolumeSource{Projected: &v1.ProjectedVolumeSource{Sources: []v1.VolumeProjection{
+						{ConfigMap: &v1.ConfigMapProjection{LocalObjectReference: ref}},
+					}}}},
+				},
+			},
+		},
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: "testns"},
+			Spec: v1.PodSpec{Containers: []v1.Container{
+				{Name: "app", EnvFrom: []v1.EnvFromSource{{ConfigMapRef: &v1.ConfigMapEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: "other"}}}}},
+			}},
+		},
+		// same name, different namespace
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "elsewhere", Namespace: "other"},
+			Spec: v1.PodSpec{Containers: []v1.Container{
+				{Name: "app", EnvFrom: []v1.EnvFromSource{{ConfigMapRef: &v1.ConfigMapEnvSource{LocalObjectReference: ref}}}},
+			}},
+		},
+	}
+}
+
+func TestConfigMapsConsumers(t *testing.T) {
+	c := setupFakeClient(configMapTestObjects()...)
+
+	cms, _, err := c.ConfigMaps(ConfigMapOptions{
+		Logger:     &logfakes.Logger{},
+		Namespace:  "testns",
+		LinkedName: "settings",
+		Context:    context.Background(),
+	})
+
+	assert.Nil(t, err)
+	assert.Len(t, cms, 1)
+	assert.Equal(t, []ConfigMapConsumer{
+		{Pod: "web", Container: "init", Via: ConfigMapRefEnvFrom},
+		{Pod: "web", Container: "app", Via: ConfigMapRefValueFrom, Key: "level", Optional: true},
+		{Pod: "web", Via: ConfigMapRefVolume, Key: "config"},
+		{Pod: "web", Via: ConfigMapRefVolume, Key: "all"},
+	}, cms[0].Consumers)
+}
+
+func TestConfigMapsFail(t *testing.T) {
+	c := setupClient("testns", "cmtest1", true, true)
+
+	_, _, err := c.ConfigMaps(ConfigMapOptions{
+		Logger:     &logfakes.Logger{},
+		Namespace:  "testns",
+		LinkedName: "cmtest1",
+		Context:    context.Background(),
+	})
+
+	assert.NotNil(t, err)
+}
+
+func TestConfigMapCached(t *testing.T) {
+	c := setupCachedClient(t, configMapTestObjects()...)
+
+	cm, err := c.ConfigMap(ConfigMapOptions{
+		Logger:    &logfakes.Logger{},
+		Namespace: "testns",
+		Name:      "settings",
+		Context:   context.Background(),
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "settings", cm.Name)
+	assert.Len(t, cm.Consumers, 4)
+}
+
+func TestConfigMapFail(t *testing.T) {
+	c := setupClient("testns", "cmtest2", true, true)
+
+	_, err := c.ConfigMap(ConfigMapOptions{
+		Logger:    &logfakes.Logger{},
+		Namespace: "testns",
+		Name:      "cmtest2",
+		Context:   context.Background(),
+	})
+
+	assert.NotNil(t, err)
+}
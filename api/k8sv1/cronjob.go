@@ -0,0 +1,306 @@
+package k8sv1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kubelens/kubelens/api/errs"
+	klog "github.com/kubelens/kubelens/api/log"
+	"github.com/robfig/cron/v3"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// the number of upcoming runs computed for a cronjob's schedule
+const cronJobNextRuns = 5
+
+// now is replaced in tests so schedules can be evaluated at a fixed time.
+var now = time.Now
+
+// CronJobOptions contains fields used for filtering when retrieving cronjobs
+type CronJobOptions struct {
+	// the name of the cronjob
+	Name string `json:"name"`
+	// the value of the first link label the object has, e.g. "app=NAME", see config.LinkKeys
+	LinkedName string `json:"linkedName"`
+	// the namespace of the cronjob
+	Namespace string `json:"namespace"`
+	// selectors and pagination narrowing a list
+	ListOptions
+	// logger instance
+	Logger klog.Logger
+	// Context .
+	Context context.Context
+}
+
+// CronJobOverview .
+type CronJobOverview struct {
+	// the name
+	Name string `json:"name"`
+	// the value of the first link label the object has, e.g. "app=NAME", see config.LinkKeys
+	LinkedName string `json:"linkedName"`
+	// the namespace
+	Namespace string `json:"namespace"`
+	// the evaluated schedule
+	Schedule CronSchedule `json:"schedule"`
+	// the most recent job that completed successfully
+	LastSuccessfulRun *JobRun `json:"lastSuccessfulRun,omitempty"`
+	// the most recent job that failed
+	LastFailedRun *JobRun `json:"lastFailedRun,omitempty"`
+	// the jobs owned by the cronjob, newest first
+	Jobs []JobRun `json:"jobs"`
+	// the evaluated health
+	Health *Health `json:"health,omitempty"`
+	// the number of fields redacted, see config.Redaction
+	Redacted int `json:"redacted,omitempty"`
+	// the full cronjob
+	CronJob *batchv1.CronJob `json:"cronJob,omitempty"`
+}
+
+// CronSchedule is a cronjob's schedule evaluated at the time of the request.
+type CronSchedule struct {
+	// the cron expression, without a time zone prefix
+	Expression string `json:"expression"`
+	// the time zone the schedule runs in, set with spec.timeZone or a "CRON_TZ=" or "TZ="
+	// prefix. without either the controller manager's time zone is used, assumed to be UTC.
+	TimeZone string `json:"timeZone"`
+	// true if the cronjob won't start new jobs
+	Suspended bool `json:"suspended"`
+	// the upcoming runs, empty when suspended
+	NextRuns []metav1.Time `json:"nextRuns,omitempty"`
+	// set if the schedule couldn't be parsed
+	Error string `json:"error,omitempty"`
+}
+
+// JobRun is a job started by a cronjob.
+type JobRun struct {
+	// the name of the job
+	Name string `json:"name"`
+	// Active, Succeeded or Failed
+	Status string `json:"status"`
+	// when the job started
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// when the job completed or failed
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// the status of a JobRun
+const (
+	JobRunActive    = "Active"
+	JobRunSucceeded = "Succeeded"
+	JobRunFailed    = "Failed"
+)
+
+// CronJob returns a CronJob given filter options
+func (k *Client) CronJob(options CronJobOptions) (overview *CronJobOverview, apiErr *errs.APIError) {
+	list, err := k.listCronJobs(options.Context, options.Namespace, metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("metadata.name=%s", options.Name),
+	})
+
+	if err != nil {
+		klog.Trace()
+		return nil, errs.InternalServerError(err.Error())
+	}
+
+	if list != nil && len(list.Items) > 0 {
+		jobs := k.cronJobJobs(options.Context, list.Items[0].Namespace)
+
+		for _, item := range list.Items {
+			return newCronJobOverview(&item, jobs), nil
+		}
+	}
+	return overview, nil
+}
+
+// CronJobs returns a list of CronJobs given filter options
+func (k *Client) CronJobs(options CronJobOptions) (overviews []CronJobOverview, meta ListMeta, apiErr *errs.APIError) {
+	overviews = []CronJobOverview{}
+
+	lo, apiErr := options.listOptions(objectMetaFields(metav1.ObjectMeta{}))
+
+	if apiErr != nil {
+		return nil, meta, apiErr
+	}
+
+	obj, err := listLinked(options.LinkedName, lo, func(lo metav1.ListOptions) (runtime.Object, error) {
+		return k.listCronJobs(options.Context, options.Namespace, lo)
+	})
+
+	if err != nil {
+		klog.Trace()
+		return nil, meta, errs.InternalServerError(err.Error())
+	}
+
+	list := obj.(*batchv1.CronJobList)
+
+	if list != nil && len(list.Items) > 0 {
+		jobs := k.cronJobJobs(options.Context, options.Namespace)
+
+		for _, item := range list.Items {
+			// the overview keeps a pointer to item
+			item := item
+
+			overviews = append(overviews, *newCronJobOverview(&item, jobs))
+		}
+	}
+	return overviews, newListMeta(list.ListMeta), nil
+}
+
+// cronJobJobs lists the jobs in a namespace. Job history is informational, so
+// if jobs can't be listed the cronjobs are returned without it.
+func (k *Client) cronJobJobs(ctx context.Context, namespace string) []batchv1.Job {
+	list, err := k.listJobs(ctx, namespace, metav1.ListOptions{})
+
+	if err != nil {
+		klog.Trace()
+		return nil
+	}
+
+	return list.Items
+}
+
+func newCronJobOverview(cj *batchv1.CronJob, jobs []batchv1.Job) *CronJobOverview {
+	overview := &CronJobOverview{
+		Name:       cj.Name,
+		LinkedName: getLinkedName(cj.Namespace, cj.Labels),
+		Namespace:  cj.Namespace,
+		Schedule:   cronSchedule(cj, now()),
+		Jobs:       []JobRun{},
+		Redacted:   redact(cj),
+		CronJob:    cj,
+	}
+
+	for i := range jobs {
+		if metav1.IsControlledBy(&jobs[i], cj) {
+			overview.Jobs = append(overview.Jobs, jobRun(&jobs[i]))
+		}
+	}
+
+	// newest first, jobs that haven't started yet sort first.
+	sort.SliceStable(overview.Jobs, func(i, j int) bool {
+		a, b := overview.Jobs[i].StartTime, overview.Jobs[j].StartTime
+		if a == nil || b == nil {
+			return a == nil && b != nil
+		}
+		return b.Before(a)
+	})
+
+	for i := range overview.Jobs {
+		run := overview.Jobs[i]
+
+		switch run.Status {
+		case JobRunSucceeded:
+			if overview.LastSuccessfulRun == nil || finishedAfter(run, *overview.LastSuccessfulRun) {
+				overview.LastSuccessfulRun = &run
+			}
+		case JobRunFailed:
+			if overview.LastFailedRun == nil || finishedAfter(run, *overview.LastFailedRun) {
+				overview.LastFailedRun = &run
+			}
+		}
+	}
+
+	overview.Health = cronJobHealth(overview)
+
+	return overview
+}
+
+// cronSchedule evaluates the schedule of a cronjob from a point in time.
+func cronSchedule(cj *batchv1.CronJob, from time.Time) (schedule CronSchedule) {
+	schedule.Expression = strings.TrimSpace(cj.Spec.Schedule)
+	schedule.TimeZone = "UTC"
+	schedule.Suspended = cj.Spec.Suspend != nil && *cj.Spec.Suspend
+
+	// the api server rejects a time zone set both ways
+	if cj.Spec.TimeZone != nil && len(*cj.Spec.TimeZone) > 0 {
+		schedule.TimeZone = *cj.Spec.TimeZone
+	}
+
+	if strings.HasPrefix(schedule.Expression, "CRON_TZ=") || strings.HasPrefix(schedule.Expression, "TZ=") {
+		if i := strings.IndexAny(schedule.Expression, " \t"); i > 0 {
+			schedule.TimeZone = schedule.Expression[strings.Index(schedule.Expression, "=")+1 : i]
+			schedule.Expression = strings.TrimSpace(schedule.Expression[i:])
+		}
+	}
+
+	// always set the time zone so the server's local time isn't used.
+	sched, err := cron.ParseStandard(fmt.Sprintf("CRON_TZ=%s %s", schedule.TimeZone, schedule.Expression))
+
+	if err != nil {
+		schedule.Error = err.Error()
+		return schedule
+	}
+
+	if schedule.Suspended {
+		return schedule
+	}
+
+	next := from
+	for i := 0; i < cronJobNextRuns; i++ {
+		// a zero time means the schedule never fires, e.g. "0 0 30 2 *"
+		if next = sched.Next(next); next.IsZero() {
+			break
+		}
+		schedule.NextRuns = append(schedule.NextRuns, metav1.NewTime(next))
+	}
+
+	return schedule
+}
+
+// jobRun returns the run status of a job from its conditions.
+func jobRun(job *batchv1.Job) JobRun {
+	run := JobRun{
+		Name:           job.Name,
+		Status:         JobRunActive,
+		StartTime:      job.Status.StartTime,
+		CompletionTime: job.Status.CompletionTime,
+	}
+
+	for _, c := range job.Status.Conditions {
+		if c.Status != v1.ConditionTrue {
+			continue
+		}
+
+		switch c.Type {
+		case batchv1.JobComplete:
+			run.Status = JobRunSucceeded
+		case batchv1.JobFailed:
+			run.Status = JobRunFailed
+			// failed jobs don't set a completion time
+			if run.CompletionTime == nil {
+				t := c.LastTransitionTime
+				run.CompletionTime = &t
+			}
+		}
+	}
+
+	return run
+}
+
+// finishedAfter returns true if run a finished after run b.
+func finishedAfter(a, b JobRun) bool {
+	if a.CompletionTime == nil || b.CompletionTime == nil {
+		return a.CompletionTime != nil
+	}
+	return b.CompletionTime.Before(a.CompletionTime)
+}
+
+// listCronJobs lists cronjobs from the cache when it has synced, otherwise from the api server.
+func (k *Client) listCronJobs(ctx context.Context, namespace string, lo metav1.ListOptions) (*batchv1.CronJobList, error) {
+	if k.cache.hasSynced(kindCronJobs) {
+		return k.cache.listCronJobs(namespace, lo)
+	}
+
+	clientset, err := k.wrapper.GetClientSet()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return clientset.BatchV1().CronJobs(namespace).List(ctx, lo)
+}
@@ -0,0 +1,183 @@
+package k8sv1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	logfakes "github.com/kubelens/kubelens/api/log/fakes"
+	"github.com/stretchr/testify/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var cronTestTime = time.Date(2021, 6, 1, 10, 30, 0, 0, time.UTC)
+
+func cronJobTestObjects() []runtime.Object {
+	cj := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "testns", UID: types.UID("cj-uid"), Labels: map[string]string{"app": "backup"}},
+		Spec:       batchv1.CronJobSpec{Schedule: "0 * * * *"},
+	}
+
+	controller := true
+	owner := []metav1.OwnerReference{{APIVersion: "batch/v1", Kind: "CronJob", Name: "backup", UID: cj.UID, Controller: &controller}}
+
+	job := func(name string, start time.Time, cond batchv1.JobConditionType) *batchv1.Job {
+		started := metav1.NewTime(start)
+		j := &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "testns", OwnerReferences: owner},
+			Status:     batchv1.JobStatus{StartTime: &started},
+		}
+		if len(cond) > 0 {
+			finished := metav1.NewTime(start.Add(time.Minute))
+			j.Status.Conditions = []batchv1.JobCondition{{Type: cond, Status: v1.ConditionTrue, LastTransitionTime: finished}}
+			if cond == batchv1.JobComplete {
+				j.Status.CompletionTime = &finished
+			}
+		}
+		return j
+	}
+
+	return []runtime.Object{
+		cj,
+		job("backup-1", cronTestTime.Add(-3*time.Hour), batchv1.JobComplete),
+		job("backup-2", cronTestTime.Add(-2*time.Hour), batchv1.JobFailed),
+		job("backup-3", cronTestTime.Add(-1*time.Hour), batchv1.JobComplete),
+		job("backup-4", cronTestTime, ""),
+		// not owned by the cronjob
+		&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "manual", Namespace: "testns"}},
+	}
+}
+
+func setCronTestTime(t *testing.T) {
+	now = func() time.Time { return cronTestTime }
+	t.Cleanup(func() { now = time.Now })
+}
+
+func TestCronJobsSuccess(t *testing.T) {
+	setCronTestTime(t)
+
+	c := setupFakeClient(cronJobTestObjects()...)
+
+	cjs, _, err := c.CronJobs(CronJobOptions{
+		Logger:     &logfakes.Logger{},
+		Namespace:  "testns",
+		LinkedName: "backup",
+		Context:    context.Background(),
+	})
+
+	assert.Nil(t, err)
+	assert.Len(t, cjs, 1)
+
+	cj := cjs[0]
+	assert.Equal(t, "backup", cj.LinkedName)
+	assert.Equal(t, "UTC", cj.Schedule.TimeZone)
+	assert.False(t, cj.Schedule.Suspended)
+	assert.Len(t, cj.Schedule.NextRuns, cronJobNextRuns)
+	assert.True(t, cj.Schedule.NextRuns[0].Time.Equal(time.Date(2021, 6, 1, 11, 0, 0, 0, time.UTC)))
+
+	var names []string
+	for _, j := range cj.Jobs {
+		names = append(names, j.Name)
+	}
+	assert.Equal(t, []string{"backup-4", "backup-3", "backup-2", "backup-1"}, names)
+	assert.Equal(t, JobRunActive, cj.Jobs[0].Status)
+	assert.Equal(t, "backup-3", cj.LastSuccessfulRun.Name)
+	assert.Equal(t, "backup-2", cj.LastFailedRun.Name)
+	assert.NotNil(t, cj.LastFailedRun.CompletionTime)
+}
+
+func TestCronJobsFail(t *testing.T) {
+	c := setupClient("testns", "cjtest1", true, true)
+
+	_, _, err := c.CronJobs(CronJobOptions{
+		Logger:     &logfakes.Logger{},
+		Namespace:  "testns",
+		LinkedName: "cjtest1",
+		Context:    context.Background(),
+	})
+
+	assert.NotNil(t, err)
+}
+
+func TestCronJobCached(t *testing.T) {
+	setCronTestTime(t)
+
+	c := setupCachedClient(t, cronJobTestObjects()...)
+
+	cj, err := c.CronJob(CronJobOptions{
+		Logger:    &logfakes.Logger{},
+		Namespace: "testns",
+		Name:      "backup",
+		Context:   context.Background(),
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "backup", cj.Name)
+	assert.Len(t, cj.Jobs, 4)
+}
+
+func TestCronJobFail(t *testing.T) {
+	c := setupClient("testns", "cjtest2", true, true)
+
+	_, err := c.CronJob(CronJobOptions{
+		Logger:    &logfakes.Logger{},
+		Namespace: "testns",
+		Name:      "cjtest2",
+		Context:   context.Background(),
+	})
+
+	assert.NotNil(t, err)
+}
+
+func TestCronScheduleTimeZone(t *testing.T) {
+	s := cronSchedule(&batchv1.CronJob{Spec: batchv1.CronJobSpec{Schedule: "CRON_TZ=America/New_York 0 9 * * *"}}, cronTestTime)
+
+	assert.Empty(t, s.Error)
+	assert.Equal(t, "America/New_York", s.TimeZone)
+	assert.Equal(t, "0 9 * * *", s.Expression)
+	// 9am in New York is 13:00 UTC during daylight saving time
+	assert.True(t, s.NextRuns[0].Time.Equal(time.Date(2021, 6, 1, 13, 0, 0, 0, time.UTC)))
+
+	s = cronSchedule(&batchv1.CronJob{Spec: batchv1.CronJobSpec{Schedule: "TZ=Europe/Berlin @daily"}}, cronTestTime)
+
+	assert.Empty(t, s.Error)
+	assert.Equal(t, "Europe/Berlin", s.TimeZone)
+	assert.True(t, s.NextRuns[0].Time.Equal(time.Date(2021, 6, 1, 22, 0, 0, 0, time.UTC)))
+}
+
+func TestCronScheduleSpecTimeZone(t *testing.T) {
+	tz := "Asia/Tokyo"
+	s := cronSchedule(&batchv1.CronJob{Spec: batchv1.CronJobSpec{Schedule: "0 9 * * *", TimeZone: &tz}}, cronTestTime)
+
+	assert.Empty(t, s.Error)
+	assert.Equal(t, "Asia/Tokyo", s.TimeZone)
+	assert.Equal(t, "0 9 * * *", s.Expression)
+	// 9am in Tokyo is midnight UTC
+	assert.True(t, s.NextRuns[0].Time.Equal(time.Date(2021, 6, 2, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestCronScheduleSuspended(t *testing.T) {
+	suspend := true
+	s := cronSchedule(&batchv1.CronJob{Spec: batchv1.CronJobSpec{Schedule: "*/5 * * * *", Suspend: &suspend}}, cronTestTime)
+
+	assert.Empty(t, s.Error)
+	assert.True(t, s.Suspended)
+	assert.Empty(t, s.NextRuns)
+}
+
+func TestCronScheduleInvalid(t *testing.T) {
+	s := cronSchedule(&batchv1.CronJob{Spec: batchv1.CronJobSpec{Schedule: "not a schedule"}}, cronTestTime)
+	assert.NotEmpty(t, s.Error)
+
+	s = cronSchedule(&batchv1.CronJob{Spec: batchv1.CronJobSpec{Schedule: "CRON_TZ=Nowhere/Special 0 * * * *"}}, cronTestTime)
+	assert.NotEmpty(t, s.Error)
+
+	// valid, but never fires
+	s = cronSchedule(&batchv1.CronJob{Spec: batchv1.CronJobSpec{Schedule: "0 0 30 2 *"}}, cronTestTime)
+	assert.Empty(t, s.Error)
+	assert.Empty(t, s.NextRuns)
+}
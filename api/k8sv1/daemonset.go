@@ -8,16 +8,19 @@ import (
 	klog "github.com/kubelens/kubelens/api/log"
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // DaemonSetOptions contains fields used for filtering when retrieving daemon sets
 type DaemonSetOptions struct {
 	// the name of the daemonSet
 	Name string `json:"name"`
-	// the value from the label "app=NAME", corresponds to config.LabelKeyLink
+	// the value of the first link label the object has, e.g. "app=NAME", see config.LinkKeys
 	LinkedName string `json:"linkedName"`
 	// namespace to filter on
 	Namespace string `json:"namespace"`
+	// selectors and pagination narrowing a list
+	ListOptions
 	// logger instance
 	Logger klog.Logger
 	// Context .
@@ -28,10 +31,14 @@ type DaemonSetOptions struct {
 type DaemonSetOverview struct {
 	// the name
 	Name string `json:"name"`
-	// the value from the label "app=NAME", corresponds to config.LabelKeyLink
+	// the value of the first link label the object has, e.g. "app=NAME", see config.LinkKeys
 	LinkedName string `json:"linkedName"`
 	// the namespace
 	Namespace string `json:"namespace"`
+	// the evaluated health
+	Health *Health `json:"health,omitempty"`
+	// the number of fields redacted, see config.Redaction
+	Redacted int `json:"redacted,omitempty"`
 	// the full daemonset
 	DaemonSet *appsv1.DaemonSet `json:"daemonSet,omitempty"`
 }
@@ -39,19 +46,7 @@ type DaemonSetOverview struct {
 // DaemonSet returns a daemonsets given filter options
 func (k *Client) DaemonSet(options DaemonSetOptions) (overview *DaemonSetOverview, apiErr *errs.APIError) {
 
-	clientset, err := k.wrapper.GetClientSet()
-
-	if err != nil {
-		klog.Trace()
-		return nil, errs.InternalServerError(err.Error())
-	}
-
-	dsl := clientset.AppsV1().DaemonSets(options.Namespace)
-
-	if dsl == nil {
-		return nil, nil
-	}
-	list, err := dsl.List(options.Context, metav1.ListOptions{
+	list, err := k.listDaemonSets(options.Context, options.Namespace, metav1.ListOptions{
 		FieldSelector: fmt.Sprintf("metadata.name=%s", options.Name),
 	})
 
@@ -64,8 +59,10 @@ func (k *Client) DaemonSet(options DaemonSetOptions) (overview *DaemonSetOvervie
 		for _, item := range list.Items {
 			return &DaemonSetOverview{
 				Name:       item.Name,
-				LinkedName: getLinkedName(item.Labels),
+				LinkedName: getLinkedName(item.Namespace, item.Labels),
 				Namespace:  item.Namespace,
+				Health:     daemonSetHealth(&item),
+				Redacted:   redact(&item),
 				DaemonSet:  &item,
 			}, nil
 		}
@@ -74,41 +71,54 @@ func (k *Client) DaemonSet(options DaemonSetOptions) (overview *DaemonSetOvervie
 }
 
 // DaemonSet returns a daemonsets given filter options
-func (k *Client) DaemonSets(options DaemonSetOptions) (overviews []DaemonSetOverview, apiErr *errs.APIError) {
+func (k *Client) DaemonSets(options DaemonSetOptions) (overviews []DaemonSetOverview, meta ListMeta, apiErr *errs.APIError) {
 	overviews = []DaemonSetOverview{}
-	clientset, err := k.wrapper.GetClientSet()
-
-	if err != nil {
-		klog.Trace()
-		return nil, errs.InternalServerError(err.Error())
-	}
+	lo, apiErr := options.listOptions(objectMetaFields(metav1.ObjectMeta{}))
 
-	dsl := clientset.AppsV1().DaemonSets(options.Namespace)
-
-	if dsl == nil {
-		return nil, nil
+	if apiErr != nil {
+		return nil, meta, apiErr
 	}
 
-	lo := metav1.ListOptions{}
-	if len(options.LinkedName) > 0 {
-		lo.LabelSelector = generateLabelSelector(options.LinkedName)
-	}
-	list, err := dsl.List(options.Context, lo)
+	obj, err := listLinked(options.LinkedName, lo, func(lo metav1.ListOptions) (runtime.Object, error) {
+		return k.listDaemonSets(options.Context, options.Namespace, lo)
+	})
 
 	if err != nil {
 		klog.Trace()
-		return nil, errs.InternalServerError(err.Error())
+		return nil, meta, errs.InternalServerError(err.Error())
 	}
 
+	list := obj.(*appsv1.DaemonSetList)
+
 	if list != nil && len(list.Items) > 0 {
 		for _, item := range list.Items {
+			// the overview keeps a pointer to item
+			item := item
+
 			overviews = append(overviews, DaemonSetOverview{
 				Name:       item.Name,
-				LinkedName: getLinkedName(item.Labels),
+				LinkedName: getLinkedName(item.Namespace, item.Labels),
 				Namespace:  item.Namespace,
+				Health:     daemonSetHealth(&item),
+				Redacted:   redact(&item),
 				DaemonSet:  &item,
 			})
 		}
 	}
-	return overviews, nil
+	return overviews, newListMeta(list.ListMeta), nil
+}
+
+// listDaemonSets lists daemonsets from the cache when it has synced, otherwise from the api server.
+func (k *Client) listDaemonSets(ctx context.Context, namespace string, lo metav1.ListOptions) (*appsv1.DaemonSetList, error) {
+	if k.cache.hasSynced(kindDaemonSets) {
+		return k.cache.listDaemonSets(namespace, lo)
+	}
+
+	clientset, err := k.wrapper.GetClientSet()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return clientset.AppsV1().DaemonSets(namespace).List(ctx, lo)
 }
@@ -11,7 +11,7 @@ import (
 func TestDaemonSetsDefaultSuccess(t *testing.T) {
 	c := setupClient("testns", "dstest1", false, false)
 
-	d, err := c.DaemonSets(DaemonSetOptions{
+	d, _, err := c.DaemonSets(DaemonSetOptions{
 		Logger:     &logfakes.Logger{},
 		Namespace:  "testns",
 		LinkedName: "dstest1",
@@ -26,7 +26,7 @@ func TestDaemonSetsDefaultSuccess(t *testing.T) {
 func TestGetDaemonSetsDefaultFail(t *testing.T) {
 	c := setupClient("testns", "dstest2", true, true)
 
-	_, err := c.DaemonSets(DaemonSetOptions{
+	_, _, err := c.DaemonSets(DaemonSetOptions{
 		Logger:     &logfakes.Logger{},
 		Namespace:  "testns",
 		LinkedName: "dstest2",
@@ -7,6 +7,7 @@ import (
 	"github.com/kubelens/kubelens/api/errs"
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	klog "github.com/kubelens/kubelens/api/log"
 )
@@ -15,10 +16,12 @@ import (
 type DeploymentOptions struct {
 	// the name of the deployment
 	Name string `json:"name"`
-	// the value from the label "app=NAME", corresponds to config.LabelKeyLink
+	// the value of the first link label the object has, e.g. "app=NAME", see config.LinkKeys
 	LinkedName string `json:"linkedName"`
 	// the namespace of the deployment
 	Namespace string `json:"namespace"`
+	// selectors and pagination narrowing a list
+	ListOptions
 	// logger instance
 	Logger klog.Logger
 	// Context .
@@ -28,29 +31,22 @@ type DeploymentOptions struct {
 type DeploymentOverview struct {
 	// the name of the deployment
 	Name string `json:"name"`
-	// the value from the label "app=NAME", corresponds to config.LabelKeyLink
+	// the value of the first link label the object has, e.g. "app=NAME", see config.LinkKeys
 	LinkedName string `json:"linkedName"`
 	// the namespace of the deployment
 	Namespace string `json:"namespace"`
+	// events about the deployment, most recent first
+	Events []EventOverview `json:"events,omitempty"`
+	// the evaluated health
+	Health *Health `json:"health,omitempty"`
+	// the number of fields redacted, see config.Redaction
+	Redacted int `json:"redacted,omitempty"`
 	// deployment labels
 	Deployment *appsv1.Deployment `json:"deployment,omitempty"`
 }
 
 func (k *Client) Deployment(options DeploymentOptions) (overview *DeploymentOverview, apiErr *errs.APIError) {
-	clientset, err := k.wrapper.GetClientSet()
-
-	if err != nil {
-		klog.Trace()
-		return nil, errs.InternalServerError(err.Error())
-	}
-
-	dpl := clientset.AppsV1().Deployments(options.Namespace)
-
-	if dpl == nil {
-		return nil, nil
-	}
-
-	list, err := dpl.List(options.Context, metav1.ListOptions{
+	list, err := k.listDeployments(options.Context, options.Namespace, metav1.ListOptions{
 		FieldSelector: fmt.Sprintf("metadata.name=%s", options.Name),
 	})
 
@@ -63,8 +59,11 @@ func (k *Client) Deployment(options DeploymentOptions) (overview *DeploymentOver
 		for _, item := range list.Items {
 			return &DeploymentOverview{
 				Name:       item.Name,
-				LinkedName: getLinkedName(item.Labels),
+				LinkedName: getLinkedName(item.Namespace, item.Labels),
 				Namespace:  item.Namespace,
+				Events:     k.objectEvents(options.Context, "Deployment", item.Namespace, item.Name),
+				Health:     deploymentHealth(&item),
+				Redacted:   redact(&item),
 				Deployment: &item,
 			}, nil
 		}
@@ -74,42 +73,55 @@ func (k *Client) Deployment(options DeploymentOptions) (overview *DeploymentOver
 }
 
 // Deployments retrieves all deployments by namespace.
-func (k *Client) Deployments(options DeploymentOptions) (overviews []DeploymentOverview, apiErr *errs.APIError) {
+func (k *Client) Deployments(options DeploymentOptions) (overviews []DeploymentOverview, meta ListMeta, apiErr *errs.APIError) {
 	overviews = []DeploymentOverview{}
-	clientset, err := k.wrapper.GetClientSet()
+	lo, apiErr := options.listOptions(objectMetaFields(metav1.ObjectMeta{}))
 
-	if err != nil {
-		klog.Trace()
-		return nil, errs.InternalServerError(err.Error())
+	if apiErr != nil {
+		return nil, meta, apiErr
 	}
 
-	dpl := clientset.AppsV1().Deployments(options.Namespace)
-
-	if dpl == nil {
-		return nil, nil
-	}
-
-	lo := metav1.ListOptions{}
-	if len(options.LinkedName) > 0 {
-		lo.LabelSelector = generateLabelSelector(options.LinkedName)
-	}
-	list, err := dpl.List(options.Context, lo)
+	obj, err := listLinked(options.LinkedName, lo, func(lo metav1.ListOptions) (runtime.Object, error) {
+		return k.listDeployments(options.Context, options.Namespace, lo)
+	})
 
 	if err != nil {
 		klog.Trace()
-		return nil, errs.InternalServerError(err.Error())
+		return nil, meta, errs.InternalServerError(err.Error())
 	}
 
+	list := obj.(*appsv1.DeploymentList)
+
 	if list != nil && len(list.Items) > 0 {
 		for _, item := range list.Items {
+			// the overview keeps a pointer to item
+			item := item
+
 			overviews = append(overviews, DeploymentOverview{
 				Name:       item.Name,
-				LinkedName: getLinkedName(item.Labels),
+				LinkedName: getLinkedName(item.Namespace, item.Labels),
 				Namespace:  item.Namespace,
+				Health:     deploymentHealth(&item),
+				Redacted:   redact(&item),
 				Deployment: &item,
 			})
 		}
 	}
 
-	return overviews, nil
+	return overviews, newListMeta(list.ListMeta), nil
+}
+
+// listDeployments lists deployments from the cache when it has synced, otherwise from the api server.
+func (k *Client) listDeployments(ctx context.Context, namespace string, lo metav1.ListOptions) (*appsv1.DeploymentList, error) {
+	if k.cache.hasSynced(kindDeployments) {
+		return k.cache.listDeployments(namespace, lo)
+	}
+
+	clientset, err := k.wrapper.GetClientSet()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return clientset.AppsV1().Deployments(namespace).List(ctx, lo)
 }
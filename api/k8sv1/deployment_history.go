@@ -0,0 +1,373 @@
+package k8sv1
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/kubelens/kubelens/api/errs"
+	klog "github.com/kubelens/kubelens/api/log"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// the annotation the deployment controller sets on replicasets with the revision they were created for
+	revisionAnnotation = "deployment.kubernetes.io/revision"
+	// the annotation recording the command or reason for a change, copied from the deployment
+	changeCauseAnnotation = "kubernetes.io/change-cause"
+)
+
+// the type of a TemplateChange
+const (
+	ChangeAdded   = "added"
+	ChangeRemoved = "removed"
+	ChangeChanged = "changed"
+)
+
+// DeploymentRevision is a revision of a deployment, reconstructed from the replicaset created for it.
+type DeploymentRevision struct {
+	// the revision number
+	Revision int64 `json:"revision"`
+	// the replicaset created for the revision
+	ReplicaSet string `json:"replicaSet"`
+	// the reason for the change, from the "kubernetes.io/change-cause" annotation
+	ChangeCause string `json:"changeCause,omitempty"`
+	// the images of the containers
+	Images []string `json:"images"`
+	// the replicas currently running the revision
+	Replicas int32 `json:"replicas"`
+	// true for the revision the deployment is rolled out to
+	Current bool `json:"current"`
+	// when the revision was created
+	CreationTimestamp metav1.Time `json:"creationTimestamp"`
+}
+
+// DeploymentDiffOptions contains fields used to compare two revisions of a deployment
+type DeploymentDiffOptions struct {
+	// the name of the deployment
+	Name string `json:"name"`
+	// the namespace of the deployment
+	Namespace string `json:"namespace"`
+	// the revision to compare from, defaults to the revision before To
+	From int64 `json:"from"`
+	// the revision to compare to, defaults to the latest revision
+	To int64 `json:"to"`
+	// logger instance
+	Logger klog.Logger
+	// Context .
+	Context context.Context
+}
+
+// DeploymentDiff is the difference between the pod templates of two revisions of a deployment.
+type DeploymentDiff struct {
+	// the revision compared from
+	From int64 `json:"from"`
+	// the revision compared to
+	To int64 `json:"to"`
+	// the changes from one pod template to the other
+	Changes []TemplateChange `json:"changes"`
+}
+
+// TemplateChange is a change to a field of a pod template.
+type TemplateChange struct {
+	// the path to the field, list items with a name are keyed by it,
+	// e.g. spec.containers[app].image
+	Path string `json:"path"`
+	// added, removed or changed
+	Type string `json:"type"`
+	// the value before the change
+	From interface{} `json:"from,omitempty"`
+	// the value after the change
+	To interface{} `json:"to,omitempty"`
+}
+
+// DeploymentHistory returns the revisions of a deployment, newest first.
+func (k *Client) DeploymentHistory(options DeploymentOptions) (history []DeploymentRevision, apiErr *errs.APIError) {
+	deployment, replicaSets, apiErr := k.deploymentReplicaSets(options.Context, options.Namespace, options.Name)
+
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	current := deployment.Annotations[revisionAnnotation]
+
+	history = []DeploymentRevision{}
+
+	for _, rs := range replicaSets {
+		revision := DeploymentRevision{
+			Revision:          replicaSetRevision(&rs),
+			ReplicaSet:        rs.Name,
+			ChangeCause:       rs.Annotations[changeCauseAnnotation],
+			Images:            []string{},
+			Replicas:          rs.Status.Replicas,
+			Current:           len(current) > 0 && rs.Annotations[revisionAnnotation] == current,
+			CreationTimestamp: rs.CreationTimestamp,
+		}
+
+		for _, c := range rs.Spec.Template.Spec.Containers {
+			revision.Images = append(revision.Images, c.Image)
+		}
+
+		history = append(history, revision)
+	}
+
+	return history, nil
+}
+
+// DeploymentDiff returns the difference between the pod templates of two revisions of a deployment.
+func (k *Client) DeploymentDiff(options DeploymentDiffOptions) (diff *DeploymentDiff, apiErr *errs.APIError) {
+	_, replicaSets, apiErr := k.deploymentReplicaSets(options.Context, options.Namespace, options.Name)
+
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	if len(replicaSets) == 0 {
+		return nil, errs.NotFound(fmt.Sprintf("no revisions found for deployment %s", options.Name))
+	}
+
+	// replicasets are sorted newest first
+	to, from := &replicaSets[0], (*appsv1.ReplicaSet)(nil)
+
+	if options.To > 0 {
+		if to = findRevision(replicaSets, options.To); to == nil {
+			return nil, errs.ValidationError(fmt.Sprintf("revision %d not found", options.To))
+		}
+	}
+
+	if options.From > 0 {
+		if from = findRevision(replicaSets, options.From); from == nil {
+			return nil, errs.ValidationError(fmt.Sprintf("revision %d not found", options.From))
+		}
+	} else {
+		for i := range replicaSets {
+			if replicaSetRevision(&replicaSets[i]) < replicaSetRevision(to) {
+				from = &replicaSets[i]
+				break
+			}
+		}
+
+		if from == nil {
+			return nil, errs.ValidationError(fmt.Sprintf("no revision before %d", replicaSetRevision(to)))
+		}
+	}
+
+	// a changed secret shows as unchanged rather than leaking either value
+	redact(from)
+	redact(to)
+
+	fromTemplate, err := templateValues(from)
+
+	if err != nil {
+		klog.Trace()
+		return nil, errs.InternalServerError(err.Error())
+	}
+
+	toTemplate, err := templateValues(to)
+
+	if err != nil {
+		klog.Trace()
+		return nil, errs.InternalServerError(err.Error())
+	}
+
+	diff = &DeploymentDiff{
+		From:    replicaSetRevision(from),
+		To:      replicaSetRevision(to),
+		Changes: []TemplateChange{},
+	}
+
+	diffValues("", fromTemplate, toTemplate, &diff.Changes)
+
+	return diff, nil
+}
+
+// deploymentReplicaSets returns a deployment and the replicasets it owns, newest revision first.
+func (k *Client) deploymentReplicaSets(ctx context.Context, namespace, name string) (*appsv1.Deployment, []appsv1.ReplicaSet, *errs.APIError) {
+	list, err := k.listDeployments(ctx, namespace, metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("metadata.name=%s", name),
+	})
+
+	if err != nil {
+		klog.Trace()
+		return nil, nil, errs.InternalServerError(err.Error())
+	}
+
+	if len(list.Items) == 0 {
+		return nil, nil, errs.NotFound(fmt.Sprintf("deployment %s not found", name))
+	}
+
+	deployment := &list.Items[0]
+
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+
+	if err != nil {
+		klog.Trace()
+		return nil, nil, errs.InternalServerError(err.Error())
+	}
+
+	rsList, err := k.listReplicaSets(ctx, deployment.Namespace, metav1.ListOptions{
+		LabelSelector: selector.String(),
+	})
+
+	if err != nil {
+		klog.Trace()
+		return nil, nil, errs.InternalServerError(err.Error())
+	}
+
+	replicaSets := []appsv1.ReplicaSet{}
+
+	for _, rs := range rsList.Items {
+		if metav1.IsControlledBy(&rs, deployment) {
+			replicaSets = append(replicaSets, rs)
+		}
+	}
+
+	sort.SliceStable(replicaSets, func(i, j int) bool {
+		return replicaSetRevision(&replicaSets[i]) > replicaSetRevision(&replicaSets[j])
+	})
+
+	return deployment, replicaSets, nil
+}
+
+// replicaSetRevision returns the revision of a replicaset, 0 if it isn't set.
+func replicaSetRevision(rs *appsv1.ReplicaSet) int64 {
+	revision, _ := strconv.ParseInt(rs.Annotations[revisionAnnotation], 10, 64)
+	return revision
+}
+
+func findRevision(replicaSets []appsv1.ReplicaSet, revision int64) *appsv1.ReplicaSet {
+	for i := range replicaSets {
+		if replicaSetRevision(&replicaSets[i]) == revision {
+			return &replicaSets[i]
+		}
+	}
+	return nil
+}
+
+// templateValues returns the pod template of a replicaset as generic values, without
+// the pod-template-hash label the controller adds to every revision.
+func templateValues(rs *appsv1.ReplicaSet) (map[string]interface{}, error) {
+	template := rs.Spec.Template.DeepCopy()
+
+	delete(template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+
+	return runtime.DefaultUnstructuredConverter.ToUnstructured(template)
+}
+
+// diffValues appends the changes between two generic values to changes.
+func diffValues(path string, from, to interface{}, changes *[]TemplateChange) {
+	switch {
+	case from == nil && to == nil:
+		return
+	case from == nil:
+		*changes = append(*changes, TemplateChange{Path: path, Type: ChangeAdded, To: to})
+		return
+	case to == nil:
+		*changes = append(*changes, TemplateChange{Path: path, Type: ChangeRemoved, From: from})
+		return
+	}
+
+	fromMap, fromIsMap := from.(map[string]interface{})
+	toMap, toIsMap := to.(map[string]interface{})
+
+	if fromIsMap && toIsMap {
+		keys := map[string]bool{}
+		for key := range fromMap {
+			keys[key] = true
+		}
+		for key := range toMap {
+			keys[key] = true
+		}
+
+		sorted := []string{}
+		for key := range keys {
+			sorted = append(sorted, key)
+		}
+		sort.Strings(sorted)
+
+		for _, key := range sorted {
+			diffValues(joinPath(path, key), fromMap[key], toMap[key], changes)
+		}
+		return
+	}
+
+	fromList, fromIsList := from.([]interface{})
+	toList, toIsList := to.([]interface{})
+
+	if fromIsList && toIsList {
+		fromNamed, fromOk := namedItems(fromList)
+		toNamed, toOk := namedItems(toList)
+
+		// containers, env, volumes, ports, etc. are matched by name so
+		// reordering or inserting one doesn't show every item as changed.
+		if fromOk && toOk {
+			names := []string{}
+			for _, item := range fromList {
+				names = append(names, itemName(item))
+			}
+			for _, item := range toList {
+				if _, ok := fromNamed[itemName(item)]; !ok {
+					names = append(names, itemName(item))
+				}
+			}
+
+			for _, name := range names {
+				diffValues(fmt.Sprintf("%s[%s]", path, name), fromNamed[name], toNamed[name], changes)
+			}
+			return
+		}
+
+		for i := 0; i < len(fromList) || i < len(toList); i++ {
+			var f, t interface{}
+			if i < len(fromList) {
+				f = fromList[i]
+			}
+			if i < len(toList) {
+				t = toList[i]
+			}
+			diffValues(fmt.Sprintf("%s[%d]", path, i), f, t, changes)
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(from, to) {
+		*changes = append(*changes, TemplateChange{Path: path, Type: ChangeChanged, From: from, To: to})
+	}
+}
+
+// namedItems keys a list by the name of each item, false if any item doesn't have a unique name.
+func namedItems(list []interface{}) (map[string]interface{}, bool) {
+	named := map[string]interface{}{}
+
+	for _, item := range list {
+		name := itemName(item)
+
+		if _, exists := named[name]; len(name) == 0 || exists {
+			return nil, false
+		}
+
+		named[name] = item
+	}
+
+	return named, true
+}
+
+func itemName(item interface{}) string {
+	if m, ok := item.(map[string]interface{}); ok {
+		if name, ok := m["name"].(string); ok {
+			return name
+		}
+	}
+	return ""
+}
+
+func joinPath(path, key string) string {
+	if len(path) == 0 {
+		return key
+	}
+	return path + "." + key
+}
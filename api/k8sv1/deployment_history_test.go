@@ -0,0 +1,178 @@
+package k8sv1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	logfakes "github.com/kubelens/kubelens/api/log/fakes"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func deploymentHistoryTestObjects() []runtime.Object {
+	lbl := map[string]string{"app": "web"}
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "web",
+			Namespace:   "testns",
+			UID:         types.UID("web-uid"),
+			Labels:      lbl,
+			Annotations: map[string]string{revisionAnnotation: "3"},
+		},
+		Spec: appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: lbl}},
+	}
+
+	controller := true
+	owner := []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "Deployment", Name: "web", UID: deployment.UID, Controller: &controller}}
+
+	rs := func(revision, hash, cause string, replicas int32, containers ...v1.Container) *appsv1.ReplicaSet {
+		labels := map[string]string{"app": "web", appsv1.DefaultDeploymentUniqueLabelKey: hash}
+		return &appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "web-" + hash,
+				Namespace:         "testns",
+				Labels:            labels,
+				OwnerReferences:   owner,
+				CreationTimestamp: metav1.NewTime(time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)),
+				Annotations:       map[string]string{revisionAnnotation: revision, changeCauseAnnotation: cause},
+			},
+			Spec: appsv1.ReplicaSetSpec{
+				Template: v1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: labels},
+					Spec:       v1.PodSpec{Containers: containers},
+				},
+			},
+			Status: appsv1.ReplicaSetStatus{Replicas: replicas},
+		}
+	}
+
+	env := []v1.EnvVar{{Name: "A", Value: "1"}, {Name: "B", Value: "2"}}
+
+	return []runtime.Object{
+		deployment,
+		rs("1", "aaa", "initial", 0,
+			v1.Container{Name: "app", Image: "app:1", Env: env}),
+		rs("2", "bbb", "", 0,
+			v1.Container{Name: "app", Image: "app:2", Env: env}),
+		rs("3", "ccc", "add sidecar", 2,
+			v1.Container{Name: "app", Image: "app:2", Env: []v1.EnvVar{{Name: "B", Value: "3"}, {Name: "C", Value: "4"}}},
+			v1.Container{Name: "proxy", Image: "proxy:1"}),
+		// matches the selector, but isn't owned by the deployment
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "orphan", Namespace: "testns", Labels: lbl}},
+	}
+}
+
+func TestDeploymentHistory(t *testing.T) {
+	c := setupFakeClient(deploymentHistoryTestObjects()...)
+
+	history, err := c.DeploymentHistory(DeploymentOptions{
+		Logger:    &logfakes.Logger{},
+		Namespace: "testns",
+		Name:      "web",
+		Context:   context.Background(),
+	})
+
+	assert.Nil(t, err)
+	assert.Len(t, history, 3)
+	assert.Equal(t, int64(3), history[0].Revision)
+	assert.True(t, history[0].Current)
+	assert.Equal(t, "add sidecar", history[0].ChangeCause)
+	assert.Equal(t, []string{"app:2", "proxy:1"}, history[0].Images)
+	assert.Equal(t, int32(2), history[0].Replicas)
+	assert.False(t, history[1].Current)
+	assert.Equal(t, "web-aaa", history[2].ReplicaSet)
+}
+
+func TestDeploymentHistoryNotFound(t *testing.T) {
+	c := setupFakeClient()
+
+	_, err := c.DeploymentHistory(DeploymentOptions{
+		Logger:    &logfakes.Logger{},
+		Namespace: "testns",
+		Name:      "web",
+		Context:   context.Background(),
+	})
+
+	assert.Equal(t, 404, err.Code)
+}
+
+func TestDeploymentHistoryFail(t *testing.T) {
+	c := setupClient("testns", "dhtest1", true, true)
+
+	_, err := c.DeploymentHistory(DeploymentOptions{
+		Logger:    &logfakes.Logger{},
+		Namespace: "testns",
+		Name:      "dhtest1",
+		Context:   context.Background(),
+	})
+
+	assert.NotNil(t, err)
+}
+
+func TestDeploymentDiffDefault(t *testing.T) {
+	c := setupFakeClient(deploymentHistoryTestObjects()...)
+
+	diff, err := c.DeploymentDiff(DeploymentDiffOptions{
+		Logger:    &logfakes.Logger{},
+		Namespace: "testns",
+		Name:      "web",
+		Context:   context.Background(),
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), diff.From)
+	assert.Equal(t, int64(3), diff.To)
+	assert.Equal(t, []TemplateChange{
+		{Path: "spec.containers[app].env[A]", Type: ChangeRemoved, From: map[string]interface{}{"name": "A", "value": "1"}},
+		{Path: "spec.containers[app].env[B].value", Type: ChangeChanged, From: "2", To: "3"},
+		{Path: "spec.containers[app].env[C]", Type: ChangeAdded, To: map[string]interface{}{"name": "C", "value": "4"}},
+		{Path: "spec.containers[proxy]", Type: ChangeAdded, To: map[string]interface{}{"name": "proxy", "image": "proxy:1", "resources": map[string]interface{}{}}},
+	}, diff.Changes)
+}
+
+func TestDeploymentDiffRevisions(t *testing.T) {
+	c := setupFakeClient(deploymentHistoryTestObjects()...)
+
+	diff, err := c.DeploymentDiff(DeploymentDiffOptions{
+		Logger:    &logfakes.Logger{},
+		Namespace: "testns",
+		Name:      "web",
+		From:      1,
+		To:        2,
+		Context:   context.Background(),
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []TemplateChange{
+		{Path: "spec.containers[app].image", Type: ChangeChanged, From: "app:1", To: "app:2"},
+	}, diff.Changes)
+}
+
+func TestDeploymentDiffInvalidRevision(t *testing.T) {
+	c := setupFakeClient(deploymentHistoryTestObjects()...)
+
+	_, err := c.DeploymentDiff(DeploymentDiffOptions{
+		Logger:    &logfakes.Logger{},
+		Namespace: "testns",
+		Name:      "web",
+		To:        9,
+		Context:   context.Background(),
+	})
+	assert.Equal(t, 400, err.Code)
+
+	// nothing before the first revision
+	_, err = c.DeploymentDiff(DeploymentDiffOptions{
+		Logger:    &logfakes.Logger{},
+		Namespace: "testns",
+		Name:      "web",
+		To:        1,
+		Context:   context.Background(),
+	})
+	assert.Equal(t, 400, err.Code)
+}
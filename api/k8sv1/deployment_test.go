@@ -6,12 +6,14 @@ import (
 
 	logfakes "github.com/kubelens/kubelens/api/log/fakes"
 	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestGetDeploymentsDefaultSuccess(t *testing.T) {
 	c := setupClient("testns", "dpl1", false, false)
 
-	d, err := c.Deployments(DeploymentOptions{
+	d, _, err := c.Deployments(DeploymentOptions{
 		Logger:    &logfakes.Logger{},
 		Namespace: "testns",
 		// just use the config map labelselctor for the service for ease.
@@ -28,7 +30,7 @@ func TestGetDeploymentsDefaultSuccess(t *testing.T) {
 func TestGetDeploymentDefaultFail(t *testing.T) {
 	c := setupClient("testns", "dpl2", true, true)
 
-	_, err := c.Deployments(DeploymentOptions{
+	_, _, err := c.Deployments(DeploymentOptions{
 		Logger:     &logfakes.Logger{},
 		Namespace:  "testns",
 		LinkedName: "dpl2",
@@ -37,3 +39,25 @@ func TestGetDeploymentDefaultFail(t *testing.T) {
 
 	assert.NotNil(t, err)
 }
+
+func TestDeploymentsMany(t *testing.T) {
+	c := setupCachedClient(t,
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "testns"}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "testns"}},
+	)
+
+	d, _, err := c.Deployments(DeploymentOptions{
+		Logger:    &logfakes.Logger{},
+		Namespace: "testns",
+		Context:   context.Background(),
+	})
+
+	assert.Nil(t, err)
+
+	names := []string{}
+	for _, o := range d {
+		names = append(names, o.Deployment.Name)
+	}
+
+	assert.ElementsMatch(t, []string{"api", "web"}, names)
+}
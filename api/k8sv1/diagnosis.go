@@ -0,0 +1,252 @@
+package k8sv1
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kubelens/kubelens/api/errs"
+	v1 "k8s.io/api/core/v1"
+)
+
+// the severity of a Finding
+const (
+	SeverityCritical = "critical"
+	SeverityWarning  = "warning"
+)
+
+// the container waiting reasons for images that can't be pulled
+var imagePullReasons = map[string]bool{
+	"ImagePullBackOff": true,
+	"ErrImagePull":     true,
+	"InvalidImageName": true,
+}
+
+// the pod event reasons already explained by a container or pod condition finding
+var diagnosedEventReasons = map[string]bool{
+	"BackOff":          true,
+	"Failed":           true,
+	"FailedScheduling": true,
+}
+
+// Diagnosis is the list of problems found with a pod, most severe first.
+type Diagnosis struct {
+	// the name of the pod
+	Pod string `json:"pod"`
+	// the namespace of the pod
+	Namespace string `json:"namespace"`
+	// the problems found, empty if the pod looks fine
+	Findings []Finding `json:"findings"`
+}
+
+// Finding is a problem found with a pod, one of its containers or a service.
+type Finding struct {
+	// critical or warning
+	Severity string `json:"severity"`
+	// a short, machine understandable reason, e.g. OOMKilled
+	Reason string `json:"reason"`
+	// a human readable explanation
+	Message string `json:"message"`
+	// the container, empty for findings about the pod or service
+	Container string `json:"container,omitempty"`
+	// the exit code of the last termination
+	ExitCode *int32 `json:"exitCode,omitempty"`
+	// the number of times the container has restarted
+	RestartCount int32 `json:"restartCount,omitempty"`
+	// true if the logs of the previous instance of the container can be read, see LogOptions.Previous
+	PreviousLogs bool `json:"previousLogs,omitempty"`
+}
+
+// PodDiagnosis returns a diagnosis of the pod found by name.
+func (k *Client) PodDiagnosis(options PodOptions) (diagnosis *Diagnosis, apiErr *errs.APIError) {
+	overview, apiErr := k.Pod(options)
+
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	if overview == nil {
+		return nil, errs.NotFound(fmt.Sprintf("pod %s not found", options.Name))
+	}
+
+	return diagnosePod(overview.Pod, overview.Events), nil
+}
+
+// diagnosePod inspects the container states, termination reasons, exit codes, restarts
+// and events of a pod, returning findings ranked by severity then restarts.
+func diagnosePod(pod *v1.Pod, events []EventOverview) *Diagnosis {
+	diagnosis := &Diagnosis{
+		Pod:       pod.Name,
+		Namespace: pod.Namespace,
+		Findings:  []Finding{},
+	}
+
+	statuses := append(append([]v1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+
+	for _, cs := range statuses {
+		diagnosis.Findings = append(diagnosis.Findings, diagnoseContainer(pod, cs, events)...)
+	}
+
+	for _, c := range pod.Status.Conditions {
+		if c.Type == v1.PodScheduled && c.Status == v1.ConditionFalse && c.Reason == v1.PodReasonUnschedulable {
+			diagnosis.Findings = append(diagnosis.Findings, Finding{
+				Severity: SeverityCritical,
+				Reason:   v1.PodReasonUnschedulable,
+				Message:  fmt.Sprintf("the pod can't be scheduled: %s", c.Message),
+			})
+		}
+	}
+
+	if pod.Status.Phase == v1.PodFailed && len(pod.Status.Reason) > 0 {
+		diagnosis.Findings = append(diagnosis.Findings, Finding{
+			Severity: SeverityCritical,
+			Reason:   pod.Status.Reason,
+			Message:  pod.Status.Message,
+		})
+	}
+
+	// warnings not explained above, e.g. failed mounts or probes
+	seen := map[string]bool{}
+
+	for _, e := range events {
+		if e.Type != v1.EventTypeWarning || e.Kind != "Pod" || e.Name != pod.Name || diagnosedEventReasons[e.Reason] || seen[e.Reason] {
+			continue
+		}
+
+		seen[e.Reason] = true
+
+		diagnosis.Findings = append(diagnosis.Findings, Finding{
+			Severity: SeverityWarning,
+			Reason:   e.Reason,
+			Message:  fmt.Sprintf("%s (%d times)", e.Message, e.Count),
+		})
+	}
+
+	sort.SliceStable(diagnosis.Findings, func(i, j int) bool {
+		a, b := diagnosis.Findings[i], diagnosis.Findings[j]
+		if a.Severity != b.Severity {
+			return a.Severity == SeverityCritical
+		}
+		return a.RestartCount > b.RestartCount
+	})
+
+	return diagnosis
+}
+
+func diagnoseContainer(pod *v1.Pod, cs v1.ContainerStatus, events []EventOverview) (findings []Finding) {
+	last := cs.LastTerminationState.Terminated
+	if last == nil {
+		last = cs.State.Terminated
+	}
+
+	finding := Finding{
+		Container:    cs.Name,
+		RestartCount: cs.RestartCount,
+		PreviousLogs: cs.LastTerminationState.Terminated != nil,
+	}
+
+	if last != nil {
+		code := last.ExitCode
+		finding.ExitCode = &code
+	}
+
+	if w := cs.State.Waiting; w != nil {
+		switch {
+		case imagePullReasons[w.Reason]:
+			finding.Severity = SeverityCritical
+			finding.Reason = w.Reason
+			finding.Message = fmt.Sprintf("image %s can't be pulled: %s", cs.Image, pullMessage(w.Message, events))
+			return append(findings, finding)
+
+		case w.Reason == "CreateContainerConfigError" || w.Reason == "CreateContainerError":
+			finding.Severity = SeverityCritical
+			finding.Reason = w.Reason
+			finding.Message = w.Message
+			return append(findings, finding)
+
+		case w.Reason == "CrashLoopBackOff" && (last == nil || last.Reason != "OOMKilled"):
+			finding.Severity = SeverityCritical
+			finding.Reason = w.Reason
+			finding.Message = fmt.Sprintf("restarted %d times%s", cs.RestartCount, since(pod))
+			if last != nil {
+				finding.Message += fmt.Sprintf(", last exited with code %d%s", last.ExitCode, exitCodeHint(last))
+			}
+			return append(findings, finding)
+		}
+	}
+
+	if last != nil && last.Reason == "OOMKilled" {
+		finding.Severity = SeverityCritical
+		finding.Reason = "OOMKilled"
+		// the restart count covers every restart, not only those after an OOM kill
+		finding.Message = fmt.Sprintf("last run was OOMKilled, %d restarts%s, %s", cs.RestartCount, since(pod), memoryLimit(pod, cs.Name))
+		return append(findings, finding)
+	}
+
+	if last != nil && last.ExitCode != 0 && cs.RestartCount > 0 {
+		finding.Severity = SeverityWarning
+		finding.Reason = last.Reason
+		finding.Message = fmt.Sprintf("exited with code %d%s, restarted %d times%s", last.ExitCode, exitCodeHint(last), cs.RestartCount, since(pod))
+		return append(findings, finding)
+	}
+
+	if cs.State.Running != nil && !cs.Ready && pod.DeletionTimestamp == nil {
+		finding.Severity = SeverityWarning
+		finding.Reason = "NotReady"
+		finding.Message = "running but not ready, check the readiness probe"
+		return append(findings, finding)
+	}
+
+	return findings
+}
+
+// pullMessage returns the most recent pull failure event for the pod, or the waiting message.
+func pullMessage(message string, events []EventOverview) string {
+	for _, e := range events {
+		if e.Reason == "Failed" && strings.Contains(e.Message, "pull") {
+			return e.Message
+		}
+	}
+	return message
+}
+
+// since returns how long the pod has been running, for example " in 10m".
+func since(pod *v1.Pod) string {
+	if pod.Status.StartTime == nil {
+		return ""
+	}
+	return fmt.Sprintf(" in %s", now().Sub(pod.Status.StartTime.Time).Round(time.Second))
+}
+
+// memoryLimit describes the memory limit of a container or init container.
+func memoryLimit(pod *v1.Pod, container string) string {
+	for _, c := range append(append([]v1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...) {
+		if c.Name != container {
+			continue
+		}
+		if limit, ok := c.Resources.Limits[v1.ResourceMemory]; ok {
+			return fmt.Sprintf("limit %s", limit.String())
+		}
+	}
+	return "no memory limit, killed by the node"
+}
+
+// exitCodeHint explains common exit codes.
+func exitCodeHint(t *v1.ContainerStateTerminated) string {
+	switch t.ExitCode {
+	case 126:
+		return " (command not executable)"
+	case 127:
+		return " (command not found)"
+	case 137:
+		return " (killed, SIGKILL)"
+	case 143:
+		return " (terminated, SIGTERM)"
+	}
+
+	if len(t.Reason) > 0 {
+		return fmt.Sprintf(" (%s)", t.Reason)
+	}
+	return ""
+}
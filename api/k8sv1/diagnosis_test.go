@@ -0,0 +1,181 @@
+package k8sv1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	logfakes "github.com/kubelens/kubelens/api/log/fakes"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var diagnosisTestTime = time.Date(2021, 6, 1, 10, 10, 0, 0, time.UTC)
+
+func diagnosisTestPod() *v1.Pod {
+	started := metav1.NewTime(diagnosisTestTime.Add(-10 * time.Minute))
+
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "testns", Labels: map[string]string{"app": "web"}},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{Name: "app", Resources: v1.ResourceRequirements{Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("256Mi")}}},
+				{Name: "worker"},
+				{Name: "sidecar", Image: "sidecar:bad"},
+				{Name: "probe"},
+			},
+		},
+		Status: v1.PodStatus{
+			Phase:     v1.PodRunning,
+			StartTime: &started,
+			ContainerStatuses: []v1.ContainerStatus{
+				{
+					Name:                 "app",
+					RestartCount:         4,
+					State:                v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+					LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137}},
+				},
+				{
+					Name:                 "worker",
+					RestartCount:         6,
+					State:                v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+					LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: "Error", ExitCode: 127}},
+				},
+				{
+					Name:  "sidecar",
+					Image: "sidecar:bad",
+					State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "ImagePullBackOff", Message: "Back-off pulling image"}},
+				},
+				{
+					Name:  "probe",
+					State: v1.ContainerState{Running: &v1.ContainerStateRunning{}},
+				},
+			},
+		},
+	}
+}
+
+func diagnosisTestEvents() []EventOverview {
+	return []EventOverview{
+		{Type: v1.EventTypeWarning, Kind: "Pod", Name: "web", Reason: "Failed", Message: `Failed to pull image "sidecar:bad": not found`, Count: 3},
+		{Type: v1.EventTypeWarning, Kind: "Pod", Name: "web", Reason: "BackOff", Message: "Back-off restarting failed container", Count: 10},
+		{Type: v1.EventTypeWarning, Kind: "Pod", Name: "web", Reason: "FailedMount", Message: "configmap missing", Count: 2},
+		{Type: v1.EventTypeNormal, Kind: "Pod", Name: "web", Reason: "Pulled", Message: "pulled"},
+	}
+}
+
+func TestDiagnosePod(t *testing.T) {
+	now = func() time.Time { return diagnosisTestTime }
+	defer func() { now = time.Now }()
+
+	d := diagnosePod(diagnosisTestPod(), diagnosisTestEvents())
+
+	assert.Equal(t, "web", d.Pod)
+	assert.Len(t, d.Findings, 5)
+
+	// critical first, most restarts first
+	assert.Equal(t, "worker", d.Findings[0].Container)
+	assert.Equal(t, "CrashLoopBackOff", d.Findings[0].Reason)
+	assert.Equal(t, "restarted 6 times in 10m0s, last exited with code 127 (command not found)", d.Findings[0].Message)
+	assert.Equal(t, int32(127), *d.Findings[0].ExitCode)
+	assert.True(t, d.Findings[0].PreviousLogs)
+
+	assert.Equal(t, "app", d.Findings[1].Container)
+	assert.Equal(t, "OOMKilled", d.Findings[1].Reason)
+	assert.Equal(t, "last run was OOMKilled, 4 restarts in 10m0s, limit 256Mi", d.Findings[1].Message)
+
+	assert.Equal(t, "sidecar", d.Findings[2].Container)
+	assert.Equal(t, "ImagePullBackOff", d.Findings[2].Reason)
+	assert.Equal(t, `image sidecar:bad can't be pulled: Failed to pull image "sidecar:bad": not found`, d.Findings[2].Message)
+	assert.False(t, d.Findings[2].PreviousLogs)
+
+	assert.Equal(t, SeverityWarning, d.Findings[3].Severity)
+	assert.Equal(t, "NotReady", d.Findings[3].Reason)
+	assert.Equal(t, "FailedMount", d.Findings[4].Reason)
+}
+
+func TestDiagnosePodInitContainerOOMKilled(t *testing.T) {
+	d := diagnosePod(&v1.Pod{
+		Spec: v1.PodSpec{
+			InitContainers: []v1.Container{{Name: "migrate", Resources: v1.ResourceRequirements{Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("64Mi")}}}},
+			Containers:     []v1.Container{{Name: "app"}},
+		},
+		Status: v1.PodStatus{
+			Phase: v1.PodPending,
+			InitContainerStatuses: []v1.ContainerStatus{{
+				Name:                 "migrate",
+				RestartCount:         2,
+				State:                v1.ContainerState{Running: &v1.ContainerStateRunning{}},
+				LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137}},
+			}},
+		},
+	}, nil)
+
+	assert.Equal(t, "migrate", d.Findings[0].Container)
+	assert.Equal(t, "last run was OOMKilled, 2 restarts, limit 64Mi", d.Findings[0].Message)
+}
+
+func TestDiagnosePodHealthy(t *testing.T) {
+	d := diagnosePod(&v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "ok"},
+		Status: v1.PodStatus{
+			Phase:             v1.PodRunning,
+			ContainerStatuses: []v1.ContainerStatus{{Name: "app", Ready: true, State: v1.ContainerState{Running: &v1.ContainerStateRunning{}}}},
+		},
+	}, nil)
+
+	assert.Empty(t, d.Findings)
+}
+
+func TestDiagnosePodUnschedulable(t *testing.T) {
+	d := diagnosePod(&v1.Pod{
+		Status: v1.PodStatus{
+			Phase:      v1.PodPending,
+			Conditions: []v1.PodCondition{{Type: v1.PodScheduled, Status: v1.ConditionFalse, Reason: v1.PodReasonUnschedulable, Message: "0/3 nodes are available"}},
+		},
+	}, nil)
+
+	assert.Len(t, d.Findings, 1)
+	assert.Equal(t, SeverityCritical, d.Findings[0].Severity)
+	assert.Equal(t, "the pod can't be scheduled: 0/3 nodes are available", d.Findings[0].Message)
+}
+
+func TestPodDiagnosis(t *testing.T) {
+	c := setupFakeClient(diagnosisTestPod())
+
+	d, err := c.PodDiagnosis(PodOptions{
+		Logger:    &logfakes.Logger{},
+		Namespace: "testns",
+		Name:      "web",
+		Context:   context.Background(),
+	})
+
+	assert.Nil(t, err)
+	assert.Len(t, d.Findings, 4)
+
+	o, err := c.Overview(OverviewOptions{
+		Logger:     &logfakes.Logger{},
+		Namespace:  "testns",
+		LinkedName: "web",
+		Context:    context.Background(),
+	})
+
+	assert.Nil(t, err)
+	assert.Len(t, o.Diagnoses, 1)
+	assert.Equal(t, "web", o.Diagnoses[0].Pod)
+}
+
+func TestPodDiagnosisNotFound(t *testing.T) {
+	c := setupFakeClient()
+
+	_, err := c.PodDiagnosis(PodOptions{
+		Logger:    &logfakes.Logger{},
+		Namespace: "testns",
+		Name:      "web",
+		Context:   context.Background(),
+	})
+
+	assert.Equal(t, 404, err.Code)
+}
@@ -0,0 +1,213 @@
+package k8sv1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kubelens/kubelens/api/errs"
+	klog "github.com/kubelens/kubelens/api/log"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// EventOptions contains fields used for filtering when retrieving events
+type EventOptions struct {
+	// namespace to filter on
+	Namespace string `json:"namespace"`
+	// the kind of the involved object, e.g. Pod
+	Kind string `json:"kind"`
+	// the name of the involved object
+	Name string `json:"name"`
+	// Normal or Warning
+	Type string `json:"type"`
+	// selectors and pagination narrowing a list
+	ListOptions
+	// logger instance
+	Logger klog.Logger
+	// Context .
+	Context context.Context
+}
+
+// EventOverview is a kubernetes event about an object.
+type EventOverview struct {
+	// Normal or Warning
+	Type string `json:"type"`
+	// a short, machine understandable reason, e.g. BackOff
+	Reason string `json:"reason"`
+	// a human readable description
+	Message string `json:"message"`
+	// the kind of the involved object
+	Kind string `json:"kind"`
+	// the name of the involved object
+	Name string `json:"name"`
+	// the namespace of the involved object
+	Namespace string `json:"namespace"`
+	// the component reporting the event, e.g. kubelet
+	Source string `json:"source,omitempty"`
+	// the number of times the event has occurred
+	Count int32 `json:"count"`
+	// when the event first occurred
+	FirstSeen metav1.Time `json:"firstSeen"`
+	// when the event most recently occurred
+	LastSeen metav1.Time `json:"lastSeen"`
+	// the number of fields redacted, see config.Redaction
+	Redacted int `json:"redacted,omitempty"`
+}
+
+// Events returns a list of events given filter options, most recent first.
+func (k *Client) Events(options EventOptions) (overviews []EventOverview, meta ListMeta, apiErr *errs.APIError) {
+	if len(options.Type) > 0 && options.Type != v1.EventTypeNormal && options.Type != v1.EventTypeWarning {
+		return nil, meta, errs.ValidationError(fmt.Sprintf("type must be %s or %s", v1.EventTypeNormal, v1.EventTypeWarning))
+	}
+
+	lo, apiErr := options.listOptions(eventFields(&v1.Event{}))
+
+	if apiErr != nil {
+		return nil, meta, apiErr
+	}
+
+	selector := fields.Set{}
+
+	if len(options.Kind) > 0 {
+		selector["involvedObject.kind"] = options.Kind
+	}
+	if len(options.Name) > 0 {
+		selector["involvedObject.name"] = options.Name
+	}
+	if len(options.Type) > 0 {
+		selector["type"] = options.Type
+	}
+
+	if len(selector) > 0 {
+		lo.FieldSelector = strings.Trim(lo.FieldSelector+","+selector.AsSelector().String(), ",")
+	}
+
+	obj, err := listLinked("", lo, func(lo metav1.ListOptions) (runtime.Object, error) {
+		return k.listEvents(options.Context, options.Namespace, lo)
+	})
+
+	if err != nil {
+		klog.Trace()
+		return nil, meta, errs.InternalServerError(err.Error())
+	}
+
+	list := obj.(*v1.EventList)
+
+	return newEventOverviews(list.Items), newListMeta(list.ListMeta), nil
+}
+
+// objectEvents returns the events for an object. Events are informational, so
+// if they can't be listed the object is returned without them.
+func (k *Client) objectEvents(ctx context.Context, kind, namespace, name string) []EventOverview {
+	events, _, apiErr := k.Events(EventOptions{
+		Namespace: namespace,
+		Kind:      kind,
+		Name:      name,
+		Context:   ctx,
+	})
+
+	if apiErr != nil {
+		return nil
+	}
+
+	return events
+}
+
+// eventsFor returns the events about an object from a list of events.
+func eventsFor(events []EventOverview, kind, namespace, name string) (found []EventOverview) {
+	for _, e := range events {
+		if e.Kind == kind && e.Namespace == namespace && e.Name == name {
+			found = append(found, e)
+		}
+	}
+	return found
+}
+
+func newEventOverviews(events []v1.Event) (overviews []EventOverview) {
+	overviews = []EventOverview{}
+	r := newRedactor()
+
+	for _, e := range events {
+		overview := EventOverview{
+			Type:      e.Type,
+			Reason:    e.Reason,
+			Message:   strings.TrimSpace(e.Message),
+			Kind:      e.InvolvedObject.Kind,
+			Name:      e.InvolvedObject.Name,
+			Namespace: e.InvolvedObject.Namespace,
+			Source:    e.Source.Component,
+			Count:     e.Count,
+			FirstSeen: e.FirstTimestamp,
+			LastSeen:  e.LastTimestamp,
+		}
+
+		if len(overview.Source) == 0 {
+			overview.Source = e.ReportingController
+		}
+
+		// messages can quote the failing value, e.g. an invalid arg
+		overview.Message, overview.Redacted = r.value(overview.Message)
+
+		// events created with the events.k8s.io api set eventTime and series
+		// rather than the deprecated timestamps and count.
+		if overview.FirstSeen.IsZero() {
+			overview.FirstSeen = metav1.NewTime(e.EventTime.Time)
+		}
+		if overview.LastSeen.IsZero() {
+			overview.LastSeen = overview.FirstSeen
+			if e.Series != nil {
+				overview.LastSeen = metav1.NewTime(e.Series.LastObservedTime.Time)
+			}
+		}
+		if overview.Count == 0 {
+			overview.Count = 1
+			if e.Series != nil {
+				overview.Count = e.Series.Count
+			}
+		}
+
+		overviews = append(overviews, overview)
+	}
+
+	sort.SliceStable(overviews, func(i, j int) bool {
+		return overviews[j].LastSeen.Time.Before(overviews[i].LastSeen.Time)
+	})
+
+	return overviews
+}
+
+// eventFields returns the fields an event can be selected on.
+func eventFields(e *v1.Event) fields.Set {
+	set := objectMetaFields(e.ObjectMeta)
+	set["involvedObject.kind"] = e.InvolvedObject.Kind
+	set["involvedObject.namespace"] = e.InvolvedObject.Namespace
+	set["involvedObject.name"] = e.InvolvedObject.Name
+	set["involvedObject.uid"] = string(e.InvolvedObject.UID)
+	set["involvedObject.apiVersion"] = e.InvolvedObject.APIVersion
+	set["involvedObject.resourceVersion"] = e.InvolvedObject.ResourceVersion
+	set["involvedObject.fieldPath"] = e.InvolvedObject.FieldPath
+	set["reason"] = e.Reason
+	set["reportingComponent"] = e.ReportingController
+	set["source"] = e.Source.Component
+	set["type"] = e.Type
+	return set
+}
+
+// listEvents lists events from the cache when it has synced, otherwise from the api server.
+func (k *Client) listEvents(ctx context.Context, namespace string, lo metav1.ListOptions) (*v1.EventList, error) {
+	if k.cache.hasSynced(kindEvents) {
+		return k.cache.listEvents(namespace, lo)
+	}
+
+	clientset, err := k.wrapper.GetClientSet()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return clientset.CoreV1().Events(namespace).List(ctx, lo)
+}
@@ -0,0 +1,136 @@
+package k8sv1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	logfakes "github.com/kubelens/kubelens/api/log/fakes"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func eventTestObjects() []runtime.Object {
+	at := func(minutes int) metav1.Time {
+		return metav1.NewTime(time.Date(2021, 6, 1, 10, minutes, 0, 0, time.UTC))
+	}
+
+	event := func(name, kind, object, typ string, last metav1.Time) *v1.Event {
+		return &v1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "testns"},
+			InvolvedObject: v1.ObjectReference{Kind: kind, Name: object, Namespace: "testns"},
+			Type:           typ,
+			Reason:         "Reason",
+			Message:        "message\n",
+			Source:         v1.EventSource{Component: "kubelet"},
+			Count:          2,
+			FirstTimestamp: at(0),
+			LastTimestamp:  last,
+		}
+	}
+
+	lbl := map[string]string{"app": "web"}
+
+	return []runtime.Object{
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "testns", Labels: lbl}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "testns", Labels: lbl}},
+		event("e1", "Pod", "web-1", v1.EventTypeNormal, at(1)),
+		event("e2", "Pod", "web-1", v1.EventTypeWarning, at(3)),
+		event("e3", "Deployment", "web", v1.EventTypeNormal, at(2)),
+		event("e4", "Pod", "unrelated", v1.EventTypeWarning, at(4)),
+		// created with the events.k8s.io api
+		&v1.Event{
+			ObjectMeta:          metav1.ObjectMeta{Name: "e5", Namespace: "testns"},
+			InvolvedObject:      v1.ObjectReference{Kind: "Pod", Name: "web-1", Namespace: "testns"},
+			Type:                v1.EventTypeWarning,
+			ReportingController: "default-scheduler",
+			EventTime:           metav1.NewMicroTime(at(0).Time),
+			Series:              &v1.EventSeries{Count: 5, LastObservedTime: metav1.NewMicroTime(at(5).Time)},
+		},
+	}
+}
+
+func TestEventsSuccess(t *testing.T) {
+	c := setupFakeClient(eventTestObjects()...)
+
+	events, _, err := c.Events(EventOptions{
+		Logger:    &logfakes.Logger{},
+		Namespace: "testns",
+		Context:   context.Background(),
+	})
+
+	assert.Nil(t, err)
+	assert.Len(t, events, 5)
+
+	// most recent first
+	assert.Equal(t, "default-scheduler", events[0].Source)
+	assert.Equal(t, int32(5), events[0].Count)
+	assert.Equal(t, 5, events[0].LastSeen.Minute())
+	assert.Equal(t, 0, events[0].FirstSeen.Minute())
+	assert.Equal(t, "message", events[1].Message)
+	assert.Equal(t, "kubelet", events[1].Source)
+}
+
+func TestEventsFiltered(t *testing.T) {
+	c := setupCachedClient(t, eventTestObjects()...)
+
+	events, _, err := c.Events(EventOptions{
+		Logger:    &logfakes.Logger{},
+		Namespace: "testns",
+		Kind:      "Pod",
+		Name:      "web-1",
+		Type:      v1.EventTypeWarning,
+		Context:   context.Background(),
+	})
+
+	assert.Nil(t, err)
+	assert.Len(t, events, 2)
+
+	for _, e := range events {
+		assert.Equal(t, "web-1", e.Name)
+		assert.Equal(t, v1.EventTypeWarning, e.Type)
+	}
+}
+
+func TestEventsInvalidType(t *testing.T) {
+	c := New(&mockWrapper{})
+
+	_, _, err := c.Events(EventOptions{
+		Logger:  &logfakes.Logger{},
+		Type:    "Error",
+		Context: context.Background(),
+	})
+
+	assert.Equal(t, 400, err.Code)
+}
+
+func TestEventsFail(t *testing.T) {
+	c := setupClient("testns", "evtest1", true, true)
+
+	_, _, err := c.Events(EventOptions{
+		Logger:    &logfakes.Logger{},
+		Namespace: "testns",
+		Context:   context.Background(),
+	})
+
+	assert.NotNil(t, err)
+}
+
+func TestOverviewEvents(t *testing.T) {
+	c := setupFakeClient(eventTestObjects()...)
+
+	o, err := c.Overview(OverviewOptions{
+		Logger:     &logfakes.Logger{},
+		Namespace:  "testns",
+		LinkedName: "web",
+		Context:    context.Background(),
+	})
+
+	assert.Nil(t, err)
+	assert.Len(t, o.Events, 4)
+	assert.Len(t, o.Pods[0].Events, 3)
+	assert.Len(t, o.Deployments[0].Events, 1)
+}
@@ -7,11 +7,33 @@ import (
 
 	"github.com/kubelens/kubelens/api/errs"
 	"github.com/kubelens/kubelens/api/k8sv1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 // K8sV1 .
 type K8sV1 struct {
-	fail *bool
+	fail  *bool
+	cache *k8sv1.CacheStatus
+}
+
+// NewFailingK8sV1 returns a K8sV1 that fails the sanity check and overview when fail is true.
+func NewFailingK8sV1(fail *bool) *K8sV1 {
+	return &K8sV1{fail: fail}
+}
+
+// NewCachedK8sV1 returns a K8sV1 reporting the given informer cache status.
+func NewCachedK8sV1(status k8sv1.CacheStatus) *K8sV1 {
+	return &K8sV1{cache: &status}
+}
+
+// CacheStatus .
+func (m *K8sV1) CacheStatus() (status k8sv1.CacheStatus) {
+	if m.cache != nil {
+		return *m.cache
+	}
+	return status
 }
 
 // SanityCheck .
@@ -45,6 +67,20 @@ func (m *K8sV1) Overview(options k8sv1.OverviewOptions) (overview *k8sv1.Overvie
 				Namespace:  options.Namespace,
 			},
 		},
+		StatefulSets: []k8sv1.StatefulSetOverview{
+			{
+				Name:       options.LinkedName + "-statefulset",
+				LinkedName: options.LinkedName,
+				Namespace:  options.Namespace,
+			},
+		},
+		CronJobs: []k8sv1.CronJobOverview{
+			{
+				Name:       options.LinkedName + "-cronjob",
+				LinkedName: options.LinkedName,
+				Namespace:  options.Namespace,
+			},
+		},
 		Jobs: []k8sv1.JobOverview{
 			{
 				Name:       options.LinkedName + "-job",
@@ -77,9 +113,13 @@ func (m *K8sV1) Overview(options k8sv1.OverviewOptions) (overview *k8sv1.Overvie
 }
 
 // Overviews .
-func (m *K8sV1) Overviews(options k8sv1.OverviewOptions) (overviews []k8sv1.Overview, apiErr *errs.APIError) {
+func (m *K8sV1) Overviews(options k8sv1.OverviewOptions) (overviews []k8sv1.Overview, meta k8sv1.ListMeta, apiErr *errs.APIError) {
 	if options.Namespace == "bad" {
-		return overviews, errs.InternalServerError("Overviews Test Error")
+		return overviews, meta, errs.InternalServerError("Overviews Test Error")
+	}
+
+	if options.Limit > 0 {
+		meta.Continue = "next"
 	}
 
 	return []k8sv1.Overview{
@@ -87,6 +127,25 @@ func (m *K8sV1) Overviews(options k8sv1.OverviewOptions) (overviews []k8sv1.Over
 			LinkedName: options.LinkedName,
 			Namespace:  options.Namespace,
 		},
+	}, meta, nil
+}
+
+// Graph .
+func (m *K8sV1) Graph(options k8sv1.GraphOptions) (graph *k8sv1.Graph, apiErr *errs.APIError) {
+	if options.Namespace == "bad" {
+		return nil, errs.InternalServerError("Graph Test Error")
+	}
+
+	return &k8sv1.Graph{
+		LinkedName: options.LinkedName,
+		Namespace:  options.Namespace,
+		Nodes: []k8sv1.GraphNode{
+			{ID: "Deployment/" + options.Namespace + "/dp", Kind: "Deployment", Name: "dp", Namespace: options.Namespace, LinkedName: options.LinkedName},
+			{ID: "ReplicaSet/" + options.Namespace + "/dp-1", Kind: "ReplicaSet", Name: "dp-1", Namespace: options.Namespace},
+		},
+		Edges: []k8sv1.GraphEdge{
+			{From: "Deployment/" + options.Namespace + "/dp", To: "ReplicaSet/" + options.Namespace + "/dp-1", Type: k8sv1.EdgeOwns},
+		},
 	}, nil
 }
 
@@ -104,9 +163,16 @@ func (m *K8sV1) Pod(options k8sv1.PodOptions) (overview *k8sv1.PodOverview, apiE
 }
 
 // Pods .
-func (m *K8sV1) Pods(options k8sv1.PodOptions) (overviews []k8sv1.PodOverview, apiErr *errs.APIError) {
+func (m *K8sV1) Pods(options k8sv1.PodOptions) (overviews []k8sv1.PodOverview, meta k8sv1.ListMeta, apiErr *errs.APIError) {
 	if options.Namespace == "bad" {
-		return overviews, errs.InternalServerError("Pods Test Error")
+		return overviews, meta, errs.InternalServerError("Pods Test Error")
+	}
+	if options.FieldSelector == "bad" {
+		return overviews, meta, errs.ValidationError("invalid fieldSelector")
+	}
+
+	if options.Limit > 0 {
+		meta.Continue = "next"
 	}
 
 	return []k8sv1.PodOverview{
@@ -115,7 +181,7 @@ func (m *K8sV1) Pods(options k8sv1.PodOptions) (overviews []k8sv1.PodOverview, a
 			LinkedName: options.LinkedName,
 			Namespace:  options.Namespace,
 		},
-	}, nil
+	}, meta, nil
 }
 
 // Logs .
@@ -123,14 +189,26 @@ func (m *K8sV1) Logs(options k8sv1.LogOptions) (logs k8sv1.Log, apiErr *errs.API
 	if options.Namespace == "bad2" {
 		logs = k8sv1.Log{
 			Pod:    options.PodName,
-			Output: "No logs returned from K8",
+			Output: []k8sv1.LogLine{{Text: "No logs returned from K8"}},
 		}
 		return logs, errs.InternalServerError("Logs Test Error")
 	}
 
 	return k8sv1.Log{
 		Pod:    options.PodName,
-		Output: "some output",
+		Output: []k8sv1.LogLine{{Text: "some output"}},
+	}, nil
+}
+
+// LinkedLogs .
+func (m *K8sV1) LinkedLogs(options k8sv1.LogOptions) (logs k8sv1.Log, apiErr *errs.APIError) {
+	if options.Namespace == "bad2" {
+		return logs, errs.InternalServerError("Logs Test Error")
+	}
+
+	return k8sv1.Log{
+		Sources: []string{"test/test"},
+		Output:  []k8sv1.LogLine{{Text: k8sv1.LogPrefix("test", "test") + "some output"}},
 	}, nil
 }
 
@@ -141,6 +219,26 @@ func (m *K8sV1) ReadLogs(options k8sv1.LogOptions) (rc io.ReadCloser, apiErr *er
 	return stringReadCloser, nil
 }
 
+// WatchPods .
+func (m *K8sV1) WatchPods(options k8sv1.PodOptions) (w watch.Interface, apiErr *errs.APIError) {
+	if options.Namespace == "bad" {
+		return nil, errs.InternalServerError("WatchPods Test Error")
+	}
+
+	fw := watch.NewFakeWithChanSize(1, false)
+	fw.Add(&v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: options.Namespace},
+		Status: v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{{
+				Name:  "test",
+				State: v1.ContainerState{Running: &v1.ContainerStateRunning{}},
+			}},
+		},
+	})
+
+	return fw, nil
+}
+
 // Service .
 func (m *K8sV1) Service(options k8sv1.ServiceOptions) (overview *k8sv1.ServiceOverview, apiErr *errs.APIError) {
 	if options.Namespace == "bad" {
@@ -155,9 +253,9 @@ func (m *K8sV1) Service(options k8sv1.ServiceOptions) (overview *k8sv1.ServiceOv
 }
 
 // Services .
-func (m *K8sV1) Services(options k8sv1.ServiceOptions) (overviews []k8sv1.ServiceOverview, apiErr *errs.APIError) {
+func (m *K8sV1) Services(options k8sv1.ServiceOptions) (overviews []k8sv1.ServiceOverview, meta k8sv1.ListMeta, apiErr *errs.APIError) {
 	if options.Namespace == "bad" {
-		return overviews, errs.InternalServerError("Services Test Error")
+		return overviews, meta, errs.InternalServerError("Services Test Error")
 	}
 
 	return []k8sv1.ServiceOverview{
@@ -166,7 +264,7 @@ func (m *K8sV1) Services(options k8sv1.ServiceOptions) (overviews []k8sv1.Servic
 			LinkedName: options.LinkedName,
 			Namespace:  options.Namespace,
 		},
-	}, nil
+	}, meta, nil
 }
 
 // Deployment .
@@ -183,9 +281,9 @@ func (m *K8sV1) Deployment(options k8sv1.DeploymentOptions) (overview *k8sv1.Dep
 }
 
 // Deployments .
-func (m *K8sV1) Deployments(options k8sv1.DeploymentOptions) (overviews []k8sv1.DeploymentOverview, apiErr *errs.APIError) {
+func (m *K8sV1) Deployments(options k8sv1.DeploymentOptions) (overviews []k8sv1.DeploymentOverview, meta k8sv1.ListMeta, apiErr *errs.APIError) {
 	if options.Namespace == "bad" {
-		return overviews, errs.InternalServerError("Deployments Test Error")
+		return overviews, meta, errs.InternalServerError("Deployments Test Error")
 	}
 
 	return []k8sv1.DeploymentOverview{
@@ -194,6 +292,66 @@ func (m *K8sV1) Deployments(options k8sv1.DeploymentOptions) (overviews []k8sv1.
 			LinkedName: options.LinkedName,
 			Namespace:  options.Namespace,
 		},
+	}, meta, nil
+}
+
+// PodDiagnosis .
+func (m *K8sV1) PodDiagnosis(options k8sv1.PodOptions) (diagnosis *k8sv1.Diagnosis, apiErr *errs.APIError) {
+	if options.Namespace == "bad" {
+		return diagnosis, errs.InternalServerError("PodDiagnosis Test Error")
+	}
+
+	return &k8sv1.Diagnosis{
+		Pod:       options.Name,
+		Namespace: options.Namespace,
+		Findings: []k8sv1.Finding{
+			{
+				Severity: k8sv1.SeverityCritical,
+				Reason:   "CrashLoopBackOff",
+				Message:  "restarted 4 times",
+			},
+		},
+	}, nil
+}
+
+// DeploymentHistory .
+func (m *K8sV1) DeploymentHistory(options k8sv1.DeploymentOptions) (history []k8sv1.DeploymentRevision, apiErr *errs.APIError) {
+	if options.Namespace == "bad" {
+		return history, errs.InternalServerError("DeploymentHistory Test Error")
+	}
+
+	return []k8sv1.DeploymentRevision{
+		{
+			Revision:   2,
+			ReplicaSet: options.Name + "-2",
+			Images:     []string{"app:2"},
+			Current:    true,
+		},
+		{
+			Revision:   1,
+			ReplicaSet: options.Name + "-1",
+			Images:     []string{"app:1"},
+		},
+	}, nil
+}
+
+// DeploymentDiff .
+func (m *K8sV1) DeploymentDiff(options k8sv1.DeploymentDiffOptions) (diff *k8sv1.DeploymentDiff, apiErr *errs.APIError) {
+	if options.Namespace == "bad" {
+		return diff, errs.InternalServerError("DeploymentDiff Test Error")
+	}
+
+	return &k8sv1.DeploymentDiff{
+		From: options.From,
+		To:   options.To,
+		Changes: []k8sv1.TemplateChange{
+			{
+				Path: "spec.containers[app].image",
+				Type: k8sv1.ChangeChanged,
+				From: "app:1",
+				To:   "app:2",
+			},
+		},
 	}, nil
 }
 
@@ -211,9 +369,9 @@ func (m *K8sV1) DaemonSet(options k8sv1.DaemonSetOptions) (overview *k8sv1.Daemo
 }
 
 // DaemonSets .
-func (m *K8sV1) DaemonSets(options k8sv1.DaemonSetOptions) (overviews []k8sv1.DaemonSetOverview, apiErr *errs.APIError) {
+func (m *K8sV1) DaemonSets(options k8sv1.DaemonSetOptions) (overviews []k8sv1.DaemonSetOverview, meta k8sv1.ListMeta, apiErr *errs.APIError) {
 	if options.Namespace == "bad" {
-		return overviews, errs.InternalServerError("DaemonSets Test Error")
+		return overviews, meta, errs.InternalServerError("DaemonSets Test Error")
 	}
 
 	return []k8sv1.DaemonSetOverview{
@@ -222,9 +380,37 @@ func (m *K8sV1) DaemonSets(options k8sv1.DaemonSetOptions) (overviews []k8sv1.Da
 			LinkedName: options.LinkedName,
 			Namespace:  options.Namespace,
 		},
+	}, meta, nil
+}
+
+// StatefulSet .
+func (m *K8sV1) StatefulSet(options k8sv1.StatefulSetOptions) (overview *k8sv1.StatefulSetOverview, apiErr *errs.APIError) {
+	if options.Namespace == "bad" {
+		return overview, errs.InternalServerError("StatefulSet Test Error")
+	}
+
+	return &k8sv1.StatefulSetOverview{
+		Name:       options.Name + "-statefulset",
+		LinkedName: options.LinkedName,
+		Namespace:  options.Namespace,
 	}, nil
 }
 
+// StatefulSets .
+func (m *K8sV1) StatefulSets(options k8sv1.StatefulSetOptions) (overviews []k8sv1.StatefulSetOverview, meta k8sv1.ListMeta, apiErr *errs.APIError) {
+	if options.Namespace == "bad" {
+		return overviews, meta, errs.InternalServerError("StatefulSets Test Error")
+	}
+
+	return []k8sv1.StatefulSetOverview{
+		{
+			Name:       options.Name + "-statefulset",
+			LinkedName: options.LinkedName,
+			Namespace:  options.Namespace,
+		},
+	}, meta, nil
+}
+
 // Job .
 func (m *K8sV1) Job(options k8sv1.JobOptions) (overview *k8sv1.JobOverview, apiErr *errs.APIError) {
 	if options.Namespace == "bad" {
@@ -239,9 +425,9 @@ func (m *K8sV1) Job(options k8sv1.JobOptions) (overview *k8sv1.JobOverview, apiE
 }
 
 // Jobs .
-func (m *K8sV1) Jobs(options k8sv1.JobOptions) (overviews []k8sv1.JobOverview, apiErr *errs.APIError) {
+func (m *K8sV1) Jobs(options k8sv1.JobOptions) (overviews []k8sv1.JobOverview, meta k8sv1.ListMeta, apiErr *errs.APIError) {
 	if options.Namespace == "bad" {
-		return overviews, errs.InternalServerError("DaemonSets Test Error")
+		return overviews, meta, errs.InternalServerError("DaemonSets Test Error")
 	}
 
 	return []k8sv1.JobOverview{
@@ -250,9 +436,109 @@ func (m *K8sV1) Jobs(options k8sv1.JobOptions) (overviews []k8sv1.JobOverview, a
 			LinkedName: options.LinkedName,
 			Namespace:  options.Namespace,
 		},
+	}, meta, nil
+}
+
+// CronJob .
+func (m *K8sV1) CronJob(options k8sv1.CronJobOptions) (overview *k8sv1.CronJobOverview, apiErr *errs.APIError) {
+	if options.Namespace == "bad" {
+		return overview, errs.InternalServerError("CronJob Test Error")
+	}
+
+	return &k8sv1.CronJobOverview{
+		Name:       options.Name + "-cronjob",
+		LinkedName: options.LinkedName,
+		Namespace:  options.Namespace,
+	}, nil
+}
+
+// CronJobs .
+func (m *K8sV1) CronJobs(options k8sv1.CronJobOptions) (overviews []k8sv1.CronJobOverview, meta k8sv1.ListMeta, apiErr *errs.APIError) {
+	if options.Namespace == "bad" {
+		return overviews, meta, errs.InternalServerError("CronJobs Test Error")
+	}
+
+	return []k8sv1.CronJobOverview{
+		{
+			Name:       options.Name + "-cronjob",
+			LinkedName: options.LinkedName,
+			Namespace:  options.Namespace,
+		},
+	}, meta, nil
+}
+
+// ConfigMap .
+func (m *K8sV1) ConfigMap(options k8sv1.ConfigMapOptions) (overview *k8sv1.ConfigMapOverview, apiErr *errs.APIError) {
+	if options.Namespace == "bad" {
+		return overview, errs.InternalServerError("ConfigMap Test Error")
+	}
+
+	return &k8sv1.ConfigMapOverview{
+		Name:       options.Name + "-configmap",
+		LinkedName: options.LinkedName,
+		Namespace:  options.Namespace,
+	}, nil
+}
+
+// ConfigMaps .
+func (m *K8sV1) ConfigMaps(options k8sv1.ConfigMapOptions) (overviews []k8sv1.ConfigMapOverview, meta k8sv1.ListMeta, apiErr *errs.APIError) {
+	if options.Namespace == "bad" {
+		return overviews, meta, errs.InternalServerError("ConfigMaps Test Error")
+	}
+
+	return []k8sv1.ConfigMapOverview{
+		{
+			Name:       options.Name + "-configmap",
+			LinkedName: options.LinkedName,
+			Namespace:  options.Namespace,
+		},
+	}, meta, nil
+}
+
+// Events .
+func (m *K8sV1) Events(options k8sv1.EventOptions) (overviews []k8sv1.EventOverview, meta k8sv1.ListMeta, apiErr *errs.APIError) {
+	if options.Namespace == "bad" {
+		return overviews, meta, errs.InternalServerError("Events Test Error")
+	}
+
+	return []k8sv1.EventOverview{
+		{
+			Type:      "Warning",
+			Reason:    "BackOff",
+			Kind:      options.Kind,
+			Name:      options.Name,
+			Namespace: options.Namespace,
+			Count:     1,
+		},
+	}, meta, nil
+}
+
+// Node .
+func (m *K8sV1) Node(options k8sv1.NodeOptions) (overview *k8sv1.NodeOverview, apiErr *errs.APIError) {
+	if options.Name == "bad" {
+		return overview, errs.InternalServerError("Node Test Error")
+	}
+
+	return &k8sv1.NodeOverview{
+		Name: options.Name,
+		Pods: []k8sv1.NodePod{},
 	}, nil
 }
 
+// Nodes .
+func (m *K8sV1) Nodes(options k8sv1.NodeOptions) (overviews []k8sv1.NodeOverview, meta k8sv1.ListMeta, apiErr *errs.APIError) {
+	if m.fail != nil && *m.fail {
+		return overviews, meta, errs.InternalServerError("Nodes Test Error")
+	}
+
+	return []k8sv1.NodeOverview{
+		{
+			Name: "node-1",
+			Pods: []k8sv1.NodePod{},
+		},
+	}, meta, nil
+}
+
 // ReplicaSet .
 func (m *K8sV1) ReplicaSet(options k8sv1.ReplicaSetOptions) (overview *k8sv1.ReplicaSetOverview, apiErr *errs.APIError) {
 	if options.Namespace == "bad" {
@@ -267,9 +553,9 @@ func (m *K8sV1) ReplicaSet(options k8sv1.ReplicaSetOptions) (overview *k8sv1.Rep
 }
 
 // ReplicaSets .
-func (m *K8sV1) ReplicaSets(options k8sv1.ReplicaSetOptions) (overviews []k8sv1.ReplicaSetOverview, apiErr *errs.APIError) {
+func (m *K8sV1) ReplicaSets(options k8sv1.ReplicaSetOptions) (overviews []k8sv1.ReplicaSetOverview, meta k8sv1.ListMeta, apiErr *errs.APIError) {
 	if options.Namespace == "bad" {
-		return overviews, errs.InternalServerError("ReplicaSet Test Error")
+		return overviews, meta, errs.InternalServerError("ReplicaSet Test Error")
 	}
 
 	return []k8sv1.ReplicaSetOverview{
@@ -278,5 +564,5 @@ func (m *K8sV1) ReplicaSets(options k8sv1.ReplicaSetOptions) (overviews []k8sv1.
 			LinkedName: options.LinkedName,
 			Namespace:  options.Namespace,
 		},
-	}, nil
+	}, meta, nil
 }
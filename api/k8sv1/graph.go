@@ -0,0 +1,272 @@
+package k8sv1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/kubelens/kubelens/api/errs"
+
+	klog "github.com/kubelens/kubelens/api/log"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// EdgeOwns is an edge from an owner to an object listing it in its ownerReferences.
+	EdgeOwns = "owns"
+	// EdgeSelects is an edge from a service to a pod matching its selector.
+	EdgeSelects = "selects"
+)
+
+// GraphOptions contains fields used for filtering when building an ownership graph.
+type GraphOptions struct {
+	// the value of the first link label the object has, e.g. "app=NAME", see config.LinkKeys
+	LinkedName string `json:"linkedName"`
+	// namespace to filter on
+	Namespace string `json:"namespace"`
+	// logger instance
+	Logger klog.Logger
+	// Context .
+	Context context.Context
+}
+
+// Graph is the set of objects related to a linked name and how they relate.
+type Graph struct {
+	LinkedName string      `json:"linkedName"`
+	Namespace  string      `json:"namespace,omitempty"`
+	Nodes      []GraphNode `json:"nodes"`
+	Edges      []GraphEdge `json:"edges"`
+}
+
+// GraphNode is an object in the graph.
+type GraphNode struct {
+	// unique within the graph, "Kind/namespace/name"
+	ID        string `json:"id"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	// the value from the label "app=NAME", empty when the object is only reached through its relations
+	LinkedName string `json:"linkedName,omitempty"`
+}
+
+// GraphEdge is a relation between two nodes of the graph.
+type GraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	// EdgeOwns or EdgeSelects
+	Type string `json:"type"`
+}
+
+// graphObject is an object collected while building the graph.
+type graphObject struct {
+	node     GraphNode
+	uid      string
+	owners   []metav1.OwnerReference
+	labels   map[string]string
+	selector map[string]string
+}
+
+// Graph returns the objects labelled with the linked name, along with everything
+// reachable from them through ownerReferences and service selectors, whether or
+// not it carries the link label.
+func (k *Client) Graph(options GraphOptions) (graph *Graph, apiErr *errs.APIError) {
+	objects, err := k.graphObjects(options.Context, options.Logger, options.Namespace)
+
+	if err != nil {
+		klog.Trace()
+		return nil, errs.InternalServerError(err.Error())
+	}
+
+	graph = &Graph{
+		LinkedName: options.LinkedName,
+		Namespace:  options.Namespace,
+		Nodes:      []GraphNode{},
+		Edges:      []GraphEdge{},
+	}
+
+	uids := map[string]*graphObject{}
+	for _, o := range objects {
+		if len(o.uid) > 0 {
+			uids[o.uid] = o
+		}
+	}
+
+	edges := []GraphEdge{}
+
+	for _, o := range objects {
+		for _, ref := range o.owners {
+			if owner, ok := uids[string(ref.UID)]; ok && len(ref.UID) > 0 {
+				edges = append(edges, GraphEdge{From: owner.node.ID, To: o.node.ID, Type: EdgeOwns})
+			}
+		}
+
+		if o.node.Kind != "Service" || len(o.selector) == 0 {
+			continue
+		}
+
+		selector := labels.SelectorFromSet(o.selector)
+
+		for _, p := range objects {
+			if p.node.Kind == "Pod" && p.node.Namespace == o.node.Namespace && selector.Matches(labels.Set(p.labels)) {
+				edges = append(edges, GraphEdge{From: o.node.ID, To: p.node.ID, Type: EdgeSelects})
+			}
+		}
+	}
+
+	seeds := map[string]bool{}
+	included := map[string]bool{}
+
+	for _, o := range objects {
+		if len(o.node.LinkedName) > 0 && o.node.LinkedName == options.LinkedName {
+			seeds[o.node.ID] = true
+			included[o.node.ID] = true
+		}
+	}
+
+	// ownership is followed both ways, so the owners and the owned objects of anything
+	// included are too. A service is included when it selects an included pod, but only
+	// a labelled service pulls in the pods it selects, otherwise a shared selector would
+	// drag in unrelated applications.
+	for changed := true; changed; {
+		changed = false
+
+		for _, e := range edges {
+			if included[e.From] && included[e.To] {
+				continue
+			}
+
+			switch {
+			case e.Type == EdgeOwns && (included[e.From] || included[e.To]),
+				e.Type == EdgeSelects && included[e.To],
+				e.Type == EdgeSelects && seeds[e.From]:
+				included[e.From] = true
+				included[e.To] = true
+				changed = true
+			}
+		}
+	}
+
+	for _, o := range objects {
+		if included[o.node.ID] {
+			graph.Nodes = append(graph.Nodes, o.node)
+		}
+	}
+
+	for _, e := range edges {
+		if included[e.From] && included[e.To] {
+			graph.Edges = append(graph.Edges, e)
+		}
+	}
+
+	sort.SliceStable(graph.Nodes, func(i, j int) bool {
+		if graphKindOrder[graph.Nodes[i].Kind] != graphKindOrder[graph.Nodes[j].Kind] {
+			return graphKindOrder[graph.Nodes[i].Kind] < graphKindOrder[graph.Nodes[j].Kind]
+		}
+		return graph.Nodes[i].ID < graph.Nodes[j].ID
+	})
+
+	return graph, nil
+}
+
+// graphKindOrder orders the nodes of a graph from the top of the ownership chains down.
+var graphKindOrder = map[string]int{
+	"Service":     0,
+	"CronJob":     1,
+	"Deployment":  2,
+	"StatefulSet": 3,
+	"DaemonSet":   4,
+	"Job":         5,
+	"ReplicaSet":  6,
+	"Pod":         7,
+}
+
+// graphObjects lists every kind that can take part in a graph. A kind that can't be listed, e.g. one
+// the api server doesn't serve or the service account can't list, is left out of the graph, it only
+// fails if no kind can be listed.
+func (k *Client) graphObjects(ctx context.Context, l klog.Logger, namespace string) (objects []*graphObject, err error) {
+	add := func(kind string, meta metav1.ObjectMeta, selector map[string]string) {
+		objects = append(objects, &graphObject{
+			node: GraphNode{
+				ID:         fmt.Sprintf("%s/%s/%s", kind, meta.Namespace, meta.Name),
+				Kind:       kind,
+				Name:       meta.Name,
+				Namespace:  meta.Namespace,
+				LinkedName: getLinkedName(meta.Namespace, meta.Labels),
+			},
+			uid:      string(meta.UID),
+			owners:   meta.OwnerReferences,
+			labels:   meta.Labels,
+			selector: selector,
+		})
+	}
+
+	listed := 0
+	var listErr error
+
+	skip := func(kind string, err error) bool {
+		if err != nil {
+			l.Errorf("Graph List %s Error : %s", kind, err.Error())
+			listErr = err
+			return true
+		}
+		listed++
+		return false
+	}
+
+	lo := metav1.ListOptions{}
+
+	if svcs, err := k.listServices(ctx, namespace, lo); !skip("Service", err) {
+		for _, item := range svcs.Items {
+			add("Service", item.ObjectMeta, item.Spec.Selector)
+		}
+	}
+
+	if cjs, err := k.listCronJobs(ctx, namespace, lo); !skip("CronJob", err) {
+		for _, item := range cjs.Items {
+			add("CronJob", item.ObjectMeta, nil)
+		}
+	}
+
+	if dps, err := k.listDeployments(ctx, namespace, lo); !skip("Deployment", err) {
+		for _, item := range dps.Items {
+			add("Deployment", item.ObjectMeta, nil)
+		}
+	}
+
+	if sts, err := k.listStatefulSets(ctx, namespace, lo); !skip("StatefulSet", err) {
+		for _, item := range sts.Items {
+			add("StatefulSet", item.ObjectMeta, nil)
+		}
+	}
+
+	if dss, err := k.listDaemonSets(ctx, namespace, lo); !skip("DaemonSet", err) {
+		for _, item := range dss.Items {
+			add("DaemonSet", item.ObjectMeta, nil)
+		}
+	}
+
+	if jbs, err := k.listJobs(ctx, namespace, lo); !skip("Job", err) {
+		for _, item := range jbs.Items {
+			add("Job", item.ObjectMeta, nil)
+		}
+	}
+
+	if rss, err := k.listReplicaSets(ctx, namespace, lo); !skip("ReplicaSet", err) {
+		for _, item := range rss.Items {
+			add("ReplicaSet", item.ObjectMeta, nil)
+		}
+	}
+
+	if pods, err := k.listPods(ctx, namespace, lo); !skip("Pod", err) {
+		for _, item := range pods.Items {
+			add("Pod", item.ObjectMeta, nil)
+		}
+	}
+
+	if listed == 0 {
+		return nil, listErr
+	}
+
+	return objects, nil
+}
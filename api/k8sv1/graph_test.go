@@ -0,0 +1,143 @@
+package k8sv1
+
+import (
+	"context"
+	"testing"
+
+	logfakes "github.com/kubelens/kubelens/api/log/fakes"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func graphOwner(kind, name, uid string) []metav1.OwnerReference {
+	return []metav1.OwnerReference{{Kind: kind, Name: name, UID: types.UID(uid)}}
+}
+
+func graphTestObjects() []runtime.Object {
+	return []runtime.Object{
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "testns", UID: "d1", Labels: map[string]string{"app": "web"}}},
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "testns", UID: "r1", OwnerReferences: graphOwner("Deployment", "web", "d1")}},
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1-a", Namespace: "testns", UID: "p1", Labels: map[string]string{"tier": "web"}, OwnerReferences: graphOwner("ReplicaSet", "web-1", "r1")}},
+		&v1.Service{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "testns", UID: "s1"}, Spec: v1.ServiceSpec{Selector: map[string]string{"tier": "web"}}},
+		// another application whose pods the unlabelled service also selects
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "testns", UID: "d2", Labels: map[string]string{"app": "other"}}},
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "other-1", Namespace: "testns", UID: "r2", OwnerReferences: graphOwner("Deployment", "other", "d2")}},
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "other-1-a", Namespace: "testns", UID: "p2", Labels: map[string]string{"tier": "web"}, OwnerReferences: graphOwner("ReplicaSet", "other-1", "r2")}},
+		&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "migrate", Namespace: "testns", UID: "j1"}},
+	}
+}
+
+func TestGraph(t *testing.T) {
+	c := setupFakeClient(graphTestObjects()...)
+
+	g, err := c.Graph(GraphOptions{
+		Logger:     &logfakes.Logger{},
+		Namespace:  "testns",
+		LinkedName: "web",
+		Context:    context.Background(),
+	})
+
+	assert.Nil(t, err)
+
+	ids := []string{}
+	for _, n := range g.Nodes {
+		ids = append(ids, n.ID)
+	}
+
+	assert.Equal(t, []string{
+		"Service/testns/web",
+		"Deployment/testns/web",
+		"ReplicaSet/testns/web-1",
+		"Pod/testns/web-1-a",
+	}, ids)
+	assert.Equal(t, "web", g.Nodes[1].LinkedName)
+	assert.Empty(t, g.Nodes[3].LinkedName)
+
+	assert.ElementsMatch(t, []GraphEdge{
+		{From: "Deployment/testns/web", To: "ReplicaSet/testns/web-1", Type: EdgeOwns},
+		{From: "ReplicaSet/testns/web-1", To: "Pod/testns/web-1-a", Type: EdgeOwns},
+		{From: "Service/testns/web", To: "Pod/testns/web-1-a", Type: EdgeSelects},
+	}, g.Edges)
+}
+
+func TestGraphLabelledService(t *testing.T) {
+	objects := append(graphTestObjects(),
+		&v1.Service{ObjectMeta: metav1.ObjectMeta{Name: "front", Namespace: "testns", UID: "s2", Labels: map[string]string{"app": "front"}}, Spec: v1.ServiceSpec{Selector: map[string]string{"tier": "web"}}})
+
+	c := setupFakeClient(objects...)
+
+	g, err := c.Graph(GraphOptions{
+		Logger:     &logfakes.Logger{},
+		Namespace:  "testns",
+		LinkedName: "front",
+		Context:    context.Background(),
+	})
+
+	assert.Nil(t, err)
+	// the labelled service pulls in the pods it selects, their owners and the other
+	// service selecting them
+	assert.Len(t, g.Nodes, 8)
+	assert.Len(t, g.Edges, 8)
+}
+
+func TestGraphNoMatch(t *testing.T) {
+	c := setupFakeClient(graphTestObjects()...)
+
+	g, err := c.Graph(GraphOptions{
+		Logger:     &logfakes.Logger{},
+		Namespace:  "testns",
+		LinkedName: "missing",
+		Context:    context.Background(),
+	})
+
+	assert.Nil(t, err)
+	assert.Empty(t, g.Nodes)
+	assert.Empty(t, g.Edges)
+}
+
+func TestGraphUnlistedKinds(t *testing.T) {
+	clientset := fake.NewSimpleClientset(graphTestObjects()...)
+
+	// not served and not allowed
+	clientset.PrependReactor("list", "cronjobs", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewNotFound(schema.GroupResource{Group: "batch", Resource: "cronjobs"}, "")
+	})
+	clientset.PrependReactor("list", "daemonsets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(schema.GroupResource{Group: "apps", Resource: "daemonsets"}, "", nil)
+	})
+
+	c := New(&fakeWrapper{clientset: clientset})
+
+	g, err := c.Graph(GraphOptions{
+		Logger:     &logfakes.Logger{},
+		Namespace:  "testns",
+		LinkedName: "web",
+		Context:    context.Background(),
+	})
+
+	assert.Nil(t, err)
+	assert.Len(t, g.Nodes, 4)
+	assert.Len(t, g.Edges, 3)
+}
+
+func TestGraphFail(t *testing.T) {
+	c := setupClient("testns", "web", true, false)
+
+	_, err := c.Graph(GraphOptions{
+		Logger:     &logfakes.Logger{},
+		Namespace:  "testns",
+		LinkedName: "web",
+		Context:    context.Background(),
+	})
+
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,383 @@
+package k8sv1
+
+import (
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// HealthStatus is a normalized status derived from the status fields of a kind.
+type HealthStatus string
+
+// the health statuses, in order of severity
+const (
+	HealthHealthy     HealthStatus = "Healthy"
+	HealthSuspended   HealthStatus = "Suspended"
+	HealthProgressing HealthStatus = "Progressing"
+	HealthDegraded    HealthStatus = "Degraded"
+	HealthFailed      HealthStatus = "Failed"
+)
+
+// the container waiting reasons that won't resolve without intervention
+var degradedWaitingReasons = map[string]bool{
+	"CrashLoopBackOff":           true,
+	"ImagePullBackOff":           true,
+	"ErrImagePull":               true,
+	"InvalidImageName":           true,
+	"CreateContainerConfigError": true,
+	"CreateContainerError":       true,
+	"RunContainerError":          true,
+}
+
+// Health is the evaluated health of an object and the reasons for it.
+type Health struct {
+	// Healthy, Progressing, Degraded, Failed or Suspended
+	Status HealthStatus `json:"status"`
+	// human readable reasons for the status, empty when healthy
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+func newHealth(status HealthStatus, reasons ...string) *Health {
+	return &Health{Status: status, Reasons: reasons}
+}
+
+// severity returns the severity of a status, higher is worse.
+func (s HealthStatus) severity() int {
+	switch s {
+	case HealthSuspended:
+		return 1
+	case HealthProgressing:
+		return 2
+	case HealthDegraded:
+		return 3
+	case HealthFailed:
+		return 4
+	}
+	return 0
+}
+
+// rollup combines the health of objects into the worst status, with the reasons
+// of each unhealthy object prefixed by its kind and name. nil if there are no objects.
+type rollup struct {
+	health *Health
+}
+
+func (r *rollup) add(kind, name string, h *Health) {
+	if h == nil {
+		return
+	}
+
+	reasons := []string{}
+	for _, reason := range h.Reasons {
+		reasons = append(reasons, fmt.Sprintf("%s %s: %s", kind, name, reason))
+	}
+
+	r.merge(newHealth(h.Status, reasons...))
+}
+
+// merge adds an already rolled up health.
+func (r *rollup) merge(h *Health) {
+	if h == nil {
+		return
+	}
+
+	if r.health == nil {
+		r.health = newHealth(HealthHealthy)
+	}
+
+	if h.Status.severity() > r.health.Status.severity() {
+		r.health.Status = h.Status
+	}
+
+	r.health.Reasons = append(r.health.Reasons, h.Reasons...)
+}
+
+// mergeHealth combines two rolled up healths.
+func mergeHealth(a, b *Health) *Health {
+	r := rollup{}
+	r.merge(a)
+	r.merge(b)
+	return r.health
+}
+
+// deploymentHealth evaluates the health of a deployment the way "kubectl rollout status" does.
+func deploymentHealth(d *appsv1.Deployment) *Health {
+	if d.Spec.Paused {
+		return newHealth(HealthSuspended, "rollout is paused")
+	}
+
+	for _, c := range d.Status.Conditions {
+		if c.Type == appsv1.DeploymentProgressing && c.Status == v1.ConditionFalse && c.Reason == "ProgressDeadlineExceeded" {
+			return newHealth(HealthFailed, fmt.Sprintf("rollout exceeded its progress deadline: %s", c.Message))
+		}
+	}
+
+	for _, c := range d.Status.Conditions {
+		if c.Type == appsv1.DeploymentReplicaFailure && c.Status == v1.ConditionTrue {
+			return newHealth(HealthDegraded, c.Message)
+		}
+		if c.Type == appsv1.DeploymentAvailable && c.Status == v1.ConditionFalse {
+			return newHealth(HealthDegraded, fmt.Sprintf("minimum availability not met: %s", c.Message))
+		}
+	}
+
+	replicas := replicasOrDefault(d.Spec.Replicas)
+
+	if d.Status.ObservedGeneration < d.Generation {
+		return newHealth(HealthProgressing, "waiting for the rollout to be observed")
+	}
+	if d.Status.UpdatedReplicas < replicas {
+		return newHealth(HealthProgressing, fmt.Sprintf("%d of %d replicas updated", d.Status.UpdatedReplicas, replicas))
+	}
+	if d.Status.Replicas > d.Status.UpdatedReplicas {
+		return newHealth(HealthProgressing, fmt.Sprintf("%d old replicas pending termination", d.Status.Replicas-d.Status.UpdatedReplicas))
+	}
+	if d.Status.AvailableReplicas < d.Status.UpdatedReplicas {
+		return newHealth(HealthProgressing, fmt.Sprintf("%d of %d updated replicas available", d.Status.AvailableReplicas, d.Status.UpdatedReplicas))
+	}
+
+	return newHealth(HealthHealthy)
+}
+
+// daemonSetHealth evaluates the health of a daemonset.
+func daemonSetHealth(ds *appsv1.DaemonSet) *Health {
+	if ds.Status.ObservedGeneration < ds.Generation {
+		return newHealth(HealthProgressing, "waiting for the rollout to be observed")
+	}
+	if ds.Status.NumberMisscheduled > 0 {
+		return newHealth(HealthDegraded, fmt.Sprintf("%d pods running on nodes they shouldn't", ds.Status.NumberMisscheduled))
+	}
+	if ds.Status.UpdatedNumberScheduled < ds.Status.DesiredNumberScheduled {
+		return newHealth(HealthProgressing, fmt.Sprintf("%d of %d pods updated", ds.Status.UpdatedNumberScheduled, ds.Status.DesiredNumberScheduled))
+	}
+	if ds.Status.NumberAvailable < ds.Status.DesiredNumberScheduled {
+		return newHealth(HealthDegraded, fmt.Sprintf("%d of %d pods available", ds.Status.NumberAvailable, ds.Status.DesiredNumberScheduled))
+	}
+
+	return newHealth(HealthHealthy)
+}
+
+// replicaSetHealth evaluates the health of a replicaset.
+func replicaSetHealth(rs *appsv1.ReplicaSet) *Health {
+	for _, c := range rs.Status.Conditions {
+		if c.Type == appsv1.ReplicaSetReplicaFailure && c.Status == v1.ConditionTrue {
+			return newHealth(HealthDegraded, c.Message)
+		}
+	}
+
+	replicas := replicasOrDefault(rs.Spec.Replicas)
+
+	if rs.Status.ObservedGeneration < rs.Generation || rs.Status.Replicas < replicas {
+		return newHealth(HealthProgressing, fmt.Sprintf("%d of %d replicas created", rs.Status.Replicas, replicas))
+	}
+	if rs.Status.AvailableReplicas < replicas {
+		return newHealth(HealthDegraded, fmt.Sprintf("%d of %d replicas available", rs.Status.AvailableReplicas, replicas))
+	}
+
+	return newHealth(HealthHealthy)
+}
+
+// statefulSetHealth evaluates the health of a statefulset.
+func statefulSetHealth(sts *appsv1.StatefulSet) *Health {
+	replicas := replicasOrDefault(sts.Spec.Replicas)
+
+	if sts.Status.ObservedGeneration < sts.Generation {
+		return newHealth(HealthProgressing, "waiting for the rollout to be observed")
+	}
+	if len(sts.Status.UpdateRevision) > 0 && sts.Status.CurrentRevision != sts.Status.UpdateRevision {
+		return newHealth(HealthProgressing, fmt.Sprintf("%d of %d replicas updated", sts.Status.UpdatedReplicas, replicas))
+	}
+	if sts.Status.ReadyReplicas < replicas {
+		return newHealth(HealthProgressing, fmt.Sprintf("%d of %d replicas ready", sts.Status.ReadyReplicas, replicas))
+	}
+
+	return newHealth(HealthHealthy)
+}
+
+// jobHealth evaluates the health of a job.
+func jobHealth(job *batchv1.Job) *Health {
+	for _, c := range job.Status.Conditions {
+		if c.Status != v1.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case batchv1.JobFailed:
+			return newHealth(HealthFailed, strings.TrimSpace(fmt.Sprintf("%s %s", c.Reason, c.Message)))
+		case batchv1.JobComplete:
+			return newHealth(HealthHealthy)
+		}
+	}
+
+	if job.Spec.Suspend != nil && *job.Spec.Suspend {
+		return newHealth(HealthSuspended, "job is suspended")
+	}
+	if job.Status.Failed > 0 {
+		return newHealth(HealthDegraded, fmt.Sprintf("%d failed attempts, retrying", job.Status.Failed))
+	}
+
+	return newHealth(HealthProgressing, fmt.Sprintf("%d active pods", job.Status.Active))
+}
+
+// cronJobHealth evaluates the health of a cronjob from its schedule and runs.
+func cronJobHealth(cj *CronJobOverview) *Health {
+	if cj.Schedule.Suspended {
+		return newHealth(HealthSuspended, "cronjob is suspended")
+	}
+	if len(cj.Schedule.Error) > 0 {
+		return newHealth(HealthFailed, fmt.Sprintf("invalid schedule: %s", cj.Schedule.Error))
+	}
+	if cj.LastFailedRun != nil && (cj.LastSuccessfulRun == nil || finishedAfter(*cj.LastFailedRun, *cj.LastSuccessfulRun)) {
+		return newHealth(HealthDegraded, fmt.Sprintf("last run %s failed", cj.LastFailedRun.Name))
+	}
+
+	return newHealth(HealthHealthy)
+}
+
+// podHealth evaluates the health of a pod from its phase, conditions and containers.
+func podHealth(pod *v1.Pod) *Health {
+	switch pod.Status.Phase {
+	case v1.PodSucceeded:
+		return newHealth(HealthHealthy)
+	case v1.PodFailed:
+		return newHealth(HealthFailed, strings.TrimSpace(fmt.Sprintf("%s %s", pod.Status.Reason, pod.Status.Message)))
+	}
+
+	if pod.DeletionTimestamp != nil {
+		return newHealth(HealthProgressing, "terminating")
+	}
+
+	reasons := []string{}
+
+	statuses := append(append([]v1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+
+	for _, cs := range statuses {
+		if w := cs.State.Waiting; w != nil && degradedWaitingReasons[w.Reason] {
+			reasons = append(reasons, fmt.Sprintf("container %s: %s", cs.Name, w.Reason))
+		} else if t := cs.LastTerminationState.Terminated; t != nil && t.Reason == "OOMKilled" && cs.RestartCount > 0 {
+			reasons = append(reasons, fmt.Sprintf("container %s: last run was OOMKilled, %d restarts", cs.Name, cs.RestartCount))
+		}
+	}
+
+	if len(reasons) > 0 {
+		return newHealth(HealthDegraded, reasons...)
+	}
+
+	for _, c := range pod.Status.Conditions {
+		if c.Type == v1.PodScheduled && c.Status == v1.ConditionFalse && c.Reason == v1.PodReasonUnschedulable {
+			return newHealth(HealthDegraded, fmt.Sprintf("unschedulable: %s", c.Message))
+		}
+	}
+
+	if pod.Status.Phase == v1.PodPending {
+		return newHealth(HealthProgressing, "pending")
+	}
+
+	for _, c := range pod.Status.Conditions {
+		if c.Type == v1.PodReady && c.Status == v1.ConditionFalse {
+			return newHealth(HealthProgressing, strings.TrimSpace(fmt.Sprintf("not ready %s", c.Message)))
+		}
+	}
+
+	return newHealth(HealthHealthy)
+}
+
+// health rolls up the health of every object in the overview. Failed pods of a healthy
+// controller, e.g. pods evicted and replaced by their deployment, and jobs superseded by
+// a newer successful run of their cronjob are left out.
+func (o *Overview) health() *Health {
+	r := rollup{}
+	// the health of each controller by kind/name
+	controllers := map[string]*Health{}
+
+	for _, d := range o.Deployments {
+		r.add("Deployment", d.Name, d.Health)
+		controllers["Deployment/"+d.Name] = d.Health
+	}
+	for _, ds := range o.DaemonSets {
+		r.add("DaemonSet", ds.Name, ds.Health)
+		controllers["DaemonSet/"+ds.Name] = ds.Health
+	}
+	for _, rs := range o.ReplicaSets {
+		r.add("ReplicaSet", rs.Name, rs.Health)
+		controllers["ReplicaSet/"+rs.Name] = rs.Health
+	}
+	for _, sts := range o.StatefulSets {
+		r.add("StatefulSet", sts.Name, sts.Health)
+		controllers["StatefulSet/"+sts.Name] = sts.Health
+	}
+	for _, j := range o.Jobs {
+		if o.superseded(j) {
+			// its pods are left out with it
+			controllers["Job/"+j.Name] = newHealth(HealthHealthy)
+			continue
+		}
+		r.add("Job", j.Name, j.Health)
+		controllers["Job/"+j.Name] = j.Health
+	}
+	for _, cj := range o.CronJobs {
+		r.add("CronJob", cj.Name, cj.Health)
+	}
+	for _, p := range o.Pods {
+		if p.Pod != nil && p.Pod.Status.Phase == v1.PodFailed && controllerHealthy(controllers, p.Pod) {
+			continue
+		}
+		r.add("Pod", p.Name, p.Health)
+	}
+
+	return r.health
+}
+
+// superseded returns true if a job of a cronjob was followed by a successful run of the same cronjob.
+func (o *Overview) superseded(job JobOverview) bool {
+	if job.Job == nil {
+		return false
+	}
+
+	owner := metav1.GetControllerOf(job.Job)
+
+	if owner == nil || owner.Kind != "CronJob" {
+		return false
+	}
+
+	for _, j := range o.Jobs {
+		if j.Job == nil || j.Health == nil || j.Health.Status != HealthHealthy {
+			continue
+		}
+
+		if other := metav1.GetControllerOf(j.Job); other != nil && other.UID == owner.UID &&
+			job.Job.CreationTimestamp.Before(&j.Job.CreationTimestamp) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// controllerHealthy returns true if the controller of a pod is in controllers and healthy. Pods of
+// a deployment are owned by a replicaset, named after the deployment and the pod template hash.
+func controllerHealthy(controllers map[string]*Health, pod *v1.Pod) bool {
+	owner := metav1.GetControllerOf(pod)
+
+	if owner == nil {
+		return false
+	}
+
+	h, ok := controllers[owner.Kind+"/"+owner.Name]
+
+	if hash := pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey]; !ok && owner.Kind == "ReplicaSet" && len(hash) > 0 {
+		h, ok = controllers["Deployment/"+strings.TrimSuffix(owner.Name, "-"+hash)]
+	}
+
+	return ok && h != nil && h.Status == HealthHealthy
+}
+
+func replicasOrDefault(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
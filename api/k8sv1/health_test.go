@@ -0,0 +1,334 @@
+package k8sv1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	logfakes "github.com/kubelens/kubelens/api/log/fakes"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func int32Ptr(i int32) *int32 { return &i }
+
+func TestDeploymentHealth(t *testing.T) {
+	tests := []struct {
+		name   string
+		d      appsv1.Deployment
+		status HealthStatus
+	}{
+		{"healthy", appsv1.Deployment{
+			Spec:   appsv1.DeploymentSpec{Replicas: int32Ptr(2)},
+			Status: appsv1.DeploymentStatus{Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2},
+		}, HealthHealthy},
+		{"paused", appsv1.Deployment{Spec: appsv1.DeploymentSpec{Paused: true}}, HealthSuspended},
+		{"deadline exceeded", appsv1.Deployment{Status: appsv1.DeploymentStatus{Conditions: []appsv1.DeploymentCondition{
+			{Type: appsv1.DeploymentProgressing, Status: v1.ConditionFalse, Reason: "ProgressDeadlineExceeded"},
+		}}}, HealthFailed},
+		{"unavailable", appsv1.Deployment{Status: appsv1.DeploymentStatus{Conditions: []appsv1.DeploymentCondition{
+			{Type: appsv1.DeploymentAvailable, Status: v1.ConditionFalse},
+		}}}, HealthDegraded},
+		{"updating", appsv1.Deployment{
+			Spec:   appsv1.DeploymentSpec{Replicas: int32Ptr(3)},
+			Status: appsv1.DeploymentStatus{Replicas: 3, UpdatedReplicas: 1, AvailableReplicas: 3},
+		}, HealthProgressing},
+		{"old replicas", appsv1.Deployment{
+			Spec:   appsv1.DeploymentSpec{Replicas: int32Ptr(1)},
+			Status: appsv1.DeploymentStatus{Replicas: 2, UpdatedReplicas: 1, AvailableReplicas: 2},
+		}, HealthProgressing},
+		{"not observed", appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Generation: 2}}, HealthProgressing},
+	}
+
+	for _, tt := range tests {
+		h := deploymentHealth(&tt.d)
+		assert.Equal(t, tt.status, h.Status, tt.name)
+		assert.Equal(t, tt.status == HealthHealthy, len(h.Reasons) == 0, tt.name)
+	}
+}
+
+func TestDaemonSetHealth(t *testing.T) {
+	assert.Equal(t, HealthHealthy, daemonSetHealth(&appsv1.DaemonSet{Status: appsv1.DaemonSetStatus{
+		DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberAvailable: 3,
+	}}).Status)
+	assert.Equal(t, HealthProgressing, daemonSetHealth(&appsv1.DaemonSet{Status: appsv1.DaemonSetStatus{
+		DesiredNumberScheduled: 3, UpdatedNumberScheduled: 1, NumberAvailable: 3,
+	}}).Status)
+	assert.Equal(t, HealthDegraded, daemonSetHealth(&appsv1.DaemonSet{Status: appsv1.DaemonSetStatus{
+		DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberAvailable: 2,
+	}}).Status)
+	assert.Equal(t, HealthDegraded, daemonSetHealth(&appsv1.DaemonSet{Status: appsv1.DaemonSetStatus{
+		NumberMisscheduled: 1,
+	}}).Status)
+}
+
+func TestReplicaSetHealth(t *testing.T) {
+	assert.Equal(t, HealthHealthy, replicaSetHealth(&appsv1.ReplicaSet{Spec: appsv1.ReplicaSetSpec{Replicas: int32Ptr(0)}}).Status)
+	assert.Equal(t, HealthProgressing, replicaSetHealth(&appsv1.ReplicaSet{
+		Spec: appsv1.ReplicaSetSpec{Replicas: int32Ptr(2)}, Status: appsv1.ReplicaSetStatus{Replicas: 1},
+	}).Status)
+	assert.Equal(t, HealthDegraded, replicaSetHealth(&appsv1.ReplicaSet{
+		Spec: appsv1.ReplicaSetSpec{Replicas: int32Ptr(2)}, Status: appsv1.ReplicaSetStatus{Replicas: 2, AvailableReplicas: 1},
+	}).Status)
+	assert.Equal(t, []string{"exceeded quota"}, replicaSetHealth(&appsv1.ReplicaSet{Status: appsv1.ReplicaSetStatus{Conditions: []appsv1.ReplicaSetCondition{
+		{Type: appsv1.ReplicaSetReplicaFailure, Status: v1.ConditionTrue, Message: "exceeded quota"},
+	}}}).Reasons)
+}
+
+func TestJobHealth(t *testing.T) {
+	suspend := true
+
+	tests := []struct {
+		name   string
+		job    batchv1.Job
+		status HealthStatus
+	}{
+		{"complete", batchv1.Job{Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: v1.ConditionTrue}}}}, HealthHealthy},
+		{"failed", batchv1.Job{Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: v1.ConditionTrue, Reason: "BackoffLimitExceeded"}}}}, HealthFailed},
+		{"suspended", batchv1.Job{Spec: batchv1.JobSpec{Suspend: &suspend}}, HealthSuspended},
+		{"retrying", batchv1.Job{Status: batchv1.JobStatus{Active: 1, Failed: 2}}, HealthDegraded},
+		{"running", batchv1.Job{Status: batchv1.JobStatus{Active: 1}}, HealthProgressing},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.status, jobHealth(&tt.job).Status, tt.name)
+	}
+}
+
+func TestPodHealth(t *testing.T) {
+	tests := []struct {
+		name   string
+		pod    v1.Pod
+		status HealthStatus
+	}{
+		{"running", v1.Pod{Status: v1.PodStatus{Phase: v1.PodRunning}}, HealthHealthy},
+		{"succeeded", v1.Pod{Status: v1.PodStatus{Phase: v1.PodSucceeded}}, HealthHealthy},
+		{"failed", v1.Pod{Status: v1.PodStatus{Phase: v1.PodFailed, Reason: "Evicted"}}, HealthFailed},
+		{"pending", v1.Pod{Status: v1.PodStatus{Phase: v1.PodPending}}, HealthProgressing},
+		{"crashloop", v1.Pod{Status: v1.PodStatus{Phase: v1.PodRunning, ContainerStatuses: []v1.ContainerStatus{
+			{Name: "app", State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}},
+		}}}, HealthDegraded},
+		{"oomkilled", v1.Pod{Status: v1.PodStatus{Phase: v1.PodRunning, ContainerStatuses: []v1.ContainerStatus{
+			{Name: "app", RestartCount: 2, LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: "OOMKilled"}}},
+		}}}, HealthDegraded},
+		{"unschedulable", v1.Pod{Status: v1.PodStatus{Phase: v1.PodPending, Conditions: []v1.PodCondition{
+			{Type: v1.PodScheduled, Status: v1.ConditionFalse, Reason: v1.PodReasonUnschedulable},
+		}}}, HealthDegraded},
+		{"not ready", v1.Pod{Status: v1.PodStatus{Phase: v1.PodRunning, Conditions: []v1.PodCondition{
+			{Type: v1.PodReady, Status: v1.ConditionFalse},
+		}}}, HealthProgressing},
+		{"terminating", v1.Pod{ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: &metav1.Time{}}, Status: v1.PodStatus{Phase: v1.PodRunning}}, HealthProgressing},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.status, podHealth(&tt.pod).Status, tt.name)
+	}
+}
+
+func TestCronJobHealth(t *testing.T) {
+	assert.Equal(t, HealthSuspended, cronJobHealth(&CronJobOverview{Schedule: CronSchedule{Suspended: true}}).Status)
+	assert.Equal(t, HealthFailed, cronJobHealth(&CronJobOverview{Schedule: CronSchedule{Error: "bad"}}).Status)
+	assert.Equal(t, HealthDegraded, cronJobHealth(&CronJobOverview{LastFailedRun: &JobRun{Name: "a"}}).Status)
+	assert.Equal(t, HealthHealthy, cronJobHealth(&CronJobOverview{}).Status)
+}
+
+func TestHealthRollup(t *testing.T) {
+	o := &Overview{}
+	assert.Nil(t, o.health())
+
+	o = &Overview{
+		Deployments: []DeploymentOverview{{Name: "web", Health: newHealth(HealthProgressing, "1 of 2 replicas updated")}},
+		Pods: []PodOverview{
+			{Name: "web-1", Health: newHealth(HealthHealthy)},
+			{Name: "web-2", Health: newHealth(HealthDegraded, "container app: CrashLoopBackOff")},
+		},
+	}
+
+	h := o.health()
+	assert.Equal(t, HealthDegraded, h.Status)
+	assert.Equal(t, []string{
+		"Deployment web: 1 of 2 replicas updated",
+		"Pod web-2: container app: CrashLoopBackOff",
+	}, h.Reasons)
+}
+
+func TestHealthRollupFailedPodsOfHealthyController(t *testing.T) {
+	controller := func(kind, name string) []metav1.OwnerReference {
+		return []metav1.OwnerReference{*metav1.NewControllerRef(&metav1.ObjectMeta{Name: name}, appsv1.SchemeGroupVersion.WithKind(kind))}
+	}
+
+	evicted := func(name string, meta metav1.ObjectMeta) PodOverview {
+		meta.Name = name
+		pod := &v1.Pod{ObjectMeta: meta, Status: v1.PodStatus{Phase: v1.PodFailed, Reason: "Evicted"}}
+		return PodOverview{Name: name, Pod: pod, Health: podHealth(pod)}
+	}
+
+	o := &Overview{
+		Deployments:  []DeploymentOverview{{Name: "web", Health: newHealth(HealthHealthy)}},
+		StatefulSets: []StatefulSetOverview{{Name: "db", Health: newHealth(HealthDegraded, "0 of 1 replicas ready")}},
+		Pods: []PodOverview{
+			// owned by the replicaset of the deployment
+			evicted("web-7d4b9-a", metav1.ObjectMeta{
+				Labels:          map[string]string{appsv1.DefaultDeploymentUniqueLabelKey: "7d4b9"},
+				OwnerReferences: controller("ReplicaSet", "web-7d4b9"),
+			}),
+			evicted("db-0", metav1.ObjectMeta{OwnerReferences: controller("StatefulSet", "db")}),
+			evicted("standalone", metav1.ObjectMeta{}),
+		},
+	}
+
+	h := o.health()
+	assert.Equal(t, HealthFailed, h.Status)
+	assert.Equal(t, []string{
+		"StatefulSet db: 0 of 1 replicas ready",
+		"Pod db-0: Evicted",
+		"Pod standalone: Evicted",
+	}, h.Reasons)
+}
+
+func TestHealthRollupSupersededJobs(t *testing.T) {
+	cronJob := &batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: "backup", UID: "cj"}}
+	start := metav1.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	job := func(name string, created metav1.Time, condition batchv1.JobConditionType) JobOverview {
+		j := &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				CreationTimestamp: created,
+				OwnerReferences:   []metav1.OwnerReference{*metav1.NewControllerRef(cronJob, batchv1.SchemeGroupVersion.WithKind("CronJob"))},
+			},
+			Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{{Type: condition, Status: v1.ConditionTrue, Reason: "BackoffLimitExceeded"}}},
+		}
+		return JobOverview{Name: name, Job: j, Health: jobHealth(j)}
+	}
+
+	failedPod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "backup-1-x",
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(&metav1.ObjectMeta{Name: "backup-1"}, batchv1.SchemeGroupVersion.WithKind("Job"))},
+		},
+		Status: v1.PodStatus{Phase: v1.PodFailed, Reason: "Error"},
+	}
+
+	o := &Overview{
+		Jobs: []JobOverview{
+			job("backup-1", start, batchv1.JobFailed),
+			job("backup-2", metav1.NewTime(start.Add(time.Hour)), batchv1.JobComplete),
+		},
+		Pods: []PodOverview{{Name: failedPod.Name, Pod: failedPod, Health: podHealth(failedPod)}},
+	}
+
+	assert.Equal(t, HealthHealthy, o.health().Status)
+
+	// the latest run failed
+	o.Jobs = append(o.Jobs, job("backup-3", metav1.NewTime(start.Add(2*time.Hour)), batchv1.JobFailed))
+
+	h := o.health()
+	assert.Equal(t, HealthFailed, h.Status)
+	assert.Equal(t, []string{"Job backup-3: BackoffLimitExceeded"}, h.Reasons)
+}
+
+func TestOverviewsHealth(t *testing.T) {
+	lbl := map[string]string{"app": "web"}
+
+	c := setupFakeClient(
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "testns"}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "testns", Labels: lbl}, Spec: appsv1.DeploymentSpec{Replicas: int32Ptr(0)}},
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "testns", Labels: lbl}, Status: v1.PodStatus{Phase: v1.PodFailed}},
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "db-1", Namespace: "testns", Labels: map[string]string{"app": "db"}}, Status: v1.PodStatus{Phase: v1.PodRunning}},
+	)
+
+	overviews, _, err := c.Overviews(OverviewOptions{
+		Logger:  &logfakes.Logger{},
+		Context: context.Background(),
+	})
+
+	assert.Nil(t, err)
+	assert.Len(t, overviews, 2)
+
+	for _, o := range overviews {
+		switch o.LinkedName {
+		case "web":
+			assert.Equal(t, HealthFailed, o.Health.Status)
+		case "db":
+			assert.Equal(t, HealthHealthy, o.Health.Status)
+		}
+	}
+
+	o, err := c.Overview(OverviewOptions{
+		Logger:     &logfakes.Logger{},
+		Namespace:  "testns",
+		LinkedName: "web",
+		Context:    context.Background(),
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, HealthFailed, o.Health.Status)
+	assert.Equal(t, HealthHealthy, o.Deployments[0].Health.Status)
+}
+
+func TestOverviewsHealthLeavesOut(t *testing.T) {
+	web := map[string]string{"app": "web"}
+	backup := map[string]string{"app": "backup"}
+	cronJob := &batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: "backup", UID: "cj"}}
+	start := metav1.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	job := func(name string, created metav1.Time, condition batchv1.JobConditionType) *batchv1.Job {
+		return &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "testns",
+				Labels:            backup,
+				CreationTimestamp: created,
+				OwnerReferences:   []metav1.OwnerReference{*metav1.NewControllerRef(cronJob, batchv1.SchemeGroupVersion.WithKind("CronJob"))},
+			},
+			Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{{Type: condition, Status: v1.ConditionTrue}}},
+		}
+	}
+
+	c := setupFakeClient(
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "testns"}},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "testns", Labels: web},
+			Spec:       appsv1.DeploymentSpec{Replicas: int32Ptr(1)},
+			Status:     appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, ReadyReplicas: 1, AvailableReplicas: 1},
+		},
+		// evicted and replaced by the deployment
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "web-7d4b9-a",
+				Namespace:       "testns",
+				Labels:          map[string]string{"app": "web", appsv1.DefaultDeploymentUniqueLabelKey: "7d4b9"},
+				OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(&metav1.ObjectMeta{Name: "web-7d4b9"}, appsv1.SchemeGroupVersion.WithKind("ReplicaSet"))},
+			},
+			Status: v1.PodStatus{Phase: v1.PodFailed, Reason: "Evicted"},
+		},
+		job("backup-1", start, batchv1.JobFailed),
+		job("backup-2", metav1.NewTime(start.Add(time.Hour)), batchv1.JobComplete),
+	)
+
+	overviews, _, err := c.Overviews(OverviewOptions{
+		Logger:  &logfakes.Logger{},
+		Context: context.Background(),
+	})
+
+	assert.Nil(t, err)
+	assert.Len(t, overviews, 2)
+
+	for _, o := range overviews {
+		assert.Equal(t, HealthHealthy, o.Health.Status, o.LinkedName)
+
+		single, err := c.Overview(OverviewOptions{
+			Logger:     &logfakes.Logger{},
+			Namespace:  "testns",
+			LinkedName: o.LinkedName,
+			Context:    context.Background(),
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, single.Health, o.Health, o.LinkedName)
+	}
+}
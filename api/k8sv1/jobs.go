@@ -8,16 +8,19 @@ import (
 	klog "github.com/kubelens/kubelens/api/log"
 	batchv1 "k8s.io/api/batch/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // JobOptions contains fields used for filtering when retrieving jobs
 type JobOptions struct {
 	// the name of the deployment
 	Name string `json:"name"`
-	// the value from the label "app=NAME", corresponds to config.LabelKeyLink
+	// the value of the first link label the object has, e.g. "app=NAME", see config.LinkKeys
 	LinkedName string `json:"linkedName"`
 	// the namespace of the deployment
 	Namespace string `json:"namespace"`
+	// selectors and pagination narrowing a list
+	ListOptions
 	// logger instance
 	Logger klog.Logger
 	// Context .
@@ -27,10 +30,14 @@ type JobOptions struct {
 type JobOverview struct {
 	/// the name
 	Name string `json:"name"`
-	// the value from the label "app=NAME", corresponds to config.LabelKeyLink
+	// the value of the first link label the object has, e.g. "app=NAME", see config.LinkKeys
 	LinkedName string `json:"linkedName"`
 	// the namespace
 	Namespace string `json:"namespace"`
+	// the evaluated health
+	Health *Health `json:"health,omitempty"`
+	// the number of fields redacted, see config.Redaction
+	Redacted int `json:"redacted,omitempty"`
 	// the full configmap
 	Job *batchv1.Job `json:"job,omitempty"`
 }
@@ -38,20 +45,7 @@ type JobOverview struct {
 // Job returns a Job given filter options
 func (k *Client) Job(options JobOptions) (overview *JobOverview, apiErr *errs.APIError) {
 
-	clientset, err := k.wrapper.GetClientSet()
-
-	if err != nil {
-		klog.Trace()
-		return nil, errs.InternalServerError(err.Error())
-	}
-
-	jbs := clientset.BatchV1().Jobs(options.Namespace)
-
-	if jbs == nil {
-		return nil, nil
-	}
-
-	list, err := jbs.List(options.Context, metav1.ListOptions{
+	list, err := k.listJobs(options.Context, options.Namespace, metav1.ListOptions{
 		FieldSelector: fmt.Sprintf("metadata.name=%s", options.Name),
 	})
 
@@ -64,8 +58,10 @@ func (k *Client) Job(options JobOptions) (overview *JobOverview, apiErr *errs.AP
 		for _, item := range list.Items {
 			return &JobOverview{
 				Name:       item.Name,
-				LinkedName: getLinkedName(item.Labels),
+				LinkedName: getLinkedName(item.Namespace, item.Labels),
 				Namespace:  item.Namespace,
+				Health:     jobHealth(&item),
+				Redacted:   redact(&item),
 				Job:        &item,
 			}, nil
 		}
@@ -74,41 +70,54 @@ func (k *Client) Job(options JobOptions) (overview *JobOverview, apiErr *errs.AP
 }
 
 // Jobs returns a list ofJobs given filter options
-func (k *Client) Jobs(options JobOptions) (overviews []JobOverview, apiErr *errs.APIError) {
+func (k *Client) Jobs(options JobOptions) (overviews []JobOverview, meta ListMeta, apiErr *errs.APIError) {
 	overviews = []JobOverview{}
-	clientset, err := k.wrapper.GetClientSet()
+	lo, apiErr := options.listOptions(jobFields(&batchv1.Job{}))
 
-	if err != nil {
-		klog.Trace()
-		return nil, errs.InternalServerError(err.Error())
+	if apiErr != nil {
+		return nil, meta, apiErr
 	}
 
-	jbs := clientset.BatchV1().Jobs(options.Namespace)
-
-	if jbs == nil {
-		return nil, nil
-	}
-
-	lo := metav1.ListOptions{}
-	if len(options.LinkedName) > 0 {
-		lo.LabelSelector = generateLabelSelector(options.LinkedName)
-	}
-	list, err := jbs.List(options.Context, lo)
+	obj, err := listLinked(options.LinkedName, lo, func(lo metav1.ListOptions) (runtime.Object, error) {
+		return k.listJobs(options.Context, options.Namespace, lo)
+	})
 
 	if err != nil {
 		klog.Trace()
-		return nil, errs.InternalServerError(err.Error())
+		return nil, meta, errs.InternalServerError(err.Error())
 	}
 
+	list := obj.(*batchv1.JobList)
+
 	if list != nil && len(list.Items) > 0 {
 		for _, item := range list.Items {
+			// the overview keeps a pointer to item
+			item := item
+
 			overviews = append(overviews, JobOverview{
 				Name:       item.Name,
-				LinkedName: getLinkedName(item.Labels),
+				LinkedName: getLinkedName(item.Namespace, item.Labels),
 				Namespace:  item.Namespace,
+				Health:     jobHealth(&item),
+				Redacted:   redact(&item),
 				Job:        &item,
 			})
 		}
 	}
-	return overviews, nil
+	return overviews, newListMeta(list.ListMeta), nil
+}
+
+// listJobs lists jobs from the cache when it has synced, otherwise from the api server.
+func (k *Client) listJobs(ctx context.Context, namespace string, lo metav1.ListOptions) (*batchv1.JobList, error) {
+	if k.cache.hasSynced(kindJobs) {
+		return k.cache.listJobs(namespace, lo)
+	}
+
+	clientset, err := k.wrapper.GetClientSet()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return clientset.BatchV1().Jobs(namespace).List(ctx, lo)
 }
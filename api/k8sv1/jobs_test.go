@@ -11,7 +11,7 @@ import (
 func TestJobsDefaultSuccess(t *testing.T) {
 	c := setupClient("testns", "jobs1", false, false)
 
-	d, err := c.Jobs(JobOptions{
+	d, _, err := c.Jobs(JobOptions{
 		Logger:     &logfakes.Logger{},
 		Namespace:  "testns",
 		LinkedName: "jobs1",
@@ -26,7 +26,7 @@ func TestJobsDefaultSuccess(t *testing.T) {
 func TestGetJobsDefaultFail(t *testing.T) {
 	c := setupClient("testns", "jobs2", true, true)
 
-	_, err := c.Jobs(JobOptions{
+	_, _, err := c.Jobs(JobOptions{
 		Logger:     &logfakes.Logger{},
 		Namespace:  "testns",
 		LinkedName: "jobs2",
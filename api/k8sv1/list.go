@@ -0,0 +1,121 @@
+package k8sv1
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kubelens/kubelens/api/errs"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// ListOptions contains the selectors, in the syntax kubectl uses, and pagination narrowing a list.
+type ListOptions struct {
+	// a label selector, e.g. "tier=web,env notin (dev,test)"
+	LabelSelector string `json:"labelSelector"`
+	// a field selector, e.g. "status.phase!=Running", the fields depend on the kind
+	FieldSelector string `json:"fieldSelector"`
+	// the maximum number of items to return, 0 returns every item
+	Limit int64 `json:"limit"`
+	// the continue token of the previous page
+	Continue string `json:"continue"`
+}
+
+// ListMeta is the pagination state of a list.
+type ListMeta struct {
+	// pass as continue to get the next page, empty on the last page
+	Continue string `json:"continue"`
+	// the number of items after this page, null when it isn't known
+	RemainingItemCount *int64 `json:"remainingItemCount"`
+}
+
+// newListMeta returns the pagination state of a list returned by listLinked.
+func newListMeta(meta metav1.ListMeta) ListMeta {
+	return ListMeta{
+		Continue:           meta.Continue,
+		RemainingItemCount: meta.RemainingItemCount,
+	}
+}
+
+// continueToken is where a list carries on from, the index of the link label key being listed
+// and the continue token of the api server or cache for that key.
+type continueToken struct {
+	Key      int    `json:"k"`
+	Continue string `json:"c,omitempty"`
+}
+
+// encodeContinue returns the continue token passed to clients, empty when there is no next page.
+func encodeContinue(token *continueToken) string {
+	if token == nil {
+		return ""
+	}
+
+	b, _ := json.Marshal(token)
+
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodeContinue returns the token a client passed as continue, an empty continue
+// starts at the beginning.
+func decodeContinue(s string) (token continueToken, err error) {
+	if len(s) == 0 {
+		return token, nil
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(s)
+
+	if err == nil {
+		err = json.Unmarshal(b, &token)
+	}
+
+	if err != nil || token.Key < 0 {
+		return token, fmt.Errorf("invalid continue token")
+	}
+
+	return token, nil
+}
+
+// listOptions validates the selectors and pagination, checking the field selector only uses fields
+// in supported, and returns them as metav1.ListOptions.
+func (o ListOptions) listOptions(supported fields.Set) (lo metav1.ListOptions, apiErr *errs.APIError) {
+	if o.Limit < 0 {
+		return lo, errs.ValidationError("limit can't be negative")
+	}
+
+	if _, err := decodeContinue(o.Continue); err != nil {
+		return lo, errs.ValidationError(err.Error())
+	}
+
+	if _, err := labels.Parse(o.LabelSelector); err != nil {
+		return lo, errs.ValidationError(fmt.Sprintf("invalid labelSelector: %s", err.Error()))
+	}
+
+	selector, err := fields.ParseSelector(o.FieldSelector)
+
+	if err != nil {
+		return lo, errs.ValidationError(fmt.Sprintf("invalid fieldSelector: %s", err.Error()))
+	}
+
+	for _, r := range selector.Requirements() {
+		if _, ok := supported[r.Field]; !ok {
+			names := []string{}
+			for name := range supported {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			return lo, errs.ValidationError(fmt.Sprintf("invalid fieldSelector: %s is not supported, use one of %s", r.Field, strings.Join(names, ", ")))
+		}
+	}
+
+	return metav1.ListOptions{
+		LabelSelector: o.LabelSelector,
+		FieldSelector: o.FieldSelector,
+		Limit:         o.Limit,
+		Continue:      o.Continue,
+	}, nil
+}
@@ -0,0 +1,155 @@
+package k8sv1
+
+import (
+	"context"
+	"testing"
+
+	logfakes "github.com/kubelens/kubelens/api/log/fakes"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestListOptions(t *testing.T) {
+	lo, err := ListOptions{
+		LabelSelector: "tier=web,env notin (dev,test)",
+		FieldSelector: "status.phase!=Running,spec.nodeName=node-1",
+	}.listOptions(podFields(&v1.Pod{}))
+
+	assert.Nil(t, err)
+	assert.Equal(t, "tier=web,env notin (dev,test)", lo.LabelSelector)
+	assert.Equal(t, "status.phase!=Running,spec.nodeName=node-1", lo.FieldSelector)
+}
+
+func TestListOptionsInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		options ListOptions
+		message string
+	}{
+		{"label syntax", ListOptions{LabelSelector: "tier in (web"}, "invalid labelSelector"},
+		{"field syntax", ListOptions{FieldSelector: "status.phase"}, "invalid fieldSelector"},
+		{"unsupported field", ListOptions{FieldSelector: "status.phase=Running"}, "invalid fieldSelector: status.phase is not supported, use one of metadata.name, metadata.namespace"},
+		{"negative limit", ListOptions{Limit: -1}, "limit can't be negative"},
+		{"continue", ListOptions{Continue: "not a token"}, "invalid continue token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.options.listOptions(objectMetaFields(metav1.ObjectMeta{}))
+
+			assert.Equal(t, 400, err.Code)
+			assert.Contains(t, err.Message, tt.message)
+		})
+	}
+}
+
+func TestCachedPodsFieldSelector(t *testing.T) {
+	c := setupCachedClient(t, cacheTestObjects()...)
+
+	r, _, err := c.Pods(PodOptions{
+		Logger:      &logfakes.Logger{},
+		Context:     context.Background(),
+		ListOptions: ListOptions{FieldSelector: "status.phase!=Running"},
+	})
+
+	assert.Nil(t, err)
+	assert.Len(t, r, 2)
+	assert.Equal(t, "cached-a", r[0].Name)
+	assert.Equal(t, "other", r[1].Name)
+
+	r, _, err = c.Pods(PodOptions{
+		Logger:      &logfakes.Logger{},
+		Context:     context.Background(),
+		Namespace:   "one",
+		LinkedName:  "cached",
+		ListOptions: ListOptions{FieldSelector: "spec.nodeName=node-1"},
+	})
+
+	assert.Nil(t, err)
+	assert.Len(t, r, 1)
+	assert.Equal(t, "cached-b", r[0].Name)
+}
+
+func TestCachedDeploymentsLabelSelector(t *testing.T) {
+	c := setupCachedClient(t, cacheTestObjects()...)
+
+	r, _, err := c.Deployments(DeploymentOptions{
+		Logger:      &logfakes.Logger{},
+		Context:     context.Background(),
+		ListOptions: ListOptions{LabelSelector: "app in (cached,other)"},
+	})
+
+	assert.Nil(t, err)
+	assert.Len(t, r, 1)
+
+	r, _, err = c.Deployments(DeploymentOptions{
+		Logger:      &logfakes.Logger{},
+		Context:     context.Background(),
+		ListOptions: ListOptions{LabelSelector: "app!=cached"},
+	})
+
+	assert.Nil(t, err)
+	assert.Empty(t, r)
+}
+
+func TestDeploymentsUnsupportedField(t *testing.T) {
+	c := setupCachedClient(t, cacheTestObjects()...)
+
+	_, _, err := c.Deployments(DeploymentOptions{
+		Logger:      &logfakes.Logger{},
+		Context:     context.Background(),
+		ListOptions: ListOptions{FieldSelector: "spec.replicas=1"},
+	})
+
+	assert.Equal(t, 400, err.Code)
+}
+
+func TestEventsFieldSelector(t *testing.T) {
+	c := setupCachedClient(t, eventTestObjects()...)
+
+	r, _, err := c.Events(EventOptions{
+		Logger:      &logfakes.Logger{},
+		Context:     context.Background(),
+		Type:        v1.EventTypeWarning,
+		ListOptions: ListOptions{FieldSelector: "involvedObject.name=web-1"},
+	})
+
+	assert.Nil(t, err)
+	assert.Len(t, r, 2)
+	for _, e := range r {
+		assert.Equal(t, v1.EventTypeWarning, e.Type)
+		assert.Equal(t, "web-1", e.Name)
+	}
+}
+
+func TestCachedPodsPages(t *testing.T) {
+	c := setupCachedClient(t, cacheTestObjects()...)
+
+	names := []string{}
+	remaining := []int64{}
+	options := PodOptions{
+		Logger:      &logfakes.Logger{},
+		Context:     context.Background(),
+		ListOptions: ListOptions{Limit: 2},
+	}
+
+	for {
+		r, meta, err := c.Pods(options)
+
+		assert.Nil(t, err)
+		for _, p := range r {
+			names = append(names, p.Name)
+		}
+
+		if len(meta.Continue) == 0 {
+			assert.Nil(t, meta.RemainingItemCount)
+			break
+		}
+		remaining = append(remaining, *meta.RemainingItemCount)
+		options.Continue = meta.Continue
+	}
+
+	assert.Equal(t, []string{"cached-a", "cached-b", "other"}, names)
+	assert.Equal(t, []int64{1}, remaining)
+}
@@ -1,10 +1,14 @@
 package k8sv1
 
 import (
-	"bytes"
+	"bufio"
 	"context"
 	"fmt"
 	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/kubelens/kubelens/api/errs"
 
@@ -17,8 +21,27 @@ import (
 type Log struct {
 	// the name of the pod
 	Pod string `json:"pod"`
-	// the log output
-	Output string `json:"output"`
+	// the pod/container of every log in the output of linked logs, see LinkedLogs
+	Sources []string `json:"sources,omitempty"`
+	// the log output, oldest line first
+	Output []LogLine `json:"output"`
+}
+
+// LogLine is a line of log output.
+type LogLine struct {
+	// when the line was logged, set when LogOptions.Timestamps is and kubernetes provided one,
+	// otherwise when a json line has one, see ParseJSONLog
+	Timestamp *time.Time `json:"timestamp,omitempty"`
+	// the line, without the timestamp or a trailing newline
+	Text string `json:"text"`
+	// the level of a json line, one of trace, debug, info, warn, error or fatal when known
+	Level string `json:"level,omitempty"`
+	// the message of a json line
+	Message string `json:"message,omitempty"`
+	// the other fields of a json line
+	Fields map[string]interface{} `json:"fields,omitempty"`
+	// true for a line kept as context around a line matching the filter, see LogFilter
+	Context bool `json:"context,omitempty"`
 }
 
 // LogOptions contains fields used for filtering when retrieving application logs
@@ -29,8 +52,36 @@ type LogOptions struct {
 	PodName string `json:"podname"`
 	// The name of the container to get logs from.
 	ContainerName string `json:"containerName"`
+	// the value from the label "app=NAME", gets the logs of every linked pod, see LinkedLogs
+	LinkedName string `json:"linkedName"`
+	// a label selector, gets the logs of every selected pod, see LinkedLogs
+	LabelSelector string `json:"labelSelector"`
 	// follow enables streaming
 	Follow bool `json:"follow"`
+	// previous gets the logs of the previous instance of the container, e.g. before it crashed
+	Previous bool `json:"previous"`
+	// only logs after this time, can't be set with SinceSeconds
+	SinceTime *time.Time `json:"sinceTime,omitempty"`
+	// only logs from the last number of seconds, can't be set with SinceTime
+	SinceSeconds int64 `json:"sinceSeconds,omitempty"`
+	// only logs up to this time, the tail is counted back from it
+	UntilTime *time.Time `json:"untilTime,omitempty"`
+	// timestamps sets the timestamp of every line, see LogLine
+	Timestamps bool `json:"timestamps"`
+	// only lines matching this regular expression, see LogFilter
+	Include string `json:"include,omitempty"`
+	// no lines matching this regular expression
+	Exclude string `json:"exclude,omitempty"`
+	// match Include and Exclude ignoring case
+	IgnoreCase bool `json:"ignoreCase"`
+	// lines of context to keep before every matching line
+	Before int `json:"before,omitempty"`
+	// lines of context to keep after every matching line
+	After int `json:"after,omitempty"`
+	// only json lines logged at this level or a more severe one
+	Level string `json:"level,omitempty"`
+	// only json lines with these fields, as key=value, see LogFilter
+	Fields []string `json:"fields,omitempty"`
 	// tail logs from line. If a stream request, this is ignored.
 	Tail int64 `json:"tail"`
 	// logger instance
@@ -49,6 +100,37 @@ func (a *LogOptions) Valid() *errs.APIError {
 		return errs.ValidationError("namespace must be provided when getting logs")
 	}
 
+	return a.validOutput()
+}
+
+// validOutput validates the options narrowing the output, the time range and the filter
+func (a *LogOptions) validOutput() *errs.APIError {
+	if apiErr := a.validTimes(); apiErr != nil {
+		return apiErr
+	}
+
+	_, apiErr := NewLogFilter(*a)
+	return apiErr
+}
+
+// validTimes validates the time range of LogOptions
+func (a *LogOptions) validTimes() *errs.APIError {
+	if a.SinceSeconds < 0 {
+		return errs.ValidationError("sinceSeconds can't be negative")
+	}
+
+	if a.SinceTime != nil && a.SinceSeconds > 0 {
+		return errs.ValidationError("only one of sinceTime or sinceSeconds can be provided when getting logs")
+	}
+
+	if a.UntilTime != nil && a.SinceTime != nil && a.UntilTime.Before(*a.SinceTime) {
+		return errs.ValidationError("untilTime can't be before sinceTime")
+	}
+
+	if a.UntilTime != nil && a.Follow {
+		return errs.ValidationError("untilTime can't be provided when streaming logs")
+	}
+
 	return nil
 }
 
@@ -68,6 +150,16 @@ func (a *LogOptions) ValidPodName() bool {
 	return false
 }
 
+// ValidSelector retuns true if LogOptions.LinkedName or LogOptions.LabelSelector is set
+func (a *LogOptions) ValidSelector() bool {
+	return len(a.LinkedName) > 0 || len(a.LabelSelector) > 0
+}
+
+// LogPrefix returns the prefix of every line of linked logs, telling which pod/container it's from.
+func LogPrefix(pod, container string) string {
+	return fmt.Sprintf("[%s/%s] ", pod, container)
+}
+
 // GetTailLines returns PodOverviewOptions.Tail > 0 || default (32, why not)
 func (a *LogOptions) GetTailLines() int64 {
 	if a.Tail > 0 {
@@ -78,35 +170,275 @@ func (a *LogOptions) GetTailLines() int64 {
 
 // Logs returns a list of all logs for pods
 func (k *Client) Logs(options LogOptions) (logs Log, apiErr *errs.APIError) {
+	logs.Output = []LogLine{}
+
 	// get logs
 	rc, apiErr := k.ReadLogs(options)
 
 	// add a message to the output for user if errror
 	if apiErr != nil {
-		logs.Output = fmt.Sprintf("Could not read request stream when retrieving logs for %s/%s", options.Namespace, options.PodName)
+		logs.Output = append(logs.Output, LogLine{
+			Text: fmt.Sprintf("Could not read request stream when retrieving logs for %s/%s", options.Namespace, options.PodName),
+		})
 		return logs, apiErr
 	}
 
 	defer rc.Close()
 
-	buf := new(bytes.Buffer)
-	_, err := io.Copy(buf, rc)
+	lines, err := readLogLines(rc, options)
 
 	if err != nil {
 		return logs, errs.InternalServerError(err.Error())
 	}
 
-	o := buf.String()
+	// the options were validated reading the logs
+	filter, _ := NewLogFilter(options)
+
+	logs.Output = filter.Lines(lines)
+
+	return logs, nil
+}
+
+// readLogLines reads the lines of a log stream requested with options. The stream is read up to
+// UntilTime, which the api server doesn't support, keeping the tail before it.
+func readLogLines(r io.Reader, options LogOptions) ([]LogLine, error) {
+	lines := []LogLine{}
+	tail := int(options.GetTailLines())
+
+	reader := bufio.NewReader(r)
+
+	for {
+		text, err := reader.ReadString('\n')
+
+		if len(text) > 0 {
+			line := LogLine{Text: strings.TrimRight(text, "\r\n")}
+
+			if options.Timestamps || options.UntilTime != nil {
+				line = parseLogLine(line.Text)
+			}
+
+			// lines are in order, the rest are later
+			if options.UntilTime != nil && line.Timestamp != nil && line.Timestamp.After(*options.UntilTime) {
+				break
+			}
+
+			if !options.Timestamps {
+				line.Timestamp = nil
+			}
+
+			lines = append(lines, ParseJSONLog(line))
 
-	if len(o) > 0 {
-		logs.Output = buf.String()
+			// only the tail is kept, copied now and then so earlier lines can be freed
+			if options.UntilTime != nil && len(lines) >= 2*tail {
+				lines = append([]LogLine{}, lines[len(lines)-tail:]...)
+			}
+		}
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if options.UntilTime != nil && len(lines) > tail {
+		lines = lines[len(lines)-tail:]
+	}
+
+	return lines, nil
+}
+
+// parseLogLine splits the timestamp kubernetes prefixes a line with from the text,
+// leaving the line as it is if it doesn't have one.
+func parseLogLine(text string) LogLine {
+	i := strings.IndexByte(text, ' ')
+	if i < 0 {
+		i = len(text)
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, text[:i])
+
+	if err != nil {
+		return LogLine{Text: text}
+	}
+
+	if i < len(text) {
+		i++
+	}
+
+	return LogLine{Timestamp: &t, Text: text[i:]}
+}
+
+// LinkedLogs returns the logs of every container of the pods linked to LinkedName and selected by
+// LabelSelector, only the container named ContainerName if set. The lines of each container are
+// prefixed with its pod/container, see LogPrefix. Containers waiting to start have no logs, and
+// with Previous only the containers that have restarted do. With Timestamps, the lines of every
+// container are merged in the order they were logged.
+func (k *Client) LinkedLogs(options LogOptions) (logs Log, apiErr *errs.APIError) {
+	logs.Sources = []string{}
+	logs.Output = []LogLine{}
+
+	if !options.ValidNamespace() {
+		return logs, errs.ValidationError("namespace must be provided when getting logs")
+	}
+
+	if !options.ValidSelector() {
+		return logs, errs.ValidationError("linkedName or labelSelector must be provided when getting linked logs")
+	}
+
+	if apiErr = options.validOutput(); apiErr != nil {
+		return logs, apiErr
+	}
+
+	pods, _, apiErr := k.Pods(PodOptions{
+		LinkedName:  options.LinkedName,
+		Namespace:   options.Namespace,
+		ListOptions: ListOptions{LabelSelector: options.LabelSelector},
+		Logger:      options.Logger,
+		Context:     options.Context,
+	})
+
+	if apiErr != nil {
+		return logs, apiErr
+	}
+
+	type source struct {
+		pod       string
+		container string
+	}
+
+	sources := []source{}
+	for _, p := range pods {
+		for _, c := range loggedContainers(p.Pod, options.ContainerName, options.Previous) {
+			sources = append(sources, source{p.Name, c})
+		}
+	}
+
+	outputs := make([][]LogLine, len(sources))
+
+	wg := sync.WaitGroup{}
+
+	wg.Add(len(sources))
+
+	for i, s := range sources {
+		go func(index int, s source) {
+			defer wg.Done()
+
+			o := options
+			o.PodName = s.pod
+			o.ContainerName = s.container
+			o.Follow = false
+
+			l, apiErr := k.Logs(o)
+
+			if apiErr != nil {
+				// the container may have been deleted since the pods were listed
+				klog.Trace()
+				return
+			}
+
+			outputs[index] = prefixLines(LogPrefix(s.pod, s.container), l.Output)
+		}(i, s)
+	}
+
+	wg.Wait()
+
+	for i, s := range sources {
+		if len(outputs[i]) > 0 {
+			logs.Sources = append(logs.Sources, fmt.Sprintf("%s/%s", s.pod, s.container))
+			logs.Output = append(logs.Output, outputs[i]...)
+		}
+	}
+
+	if options.Timestamps {
+		sortLogLines(logs.Output)
 	}
 
 	return logs, nil
 }
 
+// sortLogLines sorts lines by their timestamp, a line without one stays after the line before it.
+func sortLogLines(lines []LogLine) {
+	type entry struct {
+		line   LogLine
+		logged time.Time
+	}
+
+	entries := make([]entry, len(lines))
+
+	for i, line := range lines {
+		entries[i].line = line
+		if line.Timestamp != nil {
+			entries[i].logged = *line.Timestamp
+		} else if i > 0 {
+			entries[i].logged = entries[i-1].logged
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].logged.Before(entries[j].logged)
+	})
+
+	for i, e := range entries {
+		lines[i] = e.line
+	}
+}
+
+// loggedContainers returns the init and app containers of a pod that have logs, those that
+// are running or have terminated, only the one named container if it's set. With previous,
+// the containers with a previous instance, see previousLogs.
+func loggedContainers(pod *v1.Pod, container string, previous bool) []string {
+	containers := []string{}
+
+	if pod == nil {
+		return containers
+	}
+
+	statuses := append(append([]v1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+
+	for _, cs := range statuses {
+		if len(container) > 0 && cs.Name != container {
+			continue
+		}
+		if previous && cs.LastTerminationState.Terminated != nil {
+			containers = append(containers, cs.Name)
+		} else if !previous && (cs.State.Running != nil || cs.State.Terminated != nil) {
+			containers = append(containers, cs.Name)
+		}
+	}
+
+	return containers
+}
+
+// previousLogs returns the containers of a pod with logs from a previous instance, those
+// that have terminated and been restarted, see LogOptions.Previous.
+func previousLogs(pod *v1.Pod) []string {
+	containers := []string{}
+
+	statuses := append(append([]v1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+
+	for _, cs := range statuses {
+		if cs.LastTerminationState.Terminated != nil {
+			containers = append(containers, cs.Name)
+		}
+	}
+
+	return containers
+}
+
+// prefixLines prefixes the text of every line.
+func prefixLines(prefix string, lines []LogLine) []LogLine {
+	for i := range lines {
+		lines[i].Text = prefix + lines[i].Text
+	}
+	return lines
+}
+
 // ReadLogs returns an io.ReadCloser to live stream logs for a pod. Error codes will be the same
 // as standard http error codes, but using the values directly so this package doesn't need to import http.
+// The stream isn't filtered, see NewLogFilter.
 func (k *Client) ReadLogs(options LogOptions) (rc io.ReadCloser, apiErr *errs.APIError) {
 	if apiErr = options.Valid(); apiErr != nil {
 		return nil, apiErr
@@ -131,17 +463,38 @@ func (k *Client) ReadLogs(options LogOptions) (rc io.ReadCloser, apiErr *errs.AP
 		}
 	}
 
-	tail := options.Tail
-	// ensure tail set to 1 line since we will be streaming
-	if options.Follow {
+	tail := options.GetTailLines()
+	// ensure tail set to 1 line since we will be streaming, the previous
+	// instance has stopped so the stream ends after its last lines.
+	if options.Follow && !options.Previous {
 		tail = 1
 	}
-	// start stream at last line
-	req := list.GetLogs(options.PodName, &v1.PodLogOptions{
+	lo := &v1.PodLogOptions{
 		Container: options.ContainerName,
 		TailLines: &tail,
 		Follow:    options.Follow,
-	})
+		Previous:  options.Previous,
+		// untilTime is applied to the timestamp of each line, see readLogLines
+		Timestamps: options.Timestamps || options.UntilTime != nil,
+	}
+
+	// the tail is counted back from untilTime, not the last line, and a stream
+	// followed since a time starts there.
+	if options.UntilTime != nil || (options.Follow && options.SinceTime != nil) {
+		lo.TailLines = nil
+	}
+
+	if options.SinceTime != nil {
+		since := metav1.NewTime(*options.SinceTime)
+		lo.SinceTime = &since
+	}
+
+	if options.SinceSeconds > 0 {
+		lo.SinceSeconds = &options.SinceSeconds
+	}
+
+	// start stream at last line
+	req := list.GetLogs(options.PodName, lo)
 
 	stream, err := req.Stream(options.Context)
 
@@ -0,0 +1,166 @@
+package k8sv1
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/kubelens/kubelens/api/errs"
+)
+
+// LogFilter keeps the lines of a log matching LogOptions.Include and not matching
+// LogOptions.Exclude, with lines of context before and after them like grep -B and -A.
+// With LogOptions.Level or LogOptions.Fields, only json lines at that level or above
+// and with every field are kept, see ParseJSONLog.
+type LogFilter struct {
+	include *regexp.Regexp
+	exclude *regexp.Regexp
+	// the rank of the minimum level, -1 for any line
+	level int
+	// the values fields must have by key
+	fields []logField
+	before int
+	after  int
+	// the last lines not kept, up to before of them
+	previous []LogLine
+	// the number of lines still to keep after the last match
+	following int
+}
+
+// NewLogFilter returns the filter set by options, nil if they don't filter.
+func NewLogFilter(options LogOptions) (filter *LogFilter, apiErr *errs.APIError) {
+	if options.Before < 0 || options.After < 0 {
+		return nil, errs.ValidationError("before and after can't be negative")
+	}
+
+	if len(options.Include) == 0 && len(options.Exclude) == 0 && len(options.Level) == 0 && len(options.Fields) == 0 {
+		return nil, nil
+	}
+
+	filter = &LogFilter{
+		level:  -1,
+		before: options.Before,
+		after:  options.After,
+	}
+
+	if len(options.Level) > 0 {
+		if filter.level = levelRank(normalizeLevel(options.Level)); filter.level < 0 {
+			return nil, errs.ValidationError(fmt.Sprintf("invalid level %q, must be one of %s", options.Level, strings.Join(logLevels, ", ")))
+		}
+	}
+
+	for _, field := range options.Fields {
+		i := strings.IndexByte(field, '=')
+
+		if i < 1 {
+			return nil, errs.ValidationError(fmt.Sprintf("invalid field %q, must be key=value", field))
+		}
+
+		filter.fields = append(filter.fields, logField{key: field[:i], value: field[i+1:]})
+	}
+
+	if filter.include, apiErr = compileLogFilter("include", options.Include, options.IgnoreCase); apiErr != nil {
+		return nil, apiErr
+	}
+
+	if filter.exclude, apiErr = compileLogFilter("exclude", options.Exclude, options.IgnoreCase); apiErr != nil {
+		return nil, apiErr
+	}
+
+	return filter, nil
+}
+
+// compileLogFilter compiles the expression of a filter, nil if it isn't set.
+func compileLogFilter(name, expr string, ignoreCase bool) (*regexp.Regexp, *errs.APIError) {
+	if len(expr) == 0 {
+		return nil, nil
+	}
+
+	if ignoreCase {
+		expr = "(?i)" + expr
+	}
+
+	re, err := regexp.Compile(expr)
+
+	if err != nil {
+		return nil, errs.ValidationError(fmt.Sprintf("invalid %s: %s", name, err.Error()))
+	}
+
+	return re, nil
+}
+
+// logField is a field a json line must have, see LogOptions.Fields.
+type logField struct {
+	key   string
+	value string
+}
+
+// matches returns true if a line is included and not excluded, and is at the level and has the fields.
+func (f *LogFilter) matches(line LogLine) bool {
+	if f.include != nil && !f.include.MatchString(line.Text) {
+		return false
+	}
+
+	if f.exclude != nil && f.exclude.MatchString(line.Text) {
+		return false
+	}
+
+	if f.level >= 0 && levelRank(line.Level) < f.level {
+		return false
+	}
+
+	for _, field := range f.fields {
+		v, ok := lookupField(line.Fields, field.key)
+
+		if !ok || formatField(v) != field.value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Next returns the lines to keep once a line is read: the context before it and the line when
+// it matches, the line when it's context after a match, otherwise none. A nil filter keeps every line.
+func (f *LogFilter) Next(line LogLine) []LogLine {
+	if f == nil {
+		return []LogLine{line}
+	}
+
+	if f.matches(line) {
+		lines := append(f.previous, line)
+		f.previous = nil
+		f.following = f.after
+		return lines
+	}
+
+	line.Context = true
+
+	if f.following > 0 {
+		f.following--
+		return []LogLine{line}
+	}
+
+	if f.before > 0 {
+		f.previous = append(f.previous, line)
+		if len(f.previous) > f.before {
+			f.previous = f.previous[1:]
+		}
+	}
+
+	return nil
+}
+
+// Lines returns the lines to keep, see Next.
+func (f *LogFilter) Lines(lines []LogLine) []LogLine {
+	if f == nil {
+		return lines
+	}
+
+	kept := []LogLine{}
+	for _, line := range lines {
+		kept = append(kept, f.Next(line)...)
+	}
+
+	return kept
+}
@@ -0,0 +1,133 @@
+package k8sv1
+
+import (
+	"context"
+	"testing"
+
+	logfakes "github.com/kubelens/kubelens/api/log/fakes"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+)
+
+func logFilterTestLines(texts ...string) []LogLine {
+	lines := []LogLine{}
+	for _, text := range texts {
+		lines = append(lines, LogLine{Text: text})
+	}
+	return lines
+}
+
+func TestLogFilterLines(t *testing.T) {
+	lines := logFilterTestLines(
+		"GET /healthz 200",
+		"GET /api 200",
+		"connecting to db",
+		"ERROR db timeout",
+		"retrying",
+		"GET /healthz 200",
+		"GET /api 200",
+		"error: db timeout",
+		"GET /healthz 200",
+	)
+
+	tests := []struct {
+		name     string
+		options  LogOptions
+		expected []LogLine
+	}{
+		{
+			"no filter",
+			LogOptions{},
+			lines,
+		},
+		{
+			"include",
+			LogOptions{Include: "error"},
+			logFilterTestLines("error: db timeout"),
+		},
+		{
+			"ignore case",
+			LogOptions{Include: "error", IgnoreCase: true},
+			logFilterTestLines("ERROR db timeout", "error: db timeout"),
+		},
+		{
+			"exclude",
+			LogOptions{Include: "GET", Exclude: "healthz"},
+			logFilterTestLines("GET /api 200", "GET /api 200"),
+		},
+		{
+			"context",
+			LogOptions{Include: "timeout", Before: 1, After: 1},
+			[]LogLine{
+				{Text: "connecting to db", Context: true},
+				{Text: "ERROR db timeout"},
+				{Text: "retrying", Context: true},
+				{Text: "GET /api 200", Context: true},
+				{Text: "error: db timeout"},
+				{Text: "GET /healthz 200", Context: true},
+			},
+		},
+		{
+			"overlapping context",
+			LogOptions{Include: "timeout", Before: 3},
+			[]LogLine{
+				{Text: "GET /healthz 200", Context: true},
+				{Text: "GET /api 200", Context: true},
+				{Text: "connecting to db", Context: true},
+				{Text: "ERROR db timeout"},
+				{Text: "retrying", Context: true},
+				{Text: "GET /healthz 200", Context: true},
+				{Text: "GET /api 200", Context: true},
+				{Text: "error: db timeout"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := NewLogFilter(tt.options)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, filter.Lines(lines))
+		})
+	}
+}
+
+func TestNewLogFilterInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		options LogOptions
+	}{
+		{"include", LogOptions{Include: "(error"}},
+		{"exclude", LogOptions{Exclude: "[a-"}},
+		{"before", LogOptions{Include: "error", Before: -1}},
+		{"after", LogOptions{After: -1}},
+		{"level", LogOptions{Level: "verbose"}},
+		{"field", LogOptions{Fields: []string{"=abc"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewLogFilter(tt.options)
+
+			assert.Equal(t, 400, err.Code)
+		})
+	}
+}
+
+func TestLogsFiltered(t *testing.T) {
+	c := setupCachedClient(t, logTestPod("web-1", nil, v1.ContainerStatus{Name: "web"}))
+
+	for include, expected := range map[string]int{"fake": 1, "nothing": 0} {
+		logs, err := c.Logs(LogOptions{
+			Logger:    &logfakes.Logger{},
+			Namespace: "one",
+			PodName:   "web-1",
+			Include:   include,
+			Context:   context.Background(),
+		})
+
+		assert.Nil(t, err)
+		assert.Len(t, logs.Output, expected)
+	}
+}
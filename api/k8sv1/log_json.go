@@ -0,0 +1,194 @@
+package k8sv1
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kubelens/kubelens/api/config"
+)
+
+// logLevels are the levels of json log lines, least severe first, see normalizeLevel.
+var logLevels = []string{"trace", "debug", "info", "warn", "error", "fatal"}
+
+// levelAliases maps the level names of common loggers to logLevels.
+var levelAliases = map[string]string{
+	"dbg":         "debug",
+	"information": "info",
+	"notice":      "info",
+	"warning":     "warn",
+	"err":         "error",
+	"dpanic":      "fatal",
+	"panic":       "fatal",
+	"crit":        "fatal",
+	"critical":    "fatal",
+	"alert":       "fatal",
+	"emerg":       "fatal",
+	"emergency":   "fatal",
+}
+
+// ParseJSONLog sets the level, message, timestamp and fields of a line logged as a json object,
+// with the keys of config.JSONLogs. The timestamp kubernetes provided is kept. Any other line is
+// returned as it is.
+func ParseJSONLog(line LogLine) LogLine {
+	text := strings.TrimSpace(line.Text)
+
+	if !strings.HasPrefix(text, "{") {
+		return line
+	}
+
+	dec := json.NewDecoder(bytes.NewReader([]byte(text)))
+	// keep large ids as they were logged
+	dec.UseNumber()
+
+	fields := map[string]interface{}{}
+
+	if err := dec.Decode(&fields); err != nil {
+		return line
+	}
+
+	if _, err := dec.Token(); err != io.EOF {
+		return line
+	}
+
+	keys := config.C.JSONLogKeys()
+
+	if v, ok := takeField(fields, keys.LevelKeys); ok {
+		line.Level = normalizeLevel(v)
+	}
+
+	if v, ok := takeField(fields, keys.MessageKeys); ok {
+		line.Message = formatField(v)
+	}
+
+	if v, ok := takeField(fields, keys.TimestampKeys); ok && line.Timestamp == nil {
+		line.Timestamp = parseLogTime(v)
+	}
+
+	if len(fields) > 0 {
+		line.Fields = fields
+	}
+
+	return line
+}
+
+// takeField removes and returns the value of the first key set in fields.
+func takeField(fields map[string]interface{}, keys []string) (interface{}, bool) {
+	for _, key := range keys {
+		if v, ok := fields[key]; ok {
+			delete(fields, key)
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// normalizeLevel returns the name of a level in logLevels, numeric bunyan and pino levels
+// included. A level it doesn't know is returned in lower case.
+func normalizeLevel(v interface{}) string {
+	if n, ok := v.(json.Number); ok {
+		l, err := n.Float64()
+
+		if err != nil {
+			return n.String()
+		}
+
+		// bunyan levels, 10 is trace to 60 fatal
+		i := int(l)/10 - 1
+		if i < 0 {
+			i = 0
+		}
+		if i >= len(logLevels) {
+			i = len(logLevels) - 1
+		}
+		return logLevels[i]
+	}
+
+	level := strings.ToLower(formatField(v))
+
+	if alias, ok := levelAliases[level]; ok {
+		return alias
+	}
+	return level
+}
+
+// levelRank returns the position of a level in logLevels, -1 if it isn't one.
+func levelRank(level string) int {
+	for i, l := range logLevels {
+		if l == level {
+			return i
+		}
+	}
+	return -1
+}
+
+// parseLogTime parses an RFC 3339 time or a unix time in seconds, or milliseconds when it's too
+// large to be seconds, nil if it's neither.
+func parseLogTime(v interface{}) *time.Time {
+	switch t := v.(type) {
+	case string:
+		logged, err := time.Parse(time.RFC3339Nano, t)
+		if err != nil {
+			return nil
+		}
+		return &logged
+	case json.Number:
+		f, err := t.Float64()
+		if err != nil {
+			return nil
+		}
+		if f > 1e12 {
+			f /= 1000
+		}
+		logged := time.Unix(0, int64(f*float64(time.Second))).UTC()
+		return &logged
+	}
+	return nil
+}
+
+// lookupField returns the value of a field, a key of a nested object can be
+// given as a path, e.g. http.status.
+func lookupField(fields map[string]interface{}, key string) (interface{}, bool) {
+	if v, ok := fields[key]; ok {
+		return v, true
+	}
+
+	i := strings.IndexByte(key, '.')
+
+	for i > 0 {
+		if nested, ok := fields[key[:i]].(map[string]interface{}); ok {
+			if v, ok := lookupField(nested, key[i+1:]); ok {
+				return v, true
+			}
+		}
+
+		next := strings.IndexByte(key[i+1:], '.')
+		if next < 0 {
+			break
+		}
+		i += next + 1
+	}
+
+	return nil, false
+}
+
+// formatField returns the value of a field as it's compared with a filter, strings
+// and numbers as they were logged, objects and arrays as json.
+func formatField(v interface{}) string {
+	switch f := v.(type) {
+	case string:
+		return f
+	case json.Number:
+		return f.String()
+	case bool:
+		return strconv.FormatBool(f)
+	case nil:
+		return "null"
+	}
+
+	b, _ := json.Marshal(v)
+	return string(b)
+}
@@ -0,0 +1,131 @@
+package k8sv1
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kubelens/kubelens/api/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseJSONLog(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected LogLine
+	}{
+		{
+			"logrus",
+			`{"level":"warning","msg":"slow query","time":"2021-06-01T10:00:00Z","request_id":"abc"}`,
+			LogLine{
+				Timestamp: logTestTime("2021-06-01T10:00:00Z"),
+				Level:     "warn",
+				Message:   "slow query",
+				Fields:    map[string]interface{}{"request_id": "abc"},
+			},
+		},
+		{
+			"zap",
+			`{"level":"error","ts":1622541600.5,"msg":"request failed","http":{"status":500}}`,
+			LogLine{
+				Timestamp: logTestTime("2021-06-01T10:00:00.5Z"),
+				Level:     "error",
+				Message:   "request failed",
+				Fields:    map[string]interface{}{"http": map[string]interface{}{"status": json.Number("500")}},
+			},
+		},
+		{
+			"bunyan",
+			`{"name":"api","level":30,"msg":"listening","time":"2021-06-01T10:00:00.000Z","v":0}`,
+			LogLine{
+				Timestamp: logTestTime("2021-06-01T10:00:00Z"),
+				Level:     "info",
+				Message:   "listening",
+				Fields:    map[string]interface{}{"name": "api", "v": json.Number("0")},
+			},
+		},
+		{
+			"text",
+			`level=info msg="not json"`,
+			LogLine{},
+		},
+		{
+			"invalid",
+			`{"level":"info"`,
+			LogLine{},
+		},
+		{
+			"trailing text",
+			`{"level":"info"} and more`,
+			LogLine{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.expected.Text = tt.text
+
+			assert.Equal(t, tt.expected, ParseJSONLog(LogLine{Text: tt.text}))
+		})
+	}
+}
+
+func TestParseJSONLogKeys(t *testing.T) {
+	config.C.JSONLogs = config.JSONLogs{LevelKeys: []string{"severity"}, MessageKeys: []string{"event"}}
+
+	t.Cleanup(func() {
+		config.C.JSONLogs = config.JSONLogs{}
+	})
+
+	logged := logTestTime("2021-06-01T09:00:00Z")
+
+	line := ParseJSONLog(LogLine{
+		Timestamp: logged,
+		Text:      `{"severity":"CRITICAL","event":"disk full","msg":"other","time":"2021-06-01T10:00:00Z"}`,
+	})
+
+	assert.Equal(t, "fatal", line.Level)
+	assert.Equal(t, "disk full", line.Message)
+	// kubernetes provided the timestamp
+	assert.Equal(t, logged, line.Timestamp)
+	assert.Equal(t, map[string]interface{}{"msg": "other"}, line.Fields)
+}
+
+func TestLogFilterJSON(t *testing.T) {
+	lines := []LogLine{}
+	for _, text := range []string{
+		`{"level":"debug","msg":"query","request_id":"abc"}`,
+		`{"level":"info","msg":"request","request_id":"abc","http":{"status":200}}`,
+		"plain text",
+		`{"level":"error","msg":"request","request_id":"def","http":{"status":500}}`,
+		`{"level":"warn","msg":"retry","request_id":"abc","http":{"status":500}}`,
+	} {
+		lines = append(lines, ParseJSONLog(LogLine{Text: text}))
+	}
+
+	tests := []struct {
+		name     string
+		options  LogOptions
+		expected []string
+	}{
+		{"level", LogOptions{Level: "WARNING"}, []string{"request", "retry"}},
+		{"field", LogOptions{Fields: []string{"request_id=abc"}}, []string{"query", "request", "retry"}},
+		{"nested field", LogOptions{Fields: []string{"http.status=500"}}, []string{"request", "retry"}},
+		{"level and fields", LogOptions{Level: "info", Fields: []string{"request_id=abc", "http.status=500"}}, []string{"retry"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := NewLogFilter(tt.options)
+
+			assert.Nil(t, err)
+
+			messages := []string{}
+			for _, line := range filter.Lines(lines) {
+				messages = append(messages, line.Message)
+			}
+
+			assert.Equal(t, tt.expected, messages)
+		})
+	}
+}
@@ -2,10 +2,14 @@ package k8sv1
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
 	logfakes "github.com/kubelens/kubelens/api/log/fakes"
 	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestGetLogsDefault(t *testing.T) {
@@ -83,3 +87,220 @@ func TestGetLogsClientError(t *testing.T) {
 	assert.Equal(t, "\nInternal Server Error: GetClientSet Test Error\n", err.Message)
 	assert.Equal(t, 500, err.Code)
 }
+
+func logTestPod(name string, labels map[string]string, statuses ...v1.ContainerStatus) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "one", Labels: labels},
+		Status:     v1.PodStatus{ContainerStatuses: statuses},
+	}
+}
+
+func TestLinkedLogs(t *testing.T) {
+	running := v1.ContainerState{Running: &v1.ContainerStateRunning{}}
+	web := map[string]string{"app": "web"}
+
+	c := setupCachedClient(t,
+		logTestPod("web-1", web,
+			v1.ContainerStatus{Name: "web", State: running},
+			v1.ContainerStatus{Name: "proxy", State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{}}},
+		),
+		logTestPod("web-2", web,
+			v1.ContainerStatus{Name: "web", State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{}}},
+		),
+		logTestPod("api-1", map[string]string{"app": "api"},
+			v1.ContainerStatus{Name: "api", State: running},
+		),
+	)
+
+	logs, err := c.LinkedLogs(LogOptions{
+		Logger:     &logfakes.Logger{},
+		Namespace:  "one",
+		LinkedName: "web",
+		Tail:       100,
+		Context:    context.Background(),
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"web-1/web", "web-2/web"}, logs.Sources)
+	// the fake clientset always logs "fake logs"
+	assert.Equal(t, []LogLine{{Text: "[web-1/web] fake logs"}, {Text: "[web-2/web] fake logs"}}, logs.Output)
+}
+
+func TestLinkedLogsPrevious(t *testing.T) {
+	running := v1.ContainerState{Running: &v1.ContainerStateRunning{}}
+	crashed := v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: "Error", ExitCode: 1}}
+
+	c := setupCachedClient(t,
+		logTestPod("web-1", map[string]string{"app": "web"},
+			v1.ContainerStatus{Name: "web", State: running, LastTerminationState: crashed, RestartCount: 1},
+			v1.ContainerStatus{Name: "proxy", State: running},
+		),
+	)
+
+	logs, err := c.LinkedLogs(LogOptions{
+		Logger:     &logfakes.Logger{},
+		Namespace:  "one",
+		LinkedName: "web",
+		Previous:   true,
+		Context:    context.Background(),
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"web-1/web"}, logs.Sources)
+
+	pods, _, err := c.Pods(PodOptions{Namespace: "one", Context: context.Background()})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"web"}, pods[0].PreviousLogs)
+}
+
+func TestLinkedLogsInitContainers(t *testing.T) {
+	running := v1.ContainerState{Running: &v1.ContainerStateRunning{}}
+	crashed := v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: "Error", ExitCode: 1}}
+
+	pod := logTestPod("web-1", map[string]string{"app": "web"},
+		v1.ContainerStatus{Name: "web", State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{}}},
+	)
+	pod.Status.InitContainerStatuses = []v1.ContainerStatus{
+		{Name: "migrate", State: running, LastTerminationState: crashed, RestartCount: 1},
+	}
+
+	c := setupCachedClient(t, pod)
+
+	logs, err := c.LinkedLogs(LogOptions{
+		Logger:     &logfakes.Logger{},
+		Namespace:  "one",
+		LinkedName: "web",
+		Context:    context.Background(),
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"web-1/migrate"}, logs.Sources)
+
+	logs, err = c.LinkedLogs(LogOptions{
+		Logger:     &logfakes.Logger{},
+		Namespace:  "one",
+		LinkedName: "web",
+		Previous:   true,
+		Context:    context.Background(),
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"web-1/migrate"}, logs.Sources)
+}
+
+func TestLinkedLogsMissingSelector(t *testing.T) {
+	c := setupClient("fake", "test", false, false)
+
+	_, err := c.LinkedLogs(LogOptions{
+		Logger:    &logfakes.Logger{},
+		Namespace: "fake",
+		Context:   context.Background(),
+	})
+
+	assert.Equal(t, 400, err.Code)
+}
+
+func logTestTime(s string) *time.Time {
+	t, _ := time.Parse(time.RFC3339Nano, s)
+	return &t
+}
+
+func TestReadLogLines(t *testing.T) {
+	stream := "2021-06-01T10:00:00.5Z starting\n" +
+		"2021-06-01T10:05:00Z listening on :8080\r\n" +
+		"  at main.go:12\n" +
+		"2021-06-01T10:10:00Z shutting down"
+
+	tests := []struct {
+		name     string
+		options  LogOptions
+		expected []LogLine
+	}{
+		{
+			"text",
+			LogOptions{},
+			[]LogLine{
+				{Text: "2021-06-01T10:00:00.5Z starting"},
+				{Text: "2021-06-01T10:05:00Z listening on :8080"},
+				{Text: "  at main.go:12"},
+				{Text: "2021-06-01T10:10:00Z shutting down"},
+			},
+		},
+		{
+			"timestamps",
+			LogOptions{Timestamps: true},
+			[]LogLine{
+				{Timestamp: logTestTime("2021-06-01T10:00:00.5Z"), Text: "starting"},
+				{Timestamp: logTestTime("2021-06-01T10:05:00Z"), Text: "listening on :8080"},
+				{Text: "  at main.go:12"},
+				{Timestamp: logTestTime("2021-06-01T10:10:00Z"), Text: "shutting down"},
+			},
+		},
+		{
+			"until",
+			LogOptions{UntilTime: logTestTime("2021-06-01T10:06:00Z")},
+			[]LogLine{
+				{Text: "starting"},
+				{Text: "listening on :8080"},
+				{Text: "  at main.go:12"},
+			},
+		},
+		{
+			"tail before until",
+			LogOptions{UntilTime: logTestTime("2021-06-01T10:06:00Z"), Tail: 2, Timestamps: true},
+			[]LogLine{
+				{Timestamp: logTestTime("2021-06-01T10:05:00Z"), Text: "listening on :8080"},
+				{Text: "  at main.go:12"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines, err := readLogLines(strings.NewReader(stream), tt.options)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, lines)
+		})
+	}
+}
+
+func TestSortLogLines(t *testing.T) {
+	lines := []LogLine{
+		{Timestamp: logTestTime("2021-06-01T10:00:00Z"), Text: "[a/web] one"},
+		{Timestamp: logTestTime("2021-06-01T10:02:00Z"), Text: "[a/web] three"},
+		{Text: "[a/web] three, continued"},
+		{Timestamp: logTestTime("2021-06-01T10:01:00Z"), Text: "[b/web] two"},
+	}
+
+	sortLogLines(lines)
+
+	assert.Equal(t, []string{"[a/web] one", "[b/web] two", "[a/web] three", "[a/web] three, continued"}, []string{
+		lines[0].Text, lines[1].Text, lines[2].Text, lines[3].Text,
+	})
+}
+
+func TestLogOptionsTimes(t *testing.T) {
+	since := logTestTime("2021-06-01T10:00:00Z")
+	until := logTestTime("2021-06-01T09:00:00Z")
+
+	tests := []struct {
+		name    string
+		options LogOptions
+	}{
+		{"negative since seconds", LogOptions{SinceSeconds: -1}},
+		{"since time and seconds", LogOptions{SinceTime: since, SinceSeconds: 60}},
+		{"until before since", LogOptions{SinceTime: since, UntilTime: until}},
+		{"until streaming", LogOptions{UntilTime: until, Follow: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.options.PodName = "test"
+			tt.options.Namespace = "fake"
+
+			assert.Equal(t, 400, tt.options.Valid().Code)
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package k8sv1
+
+import (
+	"fmt"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/yaml"
+)
+
+// Manifester is an overview of a single object that can be exported as a manifest.
+type Manifester interface {
+	// Object returns the full object, nil if the overview doesn't have it.
+	Object() runtime.Object
+}
+
+// Object returns the full pod.
+func (o *PodOverview) Object() runtime.Object { return o.Pod }
+
+// Object returns the full deployment.
+func (o *DeploymentOverview) Object() runtime.Object { return o.Deployment }
+
+// Object returns the full daemonset.
+func (o *DaemonSetOverview) Object() runtime.Object { return o.DaemonSet }
+
+// Object returns the full statefulset.
+func (o *StatefulSetOverview) Object() runtime.Object { return o.StatefulSet }
+
+// Object returns the full replicaset.
+func (o *ReplicaSetOverview) Object() runtime.Object { return o.ReplicaSet }
+
+// Object returns the full job.
+func (o *JobOverview) Object() runtime.Object { return o.Job }
+
+// Object returns the full cronjob.
+func (o *CronJobOverview) Object() runtime.Object { return o.CronJob }
+
+// Object returns the full configmap.
+func (o *ConfigMapOverview) Object() runtime.Object { return o.ConfigMap }
+
+// Object returns the full service.
+func (o *ServiceOverview) Object() runtime.Object { return o.Service }
+
+// Object returns the full node.
+func (o *NodeOverview) Object() runtime.Object { return o.Node }
+
+// serverFields are the metadata fields set by the api server, which can't be applied.
+var serverFields = []string{
+	"managedFields",
+	"uid",
+	"resourceVersion",
+	"generation",
+	"creationTimestamp",
+	"deletionTimestamp",
+	"deletionGracePeriodSeconds",
+	"selfLink",
+	"ownerReferences",
+}
+
+// serverAnnotations are the annotations set by kubectl and the controllers.
+var serverAnnotations = []string{
+	"kubectl.kubernetes.io/last-applied-configuration",
+	"deployment.kubernetes.io/revision",
+}
+
+// jobLabels are the labels the job controller adds to the selector and pod template of a job.
+var jobLabels = []string{
+	"controller-uid",
+	"job-name",
+	"batch.kubernetes.io/controller-uid",
+	"batch.kubernetes.io/job-name",
+}
+
+// Manifest returns an object as a yaml manifest that can be applied to another cluster,
+// without its status or any of the fields set by the api server.
+func Manifest(obj runtime.Object) ([]byte, error) {
+	// a typed nil pointer isn't a nil interface
+	if obj == nil || reflect.ValueOf(obj).IsNil() {
+		return nil, fmt.Errorf("no object to export")
+	}
+
+	kinds, _, err := scheme.Scheme.ObjectKinds(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	apiVersion, kind := kinds[0].ToAPIVersionAndKind()
+	u["apiVersion"] = apiVersion
+	u["kind"] = kind
+
+	delete(u, "status")
+
+	if meta, ok := u["metadata"].(map[string]interface{}); ok {
+		for _, f := range serverFields {
+			delete(meta, f)
+		}
+
+		if annotations, ok := meta["annotations"].(map[string]interface{}); ok {
+			for _, a := range serverAnnotations {
+				delete(annotations, a)
+			}
+			if len(annotations) == 0 {
+				delete(meta, "annotations")
+			}
+		}
+	}
+
+	if spec, ok := u["spec"].(map[string]interface{}); ok {
+		switch kind {
+		case "Pod":
+			// assigned by the scheduler
+			delete(spec, "nodeName")
+		case "Job":
+			// generated by the job controller, unless set manually
+			if manual, _ := spec["manualSelector"].(bool); !manual {
+				delete(spec, "selector")
+
+				// the labels of a job default to those of its template
+				template, _ := nested(spec, "template", "metadata")
+				for _, meta := range []map[string]interface{}{template, u["metadata"].(map[string]interface{})} {
+					labels, _ := meta["labels"].(map[string]interface{})
+					for _, l := range jobLabels {
+						delete(labels, l)
+					}
+					if len(labels) == 0 {
+						delete(meta, "labels")
+					}
+				}
+			}
+		case "Service":
+			// allocated by the api server
+			delete(spec, "clusterIP")
+			delete(spec, "clusterIPs")
+			delete(spec, "healthCheckNodePort")
+
+			if ports, ok := spec["ports"].([]interface{}); ok {
+				for _, p := range ports {
+					if port, ok := p.(map[string]interface{}); ok {
+						delete(port, "nodePort")
+					}
+				}
+			}
+		}
+	}
+
+	// e.g. the creation timestamp of pod templates
+	removeNulls(u)
+
+	return yaml.Marshal(u)
+}
+
+// nested returns the object at the path of fields.
+func nested(obj map[string]interface{}, fields ...string) (map[string]interface{}, bool) {
+	for _, f := range fields {
+		next, ok := obj[f].(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		obj = next
+	}
+	return obj, true
+}
+
+// removeNulls removes the null fields of every nested object.
+func removeNulls(v interface{}) {
+	switch d := v.(type) {
+	case map[string]interface{}:
+		for k, item := range d {
+			if item == nil {
+				delete(d, k)
+			} else {
+				removeNulls(item)
+			}
+		}
+	case []interface{}:
+		for _, item := range d {
+			removeNulls(item)
+		}
+	}
+}
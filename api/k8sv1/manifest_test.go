@@ -0,0 +1,148 @@
+package k8sv1
+
+import (
+	"context"
+	"testing"
+
+	logfakes "github.com/kubelens/kubelens/api/log/fakes"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestManifest(t *testing.T) {
+	setRedaction(t, nil)
+
+	c := setupCachedClient(t, &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "web",
+			Namespace:       "one",
+			UID:             "1234",
+			ResourceVersion: "99",
+			Generation:      3,
+			Labels:          map[string]string{"app": "web"},
+			Annotations: map[string]string{
+				"deployment.kubernetes.io/revision":                "3",
+				"kubectl.kubernetes.io/last-applied-configuration": "{}",
+			},
+			ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubectl"}},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{{Name: "web", Image: "web:1", Env: []v1.EnvVar{{Name: "DB_PASSWORD", Value: "hunter2"}}}},
+				},
+			},
+		},
+		Status: appsv1.DeploymentStatus{ReadyReplicas: 1},
+	})
+
+	overview, err := c.Deployment(DeploymentOptions{
+		Logger:    &logfakes.Logger{},
+		Namespace: "one",
+		Name:      "web",
+		Context:   context.Background(),
+	})
+
+	assert.Nil(t, err)
+
+	m, e := Manifest(overview.Object())
+
+	assert.Nil(t, e)
+	assert.Equal(t, `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  labels:
+    app: web
+  name: web
+  namespace: one
+spec:
+  strategy: {}
+  template:
+    metadata: {}
+    spec:
+      containers:
+      - env:
+        - name: DB_PASSWORD
+          value: '[REDACTED]'
+        image: web:1
+        name: web
+        resources: {}
+`, string(m))
+}
+
+func TestManifestJob(t *testing.T) {
+	generated := map[string]string{"controller-uid": "1234", "job-name": "backup"}
+
+	m, err := Manifest(&batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "one", UID: "1234", Labels: generated},
+		Spec: batchv1.JobSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"controller-uid": "1234"}},
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: generated},
+				Spec: v1.PodSpec{
+					Containers:    []v1.Container{{Name: "backup", Image: "backup:1"}},
+					RestartPolicy: v1.RestartPolicyNever,
+				},
+			},
+		},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, `apiVersion: batch/v1
+kind: Job
+metadata:
+  name: backup
+  namespace: one
+spec:
+  template:
+    metadata: {}
+    spec:
+      containers:
+      - image: backup:1
+        name: backup
+        resources: {}
+      restartPolicy: Never
+`, string(m))
+}
+
+func TestManifestNodePortService(t *testing.T) {
+	m, err := Manifest(&v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "one"},
+		Spec: v1.ServiceSpec{
+			Type:                  v1.ServiceTypeLoadBalancer,
+			ClusterIP:             "10.0.0.1",
+			ClusterIPs:            []string{"10.0.0.1"},
+			ExternalTrafficPolicy: v1.ServiceExternalTrafficPolicyTypeLocal,
+			HealthCheckNodePort:   32000,
+			Ports:                 []v1.ServicePort{{Name: "http", Port: 80, TargetPort: intstr.FromInt(8080), NodePort: 31080}},
+			Selector:              map[string]string{"app": "web"},
+		},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, `apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  namespace: one
+spec:
+  externalTrafficPolicy: Local
+  ports:
+  - name: http
+    port: 80
+    targetPort: 8080
+  selector:
+    app: web
+  type: LoadBalancer
+`, string(m))
+}
+
+func TestManifestNoObject(t *testing.T) {
+	_, err := Manifest((&PodOverview{}).Object())
+
+	assert.NotNil(t, err)
+}
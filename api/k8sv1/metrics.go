@@ -0,0 +1,246 @@
+package k8sv1
+
+import (
+	"context"
+	"math"
+
+	klog "github.com/kubelens/kubelens/api/log"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+// Metrics come from the metrics.k8s.io api served by metrics-server. When it isn't installed,
+// or hasn't scraped an object yet, overviews are returned without metrics rather than failing.
+
+// ResourceUsage is the cpu and memory usage of one or more containers compared to
+// their requests and limits. Percentages are only set for resources with a request or limit,
+// and only compare the usage of the containers that set it.
+type ResourceUsage struct {
+	// the current usage
+	Usage v1.ResourceList `json:"usage"`
+	// the requests of the containers
+	Requests v1.ResourceList `json:"requests,omitempty"`
+	// the limits of the containers
+	Limits v1.ResourceList `json:"limits,omitempty"`
+	// usage as a percentage of requests
+	PercentOfRequests map[v1.ResourceName]float64 `json:"percentOfRequests,omitempty"`
+	// usage as a percentage of limits
+	PercentOfLimits map[v1.ResourceName]float64 `json:"percentOfLimits,omitempty"`
+
+	// the usage of the containers with a request, or a limit, for the resource
+	requestedUsage v1.ResourceList
+	limitedUsage   v1.ResourceList
+}
+
+// ContainerMetrics is the resource usage of a container.
+type ContainerMetrics struct {
+	// the name of the container
+	Name string `json:"name"`
+	ResourceUsage
+}
+
+// PodMetrics is the resource usage of a pod, summed across its containers.
+type PodMetrics struct {
+	// when the metrics were collected
+	Timestamp metav1.Time `json:"timestamp"`
+	// the window the usage was averaged over
+	Window metav1.Duration `json:"window"`
+	ResourceUsage
+	// the usage of each container
+	Containers []ContainerMetrics `json:"containers"`
+}
+
+// NodeMetrics is the resource usage of a node.
+type NodeMetrics struct {
+	// when the metrics were collected
+	Timestamp metav1.Time `json:"timestamp"`
+	// the window the usage was averaged over
+	Window metav1.Duration `json:"window"`
+	// the current usage
+	Usage v1.ResourceList `json:"usage"`
+	// usage as a percentage of the resources available to pods
+	PercentOfAllocatable map[v1.ResourceName]float64 `json:"percentOfAllocatable,omitempty"`
+	// usage as a percentage of the node's total resources
+	PercentOfCapacity map[v1.ResourceName]float64 `json:"percentOfCapacity,omitempty"`
+}
+
+// podMetrics returns the metrics for a pod, nil if there aren't any.
+func (k *Client) podMetrics(ctx context.Context, pod *v1.Pod) *PodMetrics {
+	client, err := k.wrapper.GetMetricsClientSet()
+
+	if err != nil {
+		klog.Trace()
+		return nil
+	}
+
+	m, err := client.MetricsV1beta1().PodMetricses(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+
+	if err != nil {
+		return nil
+	}
+
+	return newPodMetrics(pod, m)
+}
+
+// listPodMetrics returns the metrics for the pods in a namespace keyed by namespace/name,
+// empty if there aren't any.
+func (k *Client) listPodMetrics(ctx context.Context, namespace string, lo metav1.ListOptions) map[string]*metricsv1beta1.PodMetrics {
+	found := map[string]*metricsv1beta1.PodMetrics{}
+
+	client, err := k.wrapper.GetMetricsClientSet()
+
+	if err != nil {
+		klog.Trace()
+		return found
+	}
+
+	list, err := client.MetricsV1beta1().PodMetricses(namespace).List(ctx, lo)
+
+	if err != nil {
+		return found
+	}
+
+	for i, m := range list.Items {
+		found[m.Namespace+"/"+m.Name] = &list.Items[i]
+	}
+
+	return found
+}
+
+// listNodeMetrics returns the metrics for nodes keyed by name, empty if there aren't any.
+func (k *Client) listNodeMetrics(ctx context.Context, lo metav1.ListOptions) map[string]*metricsv1beta1.NodeMetrics {
+	found := map[string]*metricsv1beta1.NodeMetrics{}
+
+	client, err := k.wrapper.GetMetricsClientSet()
+
+	if err != nil {
+		klog.Trace()
+		return found
+	}
+
+	list, err := client.MetricsV1beta1().NodeMetricses().List(ctx, lo)
+
+	if err != nil {
+		return found
+	}
+
+	for i, m := range list.Items {
+		found[m.Name] = &list.Items[i]
+	}
+
+	return found
+}
+
+func newPodMetrics(pod *v1.Pod, m *metricsv1beta1.PodMetrics) *PodMetrics {
+	metrics := &PodMetrics{
+		Timestamp:     m.Timestamp,
+		Window:        m.Window,
+		Containers:    []ContainerMetrics{},
+		ResourceUsage: newResourceUsage(),
+	}
+
+	for _, cm := range m.Containers {
+		container := ContainerMetrics{
+			Name: cm.Name,
+			ResourceUsage: ResourceUsage{
+				Usage: cm.Usage,
+			},
+		}
+
+		for _, c := range pod.Spec.Containers {
+			if c.Name == cm.Name {
+				container.Requests = c.Resources.Requests
+				container.Limits = c.Resources.Limits
+			}
+		}
+
+		container.PercentOfRequests = percentOf(container.Usage, container.Requests)
+		container.PercentOfLimits = percentOf(container.Usage, container.Limits)
+
+		addResources(metrics.Usage, container.Usage)
+		addResources(metrics.Requests, container.Requests)
+		addResources(metrics.Limits, container.Limits)
+		addUsageOf(metrics.requestedUsage, container.Usage, container.Requests)
+		addUsageOf(metrics.limitedUsage, container.Usage, container.Limits)
+
+		metrics.Containers = append(metrics.Containers, container)
+	}
+
+	metrics.PercentOfRequests = percentOf(metrics.requestedUsage, metrics.Requests)
+	metrics.PercentOfLimits = percentOf(metrics.limitedUsage, metrics.Limits)
+
+	return metrics
+}
+
+func newNodeMetrics(node *v1.Node, m *metricsv1beta1.NodeMetrics) *NodeMetrics {
+	return &NodeMetrics{
+		Timestamp:            m.Timestamp,
+		Window:               m.Window,
+		Usage:                m.Usage,
+		PercentOfAllocatable: percentOf(m.Usage, node.Status.Allocatable),
+		PercentOfCapacity:    percentOf(m.Usage, node.Status.Capacity),
+	}
+}
+
+// sumResourceUsage sums the usage of pods.
+func sumResourceUsage(pods []*PodMetrics) *ResourceUsage {
+	if len(pods) == 0 {
+		return nil
+	}
+
+	sum := newResourceUsage()
+
+	for _, m := range pods {
+		addResources(sum.Usage, m.Usage)
+		addResources(sum.Requests, m.Requests)
+		addResources(sum.Limits, m.Limits)
+		addResources(sum.requestedUsage, m.requestedUsage)
+		addResources(sum.limitedUsage, m.limitedUsage)
+	}
+
+	sum.PercentOfRequests = percentOf(sum.requestedUsage, sum.Requests)
+	sum.PercentOfLimits = percentOf(sum.limitedUsage, sum.Limits)
+
+	return &sum
+}
+
+func newResourceUsage() ResourceUsage {
+	return ResourceUsage{
+		Usage:          v1.ResourceList{},
+		Requests:       v1.ResourceList{},
+		Limits:         v1.ResourceList{},
+		requestedUsage: v1.ResourceList{},
+		limitedUsage:   v1.ResourceList{},
+	}
+}
+
+// addUsageOf adds the usage of each resource set in resources, e.g. the requests of a container, to list.
+func addUsageOf(list, usage, resources v1.ResourceList) {
+	for name, quantity := range usage {
+		if r, ok := resources[name]; ok && !r.IsZero() {
+			addResources(list, v1.ResourceList{name: quantity})
+		}
+	}
+}
+
+// percentOf returns usage as a percentage of total for each resource in both, rounded to one decimal.
+func percentOf(usage, total v1.ResourceList) map[v1.ResourceName]float64 {
+	var percent map[v1.ResourceName]float64
+
+	for name, used := range usage {
+		t, ok := total[name]
+
+		if !ok || t.IsZero() {
+			continue
+		}
+
+		if percent == nil {
+			percent = map[v1.ResourceName]float64{}
+		}
+
+		percent[name] = math.Round(float64(used.MilliValue())/float64(t.MilliValue())*1000) / 10
+	}
+
+	return percent
+}
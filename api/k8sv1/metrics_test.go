@@ -0,0 +1,197 @@
+package k8sv1
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	logfakes "github.com/kubelens/kubelens/api/log/fakes"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stesting "k8s.io/client-go/testing"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+)
+
+// newFakeMetrics returns a metrics api serving the metrics. The fake clientset guesses the wrong
+// resource for the metrics kinds, so they're added to the tracker directly.
+func newFakeMetrics(t *testing.T, objects ...runtime.Object) *metricsfake.Clientset {
+	client := metricsfake.NewSimpleClientset()
+
+	for _, obj := range objects {
+		var err error
+
+		switch m := obj.(type) {
+		case *metricsv1beta1.PodMetrics:
+			err = client.Tracker().Create(schema.GroupVersionResource{Group: "metrics.k8s.io", Version: "v1beta1", Resource: "pods"}, m, m.Namespace)
+		case *metricsv1beta1.NodeMetrics:
+			err = client.Tracker().Create(schema.GroupVersionResource{Group: "metrics.k8s.io", Version: "v1beta1", Resource: "nodes"}, m, "")
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return client
+}
+
+// newMissingMetrics returns a metrics api that fails every request, as when metrics-server isn't installed.
+func newMissingMetrics() *metricsfake.Clientset {
+	client := metricsfake.NewSimpleClientset()
+	client.PrependReactor("*", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("the server could not find the requested resource")
+	})
+	return client
+}
+
+func metricsTestObjects() []runtime.Object {
+	return []runtime.Object{
+		&metricsv1beta1.PodMetrics{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "one", Labels: map[string]string{"app": "web"}},
+			Containers: []metricsv1beta1.ContainerMetrics{
+				{Name: "c", Usage: resources("50m", "64Mi")},
+				{Name: "c2", Usage: resources("50m", "64Mi")},
+			},
+		},
+		&metricsv1beta1.NodeMetrics{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+			Usage:      resources("1900m", "3584Mi"),
+		},
+	}
+}
+
+func metricsTestPod() *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "one", Labels: map[string]string{"app": "web"}},
+		Spec: v1.PodSpec{
+			NodeName: "node-1",
+			Containers: []v1.Container{
+				{Name: "c", Resources: v1.ResourceRequirements{Requests: resources("100m", "128Mi"), Limits: resources("200m", "256Mi")}},
+				{Name: "c2"},
+			},
+		},
+		Status: v1.PodStatus{Phase: v1.PodRunning},
+	}
+}
+
+func TestPodMetrics(t *testing.T) {
+	c := setupFakeMetricsClient(newFakeMetrics(t, metricsTestObjects()...), metricsTestPod())
+
+	p, err := c.Pod(PodOptions{
+		Logger:    &logfakes.Logger{},
+		Namespace: "one",
+		Name:      "web",
+		Context:   context.Background(),
+	})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, p.Metrics)
+	assert.Len(t, p.Metrics.Containers, 2)
+
+	container := p.Metrics.Containers[0]
+	assert.Equal(t, 50.0, container.PercentOfRequests[v1.ResourceCPU])
+	assert.Equal(t, 25.0, container.PercentOfLimits[v1.ResourceCPU])
+	assert.Equal(t, 25.0, container.PercentOfLimits[v1.ResourceMemory])
+
+	// c2 has no requests or limits
+	assert.Nil(t, p.Metrics.Containers[1].PercentOfRequests)
+
+	// only c has requests, so the usage of c2 isn't compared to them
+	assert.Equal(t, 50.0, p.Metrics.PercentOfRequests[v1.ResourceCPU])
+	assert.Equal(t, 25.0, p.Metrics.PercentOfLimits[v1.ResourceCPU])
+	cpu := p.Metrics.Usage[v1.ResourceCPU]
+	assert.Equal(t, "100m", cpu.String())
+}
+
+func TestPodMetricsMissing(t *testing.T) {
+	c := setupFakeMetricsClient(newMissingMetrics(), metricsTestPod())
+
+	p, err := c.Pod(PodOptions{
+		Logger:    &logfakes.Logger{},
+		Namespace: "one",
+		Name:      "web",
+		Context:   context.Background(),
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "web", p.Name)
+	assert.Nil(t, p.Metrics)
+}
+
+func TestNodeMetrics(t *testing.T) {
+	c := setupFakeMetricsClient(newFakeMetrics(t, metricsTestObjects()...), nodeTestObjects()...)
+
+	nodes, _, err := c.Nodes(NodeOptions{
+		Logger:  &logfakes.Logger{},
+		Context: context.Background(),
+	})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, nodes[0].Metrics)
+	assert.Equal(t, 50.0, nodes[0].Metrics.PercentOfAllocatable[v1.ResourceCPU])
+	assert.Equal(t, 47.5, nodes[0].Metrics.PercentOfCapacity[v1.ResourceCPU])
+	assert.Equal(t, 50.0, nodes[0].Metrics.PercentOfAllocatable[v1.ResourceMemory])
+	assert.Nil(t, nodes[1].Metrics)
+}
+
+func TestOverviewMetrics(t *testing.T) {
+	c := setupFakeMetricsClient(newFakeMetrics(t, metricsTestObjects()...), metricsTestPod())
+
+	o, err := c.Overview(OverviewOptions{
+		Logger:     &logfakes.Logger{},
+		Namespace:  "one",
+		LinkedName: "web",
+		Context:    context.Background(),
+	})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, o.Pods[0].Metrics)
+	assert.NotNil(t, o.Metrics)
+	assert.Equal(t, 25.0, o.Metrics.PercentOfLimits[v1.ResourceCPU])
+}
+
+func TestOverviewMetricsMissing(t *testing.T) {
+	c := setupFakeMetricsClient(newMissingMetrics(), metricsTestPod())
+
+	o, err := c.Overview(OverviewOptions{
+		Logger:     &logfakes.Logger{},
+		Namespace:  "one",
+		LinkedName: "web",
+		Context:    context.Background(),
+	})
+
+	assert.Nil(t, err)
+	assert.Len(t, o.Pods, 1)
+	assert.Nil(t, o.Metrics)
+}
+
+func TestPodMetricsPartialResources(t *testing.T) {
+	pod := &v1.Pod{
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{Name: "a", Resources: v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("100m")}}},
+				{Name: "b", Resources: v1.ResourceRequirements{Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("100Mi")}}},
+			},
+		},
+	}
+
+	m := newPodMetrics(pod, &metricsv1beta1.PodMetrics{
+		Containers: []metricsv1beta1.ContainerMetrics{
+			{Name: "a", Usage: resources("50m", "10Mi")},
+			{Name: "b", Usage: resources("30m", "50Mi")},
+		},
+	})
+
+	assert.Equal(t, map[v1.ResourceName]float64{v1.ResourceCPU: 50}, m.PercentOfRequests)
+	assert.Equal(t, map[v1.ResourceName]float64{v1.ResourceMemory: 50}, m.PercentOfLimits)
+
+	sum := sumResourceUsage([]*PodMetrics{m, m})
+
+	assert.Equal(t, map[v1.ResourceName]float64{v1.ResourceCPU: 50}, sum.PercentOfRequests)
+	assert.Equal(t, map[v1.ResourceName]float64{v1.ResourceMemory: 50}, sum.PercentOfLimits)
+}
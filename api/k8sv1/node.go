@@ -0,0 +1,262 @@
+package k8sv1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kubelens/kubelens/api/errs"
+	klog "github.com/kubelens/kubelens/api/log"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+// NodeOptions contains fields used for filtering when retrieving nodes
+type NodeOptions struct {
+	// the name of the node
+	Name string `json:"name"`
+	// selectors and pagination narrowing a list
+	ListOptions
+	// logger instance
+	Logger klog.Logger
+	// Context .
+	Context context.Context
+}
+
+// NodeOverview .
+type NodeOverview struct {
+	// the name
+	Name string `json:"name"`
+	// the version of the kubelet running on the node
+	KubeletVersion string `json:"kubeletVersion"`
+	// true if new pods can't be scheduled on the node, e.g. when cordoned
+	Unschedulable bool `json:"unschedulable"`
+	// the total resources of the node
+	Capacity v1.ResourceList `json:"capacity,omitempty"`
+	// the resources of the node available to pods
+	Allocatable v1.ResourceList `json:"allocatable,omitempty"`
+	// the summed requests of the pods on the node
+	Requests v1.ResourceList `json:"requests,omitempty"`
+	// the summed limits of the pods on the node
+	Limits v1.ResourceList `json:"limits,omitempty"`
+	// Ready, MemoryPressure, DiskPressure, etc.
+	Conditions []v1.NodeCondition `json:"conditions,omitempty"`
+	// the taints of the node
+	Taints []v1.Taint `json:"taints,omitempty"`
+	// current resource usage, set when metrics-server is installed
+	Metrics *NodeMetrics `json:"metrics,omitempty"`
+	// the pods scheduled on the node that haven't terminated
+	Pods []NodePod `json:"pods"`
+	// the number of fields redacted, see config.Redaction
+	Redacted int `json:"redacted,omitempty"`
+	// the full node
+	Node *v1.Node `json:"node,omitempty"`
+}
+
+// NodePod is a pod scheduled on a node.
+type NodePod struct {
+	// the name of the pod
+	Name string `json:"name"`
+	// the value of the first link label the object has, e.g. "app=NAME", see config.LinkKeys
+	LinkedName string `json:"linkedName"`
+	// the namespace of the pod
+	Namespace string `json:"namespace"`
+	// the phase of the pod
+	Phase v1.PodPhase `json:"phase"`
+	// the effective requests of the pod
+	Requests v1.ResourceList `json:"requests,omitempty"`
+	// the effective limits of the pod
+	Limits v1.ResourceList `json:"limits,omitempty"`
+}
+
+// Node returns a node given filter options
+func (k *Client) Node(options NodeOptions) (overview *NodeOverview, apiErr *errs.APIError) {
+	list, err := k.listNodes(options.Context, metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("metadata.name=%s", options.Name),
+	})
+
+	if err != nil {
+		klog.Trace()
+		return nil, errs.InternalServerError(err.Error())
+	}
+
+	if list != nil && len(list.Items) > 0 {
+		pods, err := k.listPods(options.Context, v1.NamespaceAll, metav1.ListOptions{
+			FieldSelector: fmt.Sprintf("spec.nodeName=%s", list.Items[0].Name),
+		})
+
+		if err != nil {
+			klog.Trace()
+			return nil, errs.InternalServerError(err.Error())
+		}
+
+		metrics := k.listNodeMetrics(options.Context, metav1.ListOptions{
+			FieldSelector: fmt.Sprintf("metadata.name=%s", list.Items[0].Name),
+		})
+
+		for _, item := range list.Items {
+			return newNodeOverview(&item, pods.Items, metrics[item.Name]), nil
+		}
+	}
+	return overview, nil
+}
+
+// Nodes returns a list of nodes given filter options
+func (k *Client) Nodes(options NodeOptions) (overviews []NodeOverview, meta ListMeta, apiErr *errs.APIError) {
+	overviews = []NodeOverview{}
+
+	lo, apiErr := options.listOptions(nodeFields(&v1.Node{}))
+
+	if apiErr != nil {
+		return nil, meta, apiErr
+	}
+
+	obj, err := listLinked("", lo, func(lo metav1.ListOptions) (runtime.Object, error) {
+		return k.listNodes(options.Context, lo)
+	})
+
+	if err != nil {
+		klog.Trace()
+		return nil, meta, errs.InternalServerError(err.Error())
+	}
+
+	list := obj.(*v1.NodeList)
+
+	if list != nil && len(list.Items) > 0 {
+		pods, err := k.listPods(options.Context, v1.NamespaceAll, metav1.ListOptions{})
+
+		if err != nil {
+			klog.Trace()
+			return nil, meta, errs.InternalServerError(err.Error())
+		}
+
+		metrics := k.listNodeMetrics(options.Context, metav1.ListOptions{})
+
+		for _, item := range list.Items {
+			// the overview keeps a pointer to item
+			item := item
+
+			overviews = append(overviews, *newNodeOverview(&item, pods.Items, metrics[item.Name]))
+		}
+	}
+	return overviews, newListMeta(list.ListMeta), nil
+}
+
+func newNodeOverview(node *v1.Node, pods []v1.Pod, metrics *metricsv1beta1.NodeMetrics) *NodeOverview {
+	overview := &NodeOverview{
+		Name:           node.Name,
+		KubeletVersion: node.Status.NodeInfo.KubeletVersion,
+		Unschedulable:  node.Spec.Unschedulable,
+		Capacity:       node.Status.Capacity,
+		Allocatable:    node.Status.Allocatable,
+		Requests:       v1.ResourceList{},
+		Limits:         v1.ResourceList{},
+		Conditions:     node.Status.Conditions,
+		Taints:         node.Spec.Taints,
+		Pods:           []NodePod{},
+		Redacted:       redact(node),
+		Node:           node,
+	}
+
+	if metrics != nil {
+		overview.Metrics = newNodeMetrics(node, metrics)
+	}
+
+	for _, pod := range pods {
+		// terminated pods no longer hold resources on the node
+		if pod.Spec.NodeName != node.Name || pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+			continue
+		}
+
+		requests, limits := podRequestsAndLimits(&pod)
+
+		addResources(overview.Requests, requests)
+		addResources(overview.Limits, limits)
+
+		overview.Pods = append(overview.Pods, NodePod{
+			Name:       pod.Name,
+			LinkedName: getLinkedName(pod.Namespace, pod.Labels),
+			Namespace:  pod.Namespace,
+			Phase:      pod.Status.Phase,
+			Requests:   requests,
+			Limits:     limits,
+		})
+	}
+
+	return overview
+}
+
+// podRequestsAndLimits returns the effective requests and limits of a pod the way the
+// scheduler sees them, the larger of the summed containers or any single init container,
+// plus the pod overhead.
+func podRequestsAndLimits(pod *v1.Pod) (requests, limits v1.ResourceList) {
+	requests, limits = v1.ResourceList{}, v1.ResourceList{}
+
+	for _, c := range pod.Spec.Containers {
+		addResources(requests, c.Resources.Requests)
+		addResources(limits, c.Resources.Limits)
+	}
+
+	for _, c := range pod.Spec.InitContainers {
+		maxResources(requests, c.Resources.Requests)
+		maxResources(limits, c.Resources.Limits)
+	}
+
+	if pod.Spec.Overhead != nil {
+		addResources(requests, pod.Spec.Overhead)
+		// only add the overhead to limits that are set
+		for name, quantity := range pod.Spec.Overhead {
+			if value, ok := limits[name]; ok {
+				value.Add(quantity)
+				limits[name] = value
+			}
+		}
+	}
+
+	return requests, limits
+}
+
+// addResources adds each resource in add to list.
+func addResources(list, add v1.ResourceList) {
+	for name, quantity := range add {
+		if value, ok := list[name]; ok {
+			value.Add(quantity)
+			list[name] = value
+		} else {
+			list[name] = quantity.DeepCopy()
+		}
+	}
+}
+
+// maxResources sets each resource in list to the larger of list and other.
+func maxResources(list, other v1.ResourceList) {
+	for name, quantity := range other {
+		if value, ok := list[name]; !ok || quantity.Cmp(value) > 0 {
+			list[name] = quantity.DeepCopy()
+		}
+	}
+}
+
+// nodeFields returns the fields a node can be selected on.
+func nodeFields(node *v1.Node) fields.Set {
+	set := objectMetaFields(node.ObjectMeta)
+	set["spec.unschedulable"] = fmt.Sprint(node.Spec.Unschedulable)
+	return set
+}
+
+// listNodes lists nodes from the cache when it has synced, otherwise from the api server.
+func (k *Client) listNodes(ctx context.Context, lo metav1.ListOptions) (*v1.NodeList, error) {
+	if k.cache.hasSynced(kindNodes) {
+		return k.cache.listNodes(lo)
+	}
+
+	clientset, err := k.wrapper.GetClientSet()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return clientset.CoreV1().Nodes().List(ctx, lo)
+}
@@ -0,0 +1,146 @@
+package k8sv1
+
+import (
+	"context"
+	"testing"
+
+	logfakes "github.com/kubelens/kubelens/api/log/fakes"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func resources(cpu, memory string) v1.ResourceList {
+	return v1.ResourceList{
+		v1.ResourceCPU:    resource.MustParse(cpu),
+		v1.ResourceMemory: resource.MustParse(memory),
+	}
+}
+
+func nodeTestObjects() []runtime.Object {
+	container := func(req, lim v1.ResourceList) v1.Container {
+		return v1.Container{Name: "c", Resources: v1.ResourceRequirements{Requests: req, Limits: lim}}
+	}
+
+	return []runtime.Object{
+		&v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+			Spec: v1.NodeSpec{
+				Unschedulable: true,
+				Taints:        []v1.Taint{{Key: "dedicated", Value: "db", Effect: v1.TaintEffectNoSchedule}},
+			},
+			Status: v1.NodeStatus{
+				Capacity:    resources("4", "8Gi"),
+				Allocatable: resources("3800m", "7Gi"),
+				Conditions:  []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionTrue}},
+				NodeInfo:    v1.NodeSystemInfo{KubeletVersion: "v1.21.2"},
+			},
+		},
+		&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-2"}},
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "one", Labels: map[string]string{"app": "web"}},
+			Spec: v1.PodSpec{
+				NodeName: "node-1",
+				Containers: []v1.Container{
+					container(resources("100m", "128Mi"), resources("200m", "256Mi")),
+					container(resources("100m", "128Mi"), nil),
+				},
+				// larger than the summed containers
+				InitContainers: []v1.Container{container(resources("500m", "64Mi"), nil)},
+				Overhead:       v1.ResourceList{v1.ResourceCPU: resource.MustParse("10m")},
+			},
+			Status: v1.PodStatus{Phase: v1.PodRunning},
+		},
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "two"},
+			Spec: v1.PodSpec{
+				NodeName:   "node-1",
+				Containers: []v1.Container{container(resources("1", "1Gi"), resources("1", "1Gi"))},
+			},
+			Status: v1.PodStatus{Phase: v1.PodPending},
+		},
+		// terminated
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "done", Namespace: "one"},
+			Spec:       v1.PodSpec{NodeName: "node-1", Containers: []v1.Container{container(resources("1", "1Gi"), nil)}},
+			Status:     v1.PodStatus{Phase: v1.PodSucceeded},
+		},
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "elsewhere", Namespace: "one"},
+			Spec:       v1.PodSpec{NodeName: "node-2", Containers: []v1.Container{container(resources("1", "1Gi"), nil)}},
+		},
+	}
+}
+
+func TestNodesSuccess(t *testing.T) {
+	c := setupFakeClient(nodeTestObjects()...)
+
+	nodes, _, err := c.Nodes(NodeOptions{
+		Logger:  &logfakes.Logger{},
+		Context: context.Background(),
+	})
+
+	assert.Nil(t, err)
+	assert.Len(t, nodes, 2)
+
+	n := nodes[0]
+	assert.Equal(t, "node-1", n.Name)
+	assert.Equal(t, "v1.21.2", n.KubeletVersion)
+	assert.True(t, n.Unschedulable)
+	assert.Len(t, n.Taints, 1)
+	assert.Len(t, n.Conditions, 1)
+	assert.Len(t, n.Pods, 2)
+	assert.Equal(t, "web", n.Pods[0].LinkedName)
+
+	// web: max(200m, 500m) + 10m overhead, db: 1
+	cpu := n.Requests[v1.ResourceCPU]
+	assert.Equal(t, "1510m", cpu.String())
+	memory := n.Requests[v1.ResourceMemory]
+	assert.Equal(t, "1280Mi", memory.String())
+
+	// the overhead isn't added to memory, which has no limit on web
+	cpu = n.Limits[v1.ResourceCPU]
+	assert.Equal(t, "1210m", cpu.String())
+
+	assert.Len(t, nodes[1].Pods, 1)
+}
+
+func TestNodeCached(t *testing.T) {
+	c := setupCachedClient(t, nodeTestObjects()...)
+
+	n, err := c.Node(NodeOptions{
+		Logger:  &logfakes.Logger{},
+		Name:    "node-2",
+		Context: context.Background(),
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "node-2", n.Name)
+	assert.Len(t, n.Pods, 1)
+	assert.Equal(t, "elsewhere", n.Pods[0].Name)
+}
+
+func TestNodesFail(t *testing.T) {
+	c := setupClient("testns", "nodetest1", true, true)
+
+	_, _, err := c.Nodes(NodeOptions{
+		Logger:  &logfakes.Logger{},
+		Context: context.Background(),
+	})
+
+	assert.NotNil(t, err)
+}
+
+func TestNodeFail(t *testing.T) {
+	c := setupClient("testns", "nodetest2", true, true)
+
+	_, err := c.Node(NodeOptions{
+		Logger:  &logfakes.Logger{},
+		Name:    "nodetest2",
+		Context: context.Background(),
+	})
+
+	assert.NotNil(t, err)
+}
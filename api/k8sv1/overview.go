@@ -2,6 +2,10 @@ package k8sv1
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
 	"strings"
 	"sync"
 
@@ -10,71 +14,97 @@ import (
 	klog "github.com/kubelens/kubelens/api/log"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
 )
 
+// labelPartOf is the recommended label naming the higher-level application, or system,
+// an application is part of.
+const labelPartOf = "app.kubernetes.io/part-of"
+
 // OverviewOptions contains fields used for filtering when retrieving application overiew(s).
 type OverviewOptions struct {
-	// the value from the label "app=NAME", corresponds to config.LabelKeyLink
+	// the value of the first link label the object has, e.g. "app=NAME", see config.LinkKeys
 	LinkedName string `json:"linkedName"`
 	// namespace to filter on
 	Namespace string `json:"namespace"`
+	// the maximum number of overviews to return, 0 returns every overview
+	Limit int64 `json:"limit"`
+	// the continue token of the previous page
+	Continue string `json:"continue"`
 	// logger instance
 	Logger klog.Logger
 	// Context .
 	Context context.Context
 }
 
+// overviewContinue is the continue token of a page of overviews, the last overview of the page.
+type overviewContinue struct {
+	Namespace  string `json:"ns"`
+	LinkedName string `json:"n"`
+}
+
 type Overview struct {
-	LinkedName  string               `json:"linkedName,omitempty"`
-	Namespace   string               `json:"namespace,omitempty"`
-	DaemonSets  []DaemonSetOverview  `json:"daemonSets,omitempty"`
-	Deployments []DeploymentOverview `json:"deployments,omitempty"`
-	Jobs        []JobOverview        `json:"jobs,omitempty"`
-	Pods        []PodOverview        `json:"pods,omitempty"`
-	ReplicaSets []ReplicaSetOverview `json:"replicaSets,omitempty"`
-	Services    []ServiceOverview    `json:"services,omitempty"`
-	ConfigMaps  []ConfigMapOverview  `json:"configMaps,omitempty"`
+	LinkedName   string                `json:"linkedName,omitempty"`
+	Namespace    string                `json:"namespace,omitempty"`
+	PartOf       string                `json:"partOf,omitempty"`
+	DaemonSets   []DaemonSetOverview   `json:"daemonSets,omitempty"`
+	Deployments  []DeploymentOverview  `json:"deployments,omitempty"`
+	Jobs         []JobOverview         `json:"jobs,omitempty"`
+	Pods         []PodOverview         `json:"pods,omitempty"`
+	ReplicaSets  []ReplicaSetOverview  `json:"replicaSets,omitempty"`
+	Services     []ServiceOverview     `json:"services,omitempty"`
+	ConfigMaps   []ConfigMapOverview   `json:"configMaps,omitempty"`
+	StatefulSets []StatefulSetOverview `json:"statefulSets,omitempty"`
+	CronJobs     []CronJobOverview     `json:"cronJobs,omitempty"`
+	// events about any of the objects above, most recent first
+	Events []EventOverview `json:"events,omitempty"`
+	// the summed resource usage of the pods, set when metrics-server is installed
+	Metrics *ResourceUsage `json:"metrics,omitempty"`
+	// the rolled up health of the objects above
+	Health *Health `json:"health,omitempty"`
+	// the diagnoses of pods with problems
+	Diagnoses []Diagnosis `json:"diagnoses,omitempty"`
 }
 
 // Overview returns a Overview given filter options
 func (k *Client) Overview(options OverviewOptions) (overview *Overview, apiErr *errs.APIError) {
 	// DaemonSets
-	dss, _ := k.DaemonSets(DaemonSetOptions{
+	dss, _, _ := k.DaemonSets(DaemonSetOptions{
 		Namespace:  options.Namespace,
 		LinkedName: options.LinkedName,
 		Logger:     options.Logger,
 		Context:    options.Context,
 	})
 	// Deployments
-	dps, _ := k.Deployments(DeploymentOptions{
+	dps, _, _ := k.Deployments(DeploymentOptions{
 		Namespace:  options.Namespace,
 		LinkedName: options.LinkedName,
 		Logger:     options.Logger,
 		Context:    options.Context,
 	})
 	// Jobs
-	jbs, _ := k.Jobs(JobOptions{
+	jbs, _, _ := k.Jobs(JobOptions{
 		Namespace:  options.Namespace,
 		LinkedName: options.LinkedName,
 		Logger:     options.Logger,
 		Context:    options.Context,
 	})
 	// Pods
-	povs, _ := k.Pods(PodOptions{
+	povs, _, _ := k.Pods(PodOptions{
 		Namespace:  options.Namespace,
 		LinkedName: options.LinkedName,
 		Logger:     options.Logger,
 		Context:    options.Context,
 	})
 	// ReplicaSets
-	rss, _ := k.ReplicaSets(ReplicaSetOptions{
+	rss, _, _ := k.ReplicaSets(ReplicaSetOptions{
 		Namespace:  options.Namespace,
 		LinkedName: options.LinkedName,
 		Logger:     options.Logger,
 		Context:    options.Context,
 	})
 	// Services
-	svcs, _ := k.Services(ServiceOptions{
+	svcs, _, _ := k.Services(ServiceOptions{
 		Namespace:  options.Namespace,
 		LinkedName: options.LinkedName,
 		Logger:     options.Logger,
@@ -82,7 +112,23 @@ func (k *Client) Overview(options OverviewOptions) (overview *Overview, apiErr *
 	})
 
 	// ConfigMaps
-	cms, _ := k.ConfigMaps(ConfigMapOptions{
+	cms, _, _ := k.ConfigMaps(ConfigMapOptions{
+		Namespace:  options.Namespace,
+		LinkedName: options.LinkedName,
+		Logger:     options.Logger,
+		Context:    options.Context,
+	})
+
+	// StatefulSets
+	sts, _, _ := k.StatefulSets(StatefulSetOptions{
+		Namespace:  options.Namespace,
+		LinkedName: options.LinkedName,
+		Logger:     options.Logger,
+		Context:    options.Context,
+	})
+
+	// CronJobs
+	cjs, _, _ := k.CronJobs(CronJobOptions{
 		Namespace:  options.Namespace,
 		LinkedName: options.LinkedName,
 		Logger:     options.Logger,
@@ -90,34 +136,146 @@ func (k *Client) Overview(options OverviewOptions) (overview *Overview, apiErr *
 	})
 
 	overview = &Overview{
-		LinkedName:  options.LinkedName,
-		Namespace:   options.Namespace,
-		DaemonSets:  dss,
-		Deployments: dps,
-		Jobs:        jbs,
-		Pods:        povs,
-		ReplicaSets: rss,
-		Services:    svcs,
-		ConfigMaps:  cms,
+		LinkedName:   options.LinkedName,
+		Namespace:    options.Namespace,
+		DaemonSets:   dss,
+		Deployments:  dps,
+		Jobs:         jbs,
+		Pods:         povs,
+		ReplicaSets:  rss,
+		Services:     svcs,
+		ConfigMaps:   cms,
+		StatefulSets: sts,
+		CronJobs:     cjs,
+	}
+
+	overview.PartOf = overview.partOf()
+
+	k.attachEvents(options, overview)
+	k.attachMetrics(options, overview)
+
+	overview.Health = overview.health()
+
+	for _, pov := range overview.Pods {
+		if pov.Pod == nil {
+			continue
+		}
+		if d := diagnosePod(pov.Pod, pov.Events); len(d.Findings) > 0 {
+			overview.Diagnoses = append(overview.Diagnoses, *d)
+		}
 	}
 
 	return overview, nil
 }
 
-// Pods returns a list ofPods given filter options
-func (k *Client) Overviews(options OverviewOptions) (overviews []Overview, apiErr *errs.APIError) {
-	clientset, err := k.wrapper.GetClientSet()
+// attachEvents lists the events in the namespace once and attaches them to the
+// pods and deployments of the overview, and every event about one of its objects
+// to the overview itself.
+func (k *Client) attachEvents(options OverviewOptions, overview *Overview) {
+	events, _, apiErr := k.Events(EventOptions{
+		Namespace: options.Namespace,
+		Logger:    options.Logger,
+		Context:   options.Context,
+	})
+
+	if apiErr != nil {
+		return
+	}
+
+	type object struct{ kind, namespace, name string }
+
+	objects := map[object]bool{}
+
+	for i, o := range overview.Pods {
+		overview.Pods[i].Events = eventsFor(events, "Pod", o.Namespace, o.Name)
+		objects[object{"Pod", o.Namespace, o.Name}] = true
+	}
+	for i, o := range overview.Deployments {
+		overview.Deployments[i].Events = eventsFor(events, "Deployment", o.Namespace, o.Name)
+		objects[object{"Deployment", o.Namespace, o.Name}] = true
+	}
+	for _, o := range overview.DaemonSets {
+		objects[object{"DaemonSet", o.Namespace, o.Name}] = true
+	}
+	for _, o := range overview.Jobs {
+		objects[object{"Job", o.Namespace, o.Name}] = true
+	}
+	for _, o := range overview.ReplicaSets {
+		objects[object{"ReplicaSet", o.Namespace, o.Name}] = true
+	}
+	for _, o := range overview.Services {
+		objects[object{"Service", o.Namespace, o.Name}] = true
+	}
+	for _, o := range overview.ConfigMaps {
+		objects[object{"ConfigMap", o.Namespace, o.Name}] = true
+	}
+	for _, o := range overview.StatefulSets {
+		objects[object{"StatefulSet", o.Namespace, o.Name}] = true
+	}
+	for _, o := range overview.CronJobs {
+		objects[object{"CronJob", o.Namespace, o.Name}] = true
+	}
+
+	for _, e := range events {
+		if objects[object{e.Kind, e.Namespace, e.Name}] {
+			overview.Events = append(overview.Events, e)
+		}
+	}
+}
+
+// attachMetrics lists the metrics of the pods once and attaches them to each pod,
+// and the sum to the overview itself.
+func (k *Client) attachMetrics(options OverviewOptions, overview *Overview) {
+	if len(overview.Pods) == 0 {
+		return
+	}
+
+	metrics := map[string]*metricsv1beta1.PodMetrics{}
+
+	for _, selector := range generateLabelSelectors(options.LinkedName) {
+		for key, m := range k.listPodMetrics(options.Context, options.Namespace, metav1.ListOptions{LabelSelector: selector}) {
+			metrics[key] = m
+		}
+	}
+
+	pods := []*PodMetrics{}
+
+	for i, pov := range overview.Pods {
+		if m, ok := metrics[pov.Namespace+"/"+pov.Name]; ok && pov.Pod != nil {
+			overview.Pods[i].Metrics = newPodMetrics(pov.Pod, m)
+			pods = append(pods, overview.Pods[i].Metrics)
+		}
+	}
+
+	overview.Metrics = sumResourceUsage(pods)
+}
+
+// Overviews returns an overview of every linked name in every namespace, sorted by namespace then
+// linked name. A page of overviews only builds the overviews of the namespaces it starts from on.
+func (k *Client) Overviews(options OverviewOptions) (overviews []Overview, meta ListMeta, apiErr *errs.APIError) {
+	if options.Limit < 0 {
+		return nil, meta, errs.ValidationError("limit can't be negative")
+	}
+
+	after, err := decodeOverviewContinue(options.Continue)
 
 	if err != nil {
-		klog.Trace()
-		return nil, errs.InternalServerError(err.Error())
+		return nil, meta, errs.ValidationError(err.Error())
 	}
 
-	namespaces, err := clientset.CoreV1().Namespaces().List(options.Context, metav1.ListOptions{})
+	list, err := k.listNamespaces(options.Context, metav1.ListOptions{})
 
 	if err != nil {
 		klog.Trace()
-		return nil, errs.InternalServerError(err.Error())
+		return nil, meta, errs.InternalServerError(err.Error())
+	}
+
+	namespaces := v1.NamespaceList{}
+
+	for _, ns := range list.Items {
+		if ns.Name >= after.Namespace {
+			namespaces.Items = append(namespaces.Items, ns)
+		}
 	}
 
 	wg := sync.WaitGroup{}
@@ -131,9 +289,24 @@ func (k *Client) Overviews(options OverviewOptions) (overviews []Overview, apiEr
 
 		go func(index int, ns v1.Namespace) {
 			defer wg.Done()
+			// Deployments
+			dps, _, _ := k.Deployments(DeploymentOptions{
+				Namespace: ns.Name,
+				Logger:    options.Logger,
+				Context:   options.Context,
+			})
+
+			for _, dp := range dps {
+				nsOverviews[index] = append(nsOverviews[index], Overview{
+					LinkedName:  dp.LinkedName,
+					Namespace:   dp.Namespace,
+					Deployments: []DeploymentOverview{dp},
+				})
+			}
+
 			// DaemonSets
-			dss, _ := k.DaemonSets(DaemonSetOptions{
-				Namespace: ns.Namespace,
+			dss, _, _ := k.DaemonSets(DaemonSetOptions{
+				Namespace: ns.Name,
 				Logger:    options.Logger,
 				Context:   options.Context,
 			})
@@ -142,12 +315,13 @@ func (k *Client) Overviews(options OverviewOptions) (overviews []Overview, apiEr
 				nsOverviews[index] = append(nsOverviews[index], Overview{
 					LinkedName: ds.LinkedName,
 					Namespace:  ds.Namespace,
+					DaemonSets: []DaemonSetOverview{ds},
 				})
 			}
 
 			// Jobs
-			jbs, _ := k.Jobs(JobOptions{
-				Namespace: ns.Namespace,
+			jbs, _, _ := k.Jobs(JobOptions{
+				Namespace: ns.Name,
 				Logger:    options.Logger,
 				Context:   options.Context,
 			})
@@ -156,12 +330,43 @@ func (k *Client) Overviews(options OverviewOptions) (overviews []Overview, apiEr
 				nsOverviews[index] = append(nsOverviews[index], Overview{
 					LinkedName: jb.LinkedName,
 					Namespace:  jb.Namespace,
+					Jobs:       []JobOverview{jb},
+				})
+			}
+
+			// StatefulSets
+			sts, _, _ := k.StatefulSets(StatefulSetOptions{
+				Namespace: ns.Name,
+				Logger:    options.Logger,
+				Context:   options.Context,
+			})
+
+			for _, st := range sts {
+				nsOverviews[index] = append(nsOverviews[index], Overview{
+					LinkedName:   st.LinkedName,
+					Namespace:    st.Namespace,
+					StatefulSets: []StatefulSetOverview{st},
+				})
+			}
+
+			// CronJobs
+			cjs, _, _ := k.CronJobs(CronJobOptions{
+				Namespace: ns.Name,
+				Logger:    options.Logger,
+				Context:   options.Context,
+			})
+
+			for _, cj := range cjs {
+				nsOverviews[index] = append(nsOverviews[index], Overview{
+					LinkedName: cj.LinkedName,
+					Namespace:  cj.Namespace,
+					CronJobs:   []CronJobOverview{cj},
 				})
 			}
 
 			// Pods
-			povs, _ := k.Pods(PodOptions{
-				Namespace: ns.Namespace,
+			povs, _, _ := k.Pods(PodOptions{
+				Namespace: ns.Name,
 				Logger:    options.Logger,
 				Context:   options.Context,
 			})
@@ -170,6 +375,7 @@ func (k *Client) Overviews(options OverviewOptions) (overviews []Overview, apiEr
 				nsOverviews[index] = append(nsOverviews[index], Overview{
 					LinkedName: pov.LinkedName,
 					Namespace:  pov.Namespace,
+					Pods:       []PodOverview{pov},
 				})
 			}
 		}(i, namespace)
@@ -177,27 +383,206 @@ func (k *Client) Overviews(options OverviewOptions) (overviews []Overview, apiEr
 
 	wg.Wait()
 
-	overviews = []Overview{}
+	// group the objects by linked name, so health leaves out what it leaves out of a single overview
+	grouped := []*Overview{}
 
 	for _, nsovs := range nsOverviews {
 		for _, nsov := range nsovs {
-			if len(nsov.LinkedName) > 0 {
-				found := false
-				for _, ov := range overviews {
-					if strings.EqualFold(nsov.LinkedName, ov.LinkedName) && strings.EqualFold(nsov.Namespace, ov.Namespace) {
-						found = true
-						break
-					}
-				}
-				if !found {
-					overviews = append(overviews, Overview{
-						LinkedName: nsov.LinkedName,
-						Namespace:  nsov.Namespace,
-					})
+			if len(nsov.LinkedName) == 0 {
+				continue
+			}
+
+			var group *Overview
+			for _, ov := range grouped {
+				if strings.EqualFold(nsov.LinkedName, ov.LinkedName) && strings.EqualFold(nsov.Namespace, ov.Namespace) {
+					group = ov
+					break
 				}
 			}
+			if group == nil {
+				group = &Overview{LinkedName: nsov.LinkedName, Namespace: nsov.Namespace}
+				grouped = append(grouped, group)
+			}
+
+			group.Deployments = append(group.Deployments, nsov.Deployments...)
+			group.DaemonSets = append(group.DaemonSets, nsov.DaemonSets...)
+			group.Jobs = append(group.Jobs, nsov.Jobs...)
+			group.StatefulSets = append(group.StatefulSets, nsov.StatefulSets...)
+			group.CronJobs = append(group.CronJobs, nsov.CronJobs...)
+			group.Pods = append(group.Pods, nsov.Pods...)
 		}
 	}
 
-	return overviews, nil
+	overviews = []Overview{}
+
+	for _, ov := range grouped {
+		overviews = append(overviews, Overview{
+			LinkedName: ov.LinkedName,
+			Namespace:  ov.Namespace,
+			PartOf:     ov.partOf(),
+			Health:     ov.health(),
+		})
+	}
+
+	return pageOverviews(overviews, after, options.Limit)
+}
+
+// pageOverviews sorts overviews and narrows them to limit overviews after the one in after.
+func pageOverviews(overviews []Overview, after overviewContinue, limit int64) ([]Overview, ListMeta, *errs.APIError) {
+	meta := ListMeta{}
+
+	key := func(o Overview) overviewContinue {
+		return overviewContinue{Namespace: o.Namespace, LinkedName: strings.ToLower(o.LinkedName)}
+	}
+
+	less := func(a, b overviewContinue) bool {
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.LinkedName < b.LinkedName
+	}
+
+	sort.Slice(overviews, func(i, j int) bool { return less(key(overviews[i]), key(overviews[j])) })
+
+	start := 0
+
+	if len(after.Namespace) > 0 {
+		start = sort.Search(len(overviews), func(i int) bool { return less(after, key(overviews[i])) })
+	}
+
+	end := len(overviews)
+	if limit > 0 && int64(end-start) > limit {
+		end = start + int(limit)
+	}
+
+	if end < len(overviews) {
+		b, _ := json.Marshal(key(overviews[end-1]))
+		remaining := int64(len(overviews) - end)
+
+		meta.Continue = base64.RawURLEncoding.EncodeToString(b)
+		meta.RemainingItemCount = &remaining
+	}
+
+	return overviews[start:end], meta, nil
+}
+
+// decodeOverviewContinue returns the last overview of the previous page, an empty continue
+// starts at the beginning.
+func decodeOverviewContinue(s string) (after overviewContinue, err error) {
+	if len(s) == 0 {
+		return after, nil
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(s)
+
+	if err == nil {
+		err = json.Unmarshal(b, &after)
+	}
+
+	if err != nil || len(after.Namespace) == 0 {
+		return after, fmt.Errorf("invalid continue token")
+	}
+
+	return after, nil
+}
+
+// partOf returns the first app.kubernetes.io/part-of label set on the objects of the overview.
+func (o *Overview) partOf() string {
+	labels := []map[string]string{}
+
+	for _, d := range o.Deployments {
+		if d.Deployment != nil {
+			labels = append(labels, d.Deployment.Labels)
+		}
+	}
+	for _, ds := range o.DaemonSets {
+		if ds.DaemonSet != nil {
+			labels = append(labels, ds.DaemonSet.Labels)
+		}
+	}
+	for _, sts := range o.StatefulSets {
+		if sts.StatefulSet != nil {
+			labels = append(labels, sts.StatefulSet.Labels)
+		}
+	}
+	for _, cj := range o.CronJobs {
+		if cj.CronJob != nil {
+			labels = append(labels, cj.CronJob.Labels)
+		}
+	}
+	for _, j := range o.Jobs {
+		if j.Job != nil {
+			labels = append(labels, j.Job.Labels)
+		}
+	}
+	for _, s := range o.Services {
+		if s.Service != nil {
+			labels = append(labels, s.Service.Labels)
+		}
+	}
+	for _, p := range o.Pods {
+		if p.Pod != nil {
+			labels = append(labels, p.Pod.Labels)
+		}
+	}
+
+	for _, l := range labels {
+		if v := l[labelPartOf]; len(v) > 0 {
+			return v
+		}
+	}
+
+	return ""
+}
+
+// System is a group of application overviews sharing the app.kubernetes.io/part-of label.
+type System struct {
+	// the value of the app.kubernetes.io/part-of label, empty for an application that isn't part of a system
+	Name      string `json:"name,omitempty"`
+	Namespace string `json:"namespace"`
+	// the rolled up health of the applications
+	Health    *Health    `json:"health,omitempty"`
+	Overviews []Overview `json:"overviews"`
+}
+
+// GroupByPartOf groups overviews into systems by namespace and app.kubernetes.io/part-of label,
+// keeping the order overviews are first seen in. Each application without the label is a system of its own.
+func GroupByPartOf(overviews []Overview) []System {
+	systems := []System{}
+	index := map[string]int{}
+
+	for _, ov := range overviews {
+		key := ov.Namespace + "/" + ov.PartOf
+
+		if i, ok := index[key]; ok && len(ov.PartOf) > 0 {
+			systems[i].Overviews = append(systems[i].Overviews, ov)
+			systems[i].Health = mergeHealth(systems[i].Health, ov.Health)
+			continue
+		}
+
+		index[key] = len(systems)
+		systems = append(systems, System{
+			Name:      ov.PartOf,
+			Namespace: ov.Namespace,
+			Health:    ov.Health,
+			Overviews: []Overview{ov},
+		})
+	}
+
+	return systems
+}
+
+// listNamespaces lists namespaces from the cache when it has synced, otherwise from the api server.
+func (k *Client) listNamespaces(ctx context.Context, lo metav1.ListOptions) (*v1.NamespaceList, error) {
+	if k.cache.hasSynced(kindNamespaces) {
+		return k.cache.listNamespaces(lo)
+	}
+
+	clientset, err := k.wrapper.GetClientSet()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return clientset.CoreV1().Namespaces().List(ctx, lo)
 }
@@ -6,12 +6,15 @@ import (
 
 	logfakes "github.com/kubelens/kubelens/api/log/fakes"
 	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestOverviewsSuccess(t *testing.T) {
 	c := setupClient("testns", "ov1", false, false)
 
-	d, err := c.Overviews(OverviewOptions{
+	d, _, err := c.Overviews(OverviewOptions{
 		Logger:  &logfakes.Logger{},
 		Context: context.Background(),
 	})
@@ -34,3 +37,120 @@ func TestOverviewSuccess(t *testing.T) {
 	assert.Nil(t, err)
 	assert.NotNil(t, d)
 }
+
+func TestOverviewsPartOf(t *testing.T) {
+	c := setupCachedClient(t,
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop"}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "cart", Namespace: "shop", Labels: map[string]string{"app": "cart", labelPartOf: "store"}}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "checkout", Namespace: "shop", Labels: map[string]string{"app": "checkout"}}},
+		&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "checkout-db", Namespace: "shop", Labels: map[string]string{"app": "checkout", labelPartOf: "store"}}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "blog", Namespace: "shop", Labels: map[string]string{"app": "blog"}}},
+	)
+
+	d, _, err := c.Overviews(OverviewOptions{
+		Logger:  &logfakes.Logger{},
+		Context: context.Background(),
+	})
+
+	assert.Nil(t, err)
+	assert.Len(t, d, 3)
+
+	partOf := map[string]string{}
+	for _, ov := range d {
+		partOf[ov.LinkedName] = ov.PartOf
+	}
+
+	assert.Equal(t, map[string]string{"blog": "", "cart": "store", "checkout": "store"}, partOf)
+
+	systems := GroupByPartOf(d)
+
+	assert.Len(t, systems, 2)
+	for _, s := range systems {
+		if s.Name == "store" {
+			assert.Len(t, s.Overviews, 2)
+		} else {
+			assert.Equal(t, "blog", s.Overviews[0].LinkedName)
+		}
+	}
+}
+
+func TestOverviewsPages(t *testing.T) {
+	c := setupCachedClient(t,
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "one"}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "two"}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "one", Labels: map[string]string{"app": "web"}}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "one", Labels: map[string]string{"app": "api"}}},
+		&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "api-db", Namespace: "one", Labels: map[string]string{"app": "api"}}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "cache", Namespace: "two", Labels: map[string]string{"app": "cache"}}},
+	)
+
+	names := []string{}
+	options := OverviewOptions{
+		Logger:  &logfakes.Logger{},
+		Context: context.Background(),
+		Limit:   2,
+	}
+
+	d, meta, err := c.Overviews(options)
+
+	assert.Nil(t, err)
+	assert.Len(t, d, 2)
+	assert.NotEmpty(t, meta.Continue)
+	assert.Equal(t, int64(1), *meta.RemainingItemCount)
+
+	for _, ov := range d {
+		names = append(names, ov.Namespace+"/"+ov.LinkedName)
+	}
+
+	options.Continue = meta.Continue
+	d, meta, err = c.Overviews(options)
+
+	assert.Nil(t, err)
+	assert.Len(t, d, 1)
+	assert.Empty(t, meta.Continue)
+	assert.Nil(t, meta.RemainingItemCount)
+
+	for _, ov := range d {
+		names = append(names, ov.Namespace+"/"+ov.LinkedName)
+	}
+
+	assert.Equal(t, []string{"one/api", "one/web", "two/cache"}, names)
+}
+
+func TestOverviewsInvalidPage(t *testing.T) {
+	c := setupFakeClient()
+
+	_, _, err := c.Overviews(OverviewOptions{
+		Logger:   &logfakes.Logger{},
+		Context:  context.Background(),
+		Continue: "not a token",
+	})
+
+	assert.Equal(t, 400, err.Code)
+
+	_, _, err = c.Overviews(OverviewOptions{
+		Logger:  &logfakes.Logger{},
+		Context: context.Background(),
+		Limit:   -1,
+	})
+
+	assert.Equal(t, 400, err.Code)
+}
+
+func TestGroupByPartOf(t *testing.T) {
+	systems := GroupByPartOf([]Overview{
+		{LinkedName: "a", Namespace: "one", PartOf: "sys", Health: newHealth(HealthHealthy)},
+		{LinkedName: "b", Namespace: "one"},
+		{LinkedName: "c", Namespace: "one", PartOf: "sys", Health: newHealth(HealthDegraded, "bad")},
+		{LinkedName: "d", Namespace: "two", PartOf: "sys"},
+		{LinkedName: "e", Namespace: "one"},
+	})
+
+	assert.Len(t, systems, 4)
+	assert.Equal(t, "sys", systems[0].Name)
+	assert.Len(t, systems[0].Overviews, 2)
+	assert.Equal(t, HealthDegraded, systems[0].Health.Status)
+	assert.Equal(t, "b", systems[1].Overviews[0].LinkedName)
+	assert.Equal(t, "two", systems[2].Namespace)
+	assert.Equal(t, "e", systems[3].Overviews[0].LinkedName)
+}
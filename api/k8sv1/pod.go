@@ -10,40 +10,43 @@ import (
 	klog "github.com/kubelens/kubelens/api/log"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 // PodOptions contains fields used for filtering when retrieving application overiew(s).
 type PodOptions struct {
 	// name of the pod
 	Name string `json:"name"`
-	// the value from the label "app=NAME", corresponds to config.LabelKeyLink
+	// the value of the first link label the object has, e.g. "app=NAME", see config.LinkKeys
 	LinkedName string `json:"linkedName"`
 	// namespace to filter on
 	Namespace string `json:"namespace"`
-	// Limit the number of pod summaries to return
-	// Use function GetLimit to get the default limit or this overriden value.
-	Limit int64 `json:"linit"`
+	// selectors and pagination narrowing a list
+	ListOptions
 	// logger instance
 	Logger klog.Logger
 	// Context .
 	Context context.Context
 }
 
-// GetLimit returns Limit || default
-func (a *PodOptions) GetLimit() int64 {
-	if a.Limit > 0 {
-		return a.Limit
-	}
-	return 100
-}
-
 type PodOverview struct {
 	/// the name
 	Name string `json:"name"`
-	// the value from the label "app=NAME", corresponds to config.LabelKeyLink
+	// the value of the first link label the object has, e.g. "app=NAME", see config.LinkKeys
 	LinkedName string `json:"linkedName"`
 	// the namespace
 	Namespace string `json:"namespace"`
+	// events about the pod, most recent first
+	Events []EventOverview `json:"events,omitempty"`
+	// current resource usage, set when metrics-server is installed
+	Metrics *PodMetrics `json:"metrics,omitempty"`
+	// the evaluated health
+	Health *Health `json:"health,omitempty"`
+	// the number of fields redacted, see config.Redaction
+	Redacted int `json:"redacted,omitempty"`
+	// the containers with logs from a previous instance, see LogOptions.Previous
+	PreviousLogs []string `json:"previousLogs,omitempty"`
 	// the full Pod
 	Pod *v1.Pod `json:"pod,omitempty"`
 }
@@ -51,20 +54,7 @@ type PodOverview struct {
 // Pod returns a Pod given filter options
 func (k *Client) Pod(options PodOptions) (overview *PodOverview, apiErr *errs.APIError) {
 
-	clientset, err := k.wrapper.GetClientSet()
-
-	if err != nil {
-		klog.Trace()
-		return nil, errs.InternalServerError(err.Error())
-	}
-
-	pds := clientset.CoreV1().Pods(options.Namespace)
-
-	if pds == nil {
-		return nil, nil
-	}
-
-	list, err := pds.List(options.Context, metav1.ListOptions{
+	list, err := k.listPods(options.Context, options.Namespace, metav1.ListOptions{
 		FieldSelector: fmt.Sprintf("metadata.name=%s", options.Name),
 	})
 
@@ -82,24 +72,14 @@ func (k *Client) Pod(options PodOptions) (overview *PodOverview, apiErr *errs.AP
 			go func(index int, pod v1.Pod) {
 				defer wg.Done()
 
-				// try to catch sensitive values in environment variables.
-				// TODO is there a better way?
-				for ci, c := range pod.Spec.Containers {
-					env := []v1.EnvVar{}
-
-					for _, e := range c.Env {
-						if !stringContainsSensitiveInfo(e.Name) {
-							env = append(env, e)
-						}
-					}
-					pod.Spec.Containers[ci].Env = env
-				}
-
 				overview = &PodOverview{
-					Name:       pod.Name,
-					LinkedName: getLinkedName(pod.Labels),
-					Namespace:  pod.Namespace,
-					Pod:        &pod,
+					Name:         pod.Name,
+					LinkedName:   getLinkedName(pod.Namespace, pod.Labels),
+					Namespace:    pod.Namespace,
+					Health:       podHealth(&pod),
+					Redacted:     redact(&pod),
+					PreviousLogs: previousLogs(&pod),
+					Pod:          &pod,
 				}
 			}(i, item)
 		}
@@ -107,35 +87,35 @@ func (k *Client) Pod(options PodOptions) (overview *PodOverview, apiErr *errs.AP
 
 	wg.Wait()
 
+	if overview != nil {
+		overview.Events = k.objectEvents(options.Context, "Pod", overview.Namespace, overview.Name)
+		overview.Metrics = k.podMetrics(options.Context, overview.Pod)
+	}
+
 	return overview, nil
 }
 
 // Pods returns a list ofPods given filter options
-func (k *Client) Pods(options PodOptions) (overviews []PodOverview, apiErr *errs.APIError) {
-	clientset, err := k.wrapper.GetClientSet()
-
-	if err != nil {
-		klog.Trace()
-		return nil, errs.InternalServerError(err.Error())
-	}
+func (k *Client) Pods(options PodOptions) (overviews []PodOverview, meta ListMeta, apiErr *errs.APIError) {
+	overviews = []PodOverview{}
 
-	pds := clientset.CoreV1().Pods(options.Namespace)
+	lo, apiErr := options.listOptions(podFields(&v1.Pod{}))
 
-	if pds == nil {
-		return nil, nil
+	if apiErr != nil {
+		return nil, meta, apiErr
 	}
 
-	lo := metav1.ListOptions{}
-	if len(options.LinkedName) > 0 {
-		lo.LabelSelector = generateLabelSelector(options.LinkedName)
-	}
-	list, err := pds.List(options.Context, lo)
+	obj, err := listLinked(options.LinkedName, lo, func(lo metav1.ListOptions) (runtime.Object, error) {
+		return k.listPods(options.Context, options.Namespace, lo)
+	})
 
 	if err != nil {
 		klog.Trace()
-		return nil, errs.InternalServerError(err.Error())
+		return nil, meta, errs.InternalServerError(err.Error())
 	}
 
+	list := obj.(*v1.PodList)
+
 	wg := sync.WaitGroup{}
 
 	wg.Add(len(list.Items))
@@ -147,10 +127,13 @@ func (k *Client) Pods(options PodOptions) (overviews []PodOverview, apiErr *errs
 			go func(index int, pod v1.Pod) {
 				defer wg.Done()
 				overviews[index] = PodOverview{
-					Name:       pod.Name,
-					LinkedName: getLinkedName(pod.Labels),
-					Namespace:  pod.Namespace,
-					Pod:        &pod,
+					Name:         pod.Name,
+					LinkedName:   getLinkedName(pod.Namespace, pod.Labels),
+					Namespace:    pod.Namespace,
+					Health:       podHealth(&pod),
+					Redacted:     redact(&pod),
+					PreviousLogs: previousLogs(&pod),
+					Pod:          &pod,
 				}
 			}(i, item)
 		}
@@ -158,5 +141,61 @@ func (k *Client) Pods(options PodOptions) (overviews []PodOverview, apiErr *errs
 
 	wg.Wait()
 
-	return overviews, nil
+	return overviews, newListMeta(list.ListMeta), nil
+}
+
+// WatchPods watches the pods linked to LinkedName and selected by the label and field selectors.
+// The watch is started without a resource version, so the api server begins it with an added
+// event for every existing pod. Watches always go to the api server, not the informer cache.
+func (k *Client) WatchPods(options PodOptions) (w watch.Interface, apiErr *errs.APIError) {
+	lo, apiErr := options.listOptions(podFields(&v1.Pod{}))
+
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	// a watch isn't paginated
+	lo.Limit = 0
+	lo.Continue = ""
+
+	clientset, err := k.wrapper.GetClientSet()
+
+	if err != nil {
+		klog.Trace()
+		return nil, errs.InternalServerError(err.Error())
+	}
+
+	w, err = clientset.CoreV1().Pods(options.Namespace).Watch(options.Context, lo)
+
+	if err != nil {
+		klog.Trace()
+		return nil, errs.InternalServerError(err.Error())
+	}
+
+	if len(options.LinkedName) == 0 {
+		return w, nil
+	}
+
+	// label selectors can't express "the first link label key set", see listLinked
+	return watch.Filter(w, func(e watch.Event) (watch.Event, bool) {
+		if pod, ok := e.Object.(*v1.Pod); ok {
+			return e, getLinkedName(pod.Namespace, pod.Labels) == options.LinkedName
+		}
+		return e, true
+	}), nil
+}
+
+// listPods lists pods from the cache when it has synced, otherwise from the api server.
+func (k *Client) listPods(ctx context.Context, namespace string, lo metav1.ListOptions) (*v1.PodList, error) {
+	if k.cache.hasSynced(kindPods) {
+		return k.cache.listPods(namespace, lo)
+	}
+
+	clientset, err := k.wrapper.GetClientSet()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return clientset.CoreV1().Pods(namespace).List(ctx, lo)
 }
@@ -4,9 +4,14 @@ import (
 	"context"
 	"net/http"
 	"testing"
+	"time"
 
 	logfakes "github.com/kubelens/kubelens/api/log/fakes"
 	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/fake"
 )
 
 func TestPodDefault(t *testing.T) {
@@ -40,7 +45,7 @@ func TestPodDetailForbidden(t *testing.T) {
 func TestPodsDefault(t *testing.T) {
 	c := setupClient("default", "pod3", false, false)
 
-	r, err := c.Pods(PodOptions{
+	r, _, err := c.Pods(PodOptions{
 		Logger:     &logfakes.Logger{},
 		Namespace:  "default",
 		LinkedName: "pod3",
@@ -54,7 +59,7 @@ func TestPodsDefault(t *testing.T) {
 func TestPodsDefaultWithFilters(t *testing.T) {
 	c := setupClient("default", "pod4", false, false)
 
-	r, err := c.Pods(PodOptions{
+	r, _, err := c.Pods(PodOptions{
 		Logger:     &logfakes.Logger{},
 		Namespace:  "default",
 		Name:       "test",
@@ -65,3 +70,51 @@ func TestPodsDefaultWithFilters(t *testing.T) {
 	assert.Nil(t, err)
 	assert.True(t, len(r) > 0)
 }
+
+func TestWatchPodsLinked(t *testing.T) {
+	setLinkKeys(t, []string{"app.kubernetes.io/name", "app"}, nil)
+
+	clientset := fake.NewSimpleClientset()
+	c := New(&fakeWrapper{clientset: clientset})
+
+	w, err := c.WatchPods(PodOptions{
+		Logger:     &logfakes.Logger{},
+		LinkedName: "web",
+		Namespace:  "one",
+		Context:    context.Background(),
+	})
+
+	assert.Nil(t, err)
+	defer w.Stop()
+
+	for _, pod := range []*v1.Pod{
+		// the first key set wins, this is "api" even though "app" is web
+		{ObjectMeta: metav1.ObjectMeta{Name: "api-1", Namespace: "one", Labels: map[string]string{"app.kubernetes.io/name": "api", "app": "web"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "one", Labels: map[string]string{"app": "web"}}},
+	} {
+		if _, err := clientset.CoreV1().Pods("one").Create(context.Background(), pod, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	select {
+	case e := <-w.ResultChan():
+		assert.Equal(t, watch.Added, e.Type)
+		assert.Equal(t, "web-1", e.Object.(*v1.Pod).Name)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the pod to be added")
+	}
+}
+
+func TestWatchPodsInvalidSelector(t *testing.T) {
+	c := setupClient("default", "pod1", false, false)
+
+	_, err := c.WatchPods(PodOptions{
+		Logger:      &logfakes.Logger{},
+		Namespace:   "default",
+		ListOptions: ListOptions{LabelSelector: "app in"},
+		Context:     context.Background(),
+	})
+
+	assert.Equal(t, 400, err.Code)
+}
@@ -0,0 +1,324 @@
+package k8sv1
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/kubelens/kubelens/api/config"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Redacted replaces a redacted value.
+const Redacted = "[REDACTED]"
+
+// redactor redacts values by what they are stored under or by what they look like,
+// see config.Redaction.
+type redactor struct {
+	names  []*regexp.Regexp
+	values []*regexp.Regexp
+}
+
+var (
+	redactorMu    sync.Mutex
+	redactorRules config.Redaction
+	redactorCache *redactor
+)
+
+// newRedactor returns a redactor for the configured rules, compiled once per change of the rules.
+// Rules that don't compile are skipped, config.Set rejects them.
+func newRedactor() *redactor {
+	rules := config.C.RedactionRules()
+
+	redactorMu.Lock()
+	defer redactorMu.Unlock()
+
+	if redactorCache != nil && reflect.DeepEqual(rules, redactorRules) {
+		return redactorCache
+	}
+
+	r := &redactor{}
+	for _, rule := range rules.Names {
+		if re, err := regexp.Compile(rule); err == nil {
+			r.names = append(r.names, re)
+		}
+	}
+	for _, rule := range rules.Values {
+		if re, err := regexp.Compile(rule); err == nil {
+			r.values = append(r.values, re)
+		}
+	}
+
+	redactorRules, redactorCache = rules, r
+
+	return r
+}
+
+// redact redacts the env vars, command line args, annotations and data of an object
+// in place, returning the number of fields redacted.
+func redact(obj runtime.Object) int {
+	r := newRedactor()
+
+	switch o := obj.(type) {
+	case *v1.Pod:
+		return r.meta(&o.ObjectMeta) + r.podSpec(&o.Spec)
+	case *appsv1.Deployment:
+		return r.meta(&o.ObjectMeta) + r.template(&o.Spec.Template)
+	case *appsv1.DaemonSet:
+		return r.meta(&o.ObjectMeta) + r.template(&o.Spec.Template)
+	case *appsv1.StatefulSet:
+		return r.meta(&o.ObjectMeta) + r.template(&o.Spec.Template)
+	case *appsv1.ReplicaSet:
+		return r.meta(&o.ObjectMeta) + r.template(&o.Spec.Template)
+	case *batchv1.Job:
+		return r.meta(&o.ObjectMeta) + r.template(&o.Spec.Template)
+	case *batchv1.CronJob:
+		return r.meta(&o.ObjectMeta) + r.meta(&o.Spec.JobTemplate.ObjectMeta) + r.template(&o.Spec.JobTemplate.Spec.Template)
+	case *v1.ConfigMap:
+		return r.meta(&o.ObjectMeta) + r.configMap(o)
+	}
+
+	if m, ok := obj.(metav1.Object); ok {
+		annotations := m.GetAnnotations()
+		n := r.annotations(annotations)
+		m.SetAnnotations(annotations)
+		return n
+	}
+
+	return 0
+}
+
+// name returns true if a value stored under name is redacted whatever it is.
+func (r *redactor) name(name string) bool {
+	for _, re := range r.names {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// value redacts the parts of a value matching the value rules, the first group of
+// a rule with groups.
+func (r *redactor) value(value string) (string, int) {
+	redacted := value
+
+	for _, re := range r.values {
+		matches := re.FindAllStringSubmatchIndex(redacted, -1)
+
+		// replaced from the end so the earlier indexes stay valid
+		for i := len(matches) - 1; i >= 0; i-- {
+			start, end := matches[i][0], matches[i][1]
+			if len(matches[i]) >= 4 && matches[i][2] >= 0 {
+				start, end = matches[i][2], matches[i][3]
+			}
+			redacted = redacted[:start] + Redacted + redacted[end:]
+		}
+	}
+
+	if redacted != value {
+		return redacted, 1
+	}
+	return value, 0
+}
+
+// field redacts a value stored under name.
+func (r *redactor) field(name, value string) (string, int) {
+	if len(value) > 0 && r.name(name) {
+		return Redacted, 1
+	}
+	return r.value(value)
+}
+
+func (r *redactor) meta(meta *metav1.ObjectMeta) int {
+	return r.annotations(meta.Annotations)
+}
+
+// annotations redacts annotation values, annotations holding json, such as
+// kubectl.kubernetes.io/last-applied-configuration, are redacted field by field.
+func (r *redactor) annotations(annotations map[string]string) (count int) {
+	for k, v := range annotations {
+		if r.name(k) {
+			annotations[k], count = Redacted, count+1
+			continue
+		}
+
+		var doc interface{}
+		if trimmed := strings.TrimSpace(v); strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+			if err := json.Unmarshal([]byte(v), &doc); err == nil {
+				if n := r.json(doc); n > 0 {
+					b, _ := json.Marshal(doc)
+					annotations[k], count = string(b), count+n
+				}
+				continue
+			}
+		}
+
+		var n int
+		annotations[k], n = r.value(v)
+		count += n
+	}
+
+	return count
+}
+
+// json redacts a decoded json document in place. Names are only matched where they name
+// the value: env vars, objects with a name and a value, flags in lists of args and the keys of
+// annotations and configmap data. Every other string is redacted by what it looks like, so
+// fields such as secretName or a toleration key are left alone.
+func (r *redactor) json(doc interface{}) (count int) {
+	switch d := doc.(type) {
+	case map[string]interface{}:
+		if name, ok := d["name"].(string); ok {
+			if value, ok := d["value"].(string); ok && len(value) > 0 && r.name(name) {
+				d["value"], count = Redacted, count+1
+			}
+		}
+
+		for k, v := range d {
+			switch item := v.(type) {
+			case string:
+				var n int
+				d[k], n = r.value(item)
+				count += n
+			case map[string]interface{}:
+				if k == "annotations" || k == "data" {
+					count += r.jsonFields(item)
+				} else {
+					count += r.json(item)
+				}
+			default:
+				count += r.json(item)
+			}
+		}
+	case []interface{}:
+		args := make([]string, 0, len(d))
+		for _, v := range d {
+			if s, ok := v.(string); ok {
+				args = append(args, s)
+			}
+		}
+
+		// e.g. a command, its flags are redacted by name
+		if len(args) == len(d) {
+			count += r.args(args)
+			for i := range d {
+				d[i] = args[i]
+			}
+			return count
+		}
+
+		for i, v := range d {
+			if s, ok := v.(string); ok {
+				var n int
+				d[i], n = r.value(s)
+				count += n
+			} else {
+				count += r.json(v)
+			}
+		}
+	}
+
+	return count
+}
+
+// jsonFields redacts the string values of a decoded json object by their key.
+func (r *redactor) jsonFields(fields map[string]interface{}) (count int) {
+	for k, v := range fields {
+		if s, ok := v.(string); ok {
+			var n int
+			fields[k], n = r.field(k, s)
+			count += n
+		} else {
+			count += r.json(v)
+		}
+	}
+	return count
+}
+
+func (r *redactor) template(template *v1.PodTemplateSpec) int {
+	return r.meta(&template.ObjectMeta) + r.podSpec(&template.Spec)
+}
+
+func (r *redactor) podSpec(spec *v1.PodSpec) (count int) {
+	for i := range spec.InitContainers {
+		c := &spec.InitContainers[i]
+		count += r.env(c.Env) + r.args(c.Command) + r.args(c.Args)
+	}
+	for i := range spec.Containers {
+		c := &spec.Containers[i]
+		count += r.env(c.Env) + r.args(c.Command) + r.args(c.Args)
+	}
+	for i := range spec.EphemeralContainers {
+		c := &spec.EphemeralContainers[i]
+		count += r.env(c.Env) + r.args(c.Command) + r.args(c.Args)
+	}
+	return count
+}
+
+// env redacts env var values, values from secrets and configmaps are references and are left alone.
+func (r *redactor) env(env []v1.EnvVar) (count int) {
+	for i := range env {
+		var n int
+		env[i].Value, n = r.field(env[i].Name, env[i].Value)
+		count += n
+	}
+	return count
+}
+
+// args redacts command line args, the values of flags are redacted by the flag name,
+// both as --flag=value and --flag value.
+func (r *redactor) args(args []string) (count int) {
+	for i, arg := range args {
+		var n int
+
+		switch {
+		case strings.HasPrefix(arg, "-") && strings.Contains(arg, "="):
+			eq := strings.Index(arg, "=")
+			var value string
+			value, n = r.field(strings.TrimLeft(arg[:eq], "-"), arg[eq+1:])
+			args[i] = arg[:eq+1] + value
+		case strings.HasPrefix(arg, "-"):
+			args[i], n = r.value(arg)
+		case i > 0 && isFlag(args[i-1]) && r.name(strings.TrimLeft(args[i-1], "-")):
+			args[i], n = Redacted, 1
+		default:
+			args[i], n = r.value(arg)
+		}
+
+		count += n
+	}
+	return count
+}
+
+// isFlag returns true for a flag expecting its value in the next arg.
+func isFlag(arg string) bool {
+	return strings.HasPrefix(arg, "-") && !strings.Contains(arg, "=")
+}
+
+func (r *redactor) configMap(cm *v1.ConfigMap) (count int) {
+	for k, v := range cm.Data {
+		var n int
+		cm.Data[k], n = r.field(k, v)
+		count += n
+	}
+
+	for k, v := range cm.BinaryData {
+		if r.name(k) {
+			cm.BinaryData[k], count = []byte(Redacted), count+1
+		} else if utf8.Valid(v) {
+			if s, n := r.value(string(v)); n > 0 {
+				cm.BinaryData[k], count = []byte(s), count+n
+			}
+		}
+	}
+
+	return count
+}
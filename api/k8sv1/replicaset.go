@@ -9,16 +9,19 @@ import (
 	klog "github.com/kubelens/kubelens/api/log"
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // ReplicaSetOptions contains fields used for filtering when retrieving daemon sets
 type ReplicaSetOptions struct {
 	// the name of the deployment
 	Name string `json:"name"`
-	// the value from the label "app=NAME", corresponds to config.LabelKeyLink
+	// the value of the first link label the object has, e.g. "app=NAME", see config.LinkKeys
 	LinkedName string `json:"linkedName"`
 	// the namespace of the deployment
 	Namespace string `json:"namespace"`
+	// selectors and pagination narrowing a list
+	ListOptions
 	// logger instance
 	Logger klog.Logger
 	// Context .
@@ -28,10 +31,14 @@ type ReplicaSetOptions struct {
 type ReplicaSetOverview struct {
 	/// the name
 	Name string `json:"name"`
-	// the value from the label "app=NAME", corresponds to config.LabelKeyLink
+	// the value of the first link label the object has, e.g. "app=NAME", see config.LinkKeys
 	LinkedName string `json:"linkedName"`
 	// the namespace
 	Namespace string `json:"namespace"`
+	// the evaluated health
+	Health *Health `json:"health,omitempty"`
+	// the number of fields redacted, see config.Redaction
+	Redacted int `json:"redacted,omitempty"`
 	// the full ReplicaSet
 	ReplicaSet *appsv1.ReplicaSet `json:"replicaSet,omitempty"`
 }
@@ -39,20 +46,7 @@ type ReplicaSetOverview struct {
 // ReplicaSet returns a ReplicaSet given filter options
 func (k *Client) ReplicaSet(options ReplicaSetOptions) (overview *ReplicaSetOverview, apiErr *errs.APIError) {
 
-	clientset, err := k.wrapper.GetClientSet()
-
-	if err != nil {
-		klog.Trace()
-		return nil, errs.InternalServerError(err.Error())
-	}
-
-	rsl := clientset.AppsV1().ReplicaSets(options.Namespace)
-
-	if rsl == nil {
-		return nil, nil
-	}
-
-	list, err := rsl.List(options.Context, metav1.ListOptions{
+	list, err := k.listReplicaSets(options.Context, options.Namespace, metav1.ListOptions{
 		FieldSelector: fmt.Sprintf("metadata.name=%s", options.Name),
 	})
 
@@ -71,8 +65,10 @@ func (k *Client) ReplicaSet(options ReplicaSetOptions) (overview *ReplicaSetOver
 				defer wg.Done()
 				overview = &ReplicaSetOverview{
 					Name:       rs.Name,
-					LinkedName: getLinkedName(rs.Labels),
+					LinkedName: getLinkedName(rs.Namespace, rs.Labels),
 					Namespace:  rs.Namespace,
+					Health:     replicaSetHealth(&rs),
+					Redacted:   redact(&rs),
 					ReplicaSet: &rs,
 				}
 			}(i, item)
@@ -85,31 +81,26 @@ func (k *Client) ReplicaSet(options ReplicaSetOptions) (overview *ReplicaSetOver
 }
 
 // ReplicaSets returns a list of ReplicaSets given filter options
-func (k *Client) ReplicaSets(options ReplicaSetOptions) (overviews []ReplicaSetOverview, apiErr *errs.APIError) {
-	clientset, err := k.wrapper.GetClientSet()
+func (k *Client) ReplicaSets(options ReplicaSetOptions) (overviews []ReplicaSetOverview, meta ListMeta, apiErr *errs.APIError) {
+	overviews = []ReplicaSetOverview{}
 
-	if err != nil {
-		klog.Trace()
-		return nil, errs.InternalServerError(err.Error())
-	}
+	lo, apiErr := options.listOptions(replicaSetFields(&appsv1.ReplicaSet{}))
 
-	rsl := clientset.AppsV1().ReplicaSets(options.Namespace)
-
-	if rsl == nil {
-		return nil, nil
+	if apiErr != nil {
+		return nil, meta, apiErr
 	}
 
-	lo := metav1.ListOptions{}
-	if len(options.LinkedName) > 0 {
-		lo.LabelSelector = generateLabelSelector(options.LinkedName)
-	}
-	list, err := rsl.List(options.Context, lo)
+	obj, err := listLinked(options.LinkedName, lo, func(lo metav1.ListOptions) (runtime.Object, error) {
+		return k.listReplicaSets(options.Context, options.Namespace, lo)
+	})
 
 	if err != nil {
 		klog.Trace()
-		return nil, errs.InternalServerError(err.Error())
+		return nil, meta, errs.InternalServerError(err.Error())
 	}
 
+	list := obj.(*appsv1.ReplicaSetList)
+
 	wg := sync.WaitGroup{}
 
 	wg.Add(len(list.Items))
@@ -124,8 +115,10 @@ func (k *Client) ReplicaSets(options ReplicaSetOptions) (overviews []ReplicaSetO
 				defer wg.Done()
 				ovs[index] = ReplicaSetOverview{
 					Name:       rs.Name,
-					LinkedName: getLinkedName(rs.Labels),
+					LinkedName: getLinkedName(rs.Namespace, rs.Labels),
 					Namespace:  rs.Namespace,
+					Health:     replicaSetHealth(&rs),
+					Redacted:   redact(&rs),
 					ReplicaSet: &rs,
 				}
 			}(i, item)
@@ -143,5 +136,20 @@ func (k *Client) ReplicaSets(options ReplicaSetOptions) (overviews []ReplicaSetO
 		}
 	}
 
-	return overviews, nil
+	return overviews, newListMeta(list.ListMeta), nil
+}
+
+// listReplicaSets lists replicasets from the cache when it has synced, otherwise from the api server.
+func (k *Client) listReplicaSets(ctx context.Context, namespace string, lo metav1.ListOptions) (*appsv1.ReplicaSetList, error) {
+	if k.cache.hasSynced(kindReplicaSets) {
+		return k.cache.listReplicaSets(namespace, lo)
+	}
+
+	clientset, err := k.wrapper.GetClientSet()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return clientset.AppsV1().ReplicaSets(namespace).List(ctx, lo)
 }
@@ -11,7 +11,7 @@ import (
 func TestReplicaSetsDefaultSuccess(t *testing.T) {
 	c := setupClient("testns", "rs1", false, false)
 
-	d, err := c.ReplicaSets(ReplicaSetOptions{
+	d, _, err := c.ReplicaSets(ReplicaSetOptions{
 		Logger:     &logfakes.Logger{},
 		Namespace:  "testns",
 		LinkedName: "rs1",
@@ -26,7 +26,7 @@ func TestReplicaSetsDefaultSuccess(t *testing.T) {
 func TestGetReplicaSetsDefaultFail(t *testing.T) {
 	c := setupClient("testns", "rs2", true, true)
 
-	_, err := c.ReplicaSets(ReplicaSetOptions{
+	_, _, err := c.ReplicaSets(ReplicaSetOptions{
 		Logger:     &logfakes.Logger{},
 		Namespace:  "testns",
 		LinkedName: "rs2",
@@ -9,18 +9,21 @@ import (
 	klog "github.com/kubelens/kubelens/api/log"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // ServiceOptions contains fields used for filtering when retrieving services
 type ServiceOptions struct {
 	/// the name (optional)
 	Name string `json:"name"`
-	// the value from the label "app=NAME", corresponds to config.LabelKeyLink
+	// the value of the first link label the object has, e.g. "app=NAME", see config.LinkKeys
 	LinkedName string `json:"linkedName"`
 	// namespace to filter on
 	Namespace string `json:"namespace"`
 	// the labels to match kinds
 	Labels map[string]string `json:"labels"`
+	// selectors and pagination narrowing a list
+	ListOptions
 	// logger instance
 	Logger klog.Logger
 	// Context .
@@ -30,10 +33,18 @@ type ServiceOptions struct {
 type ServiceOverview struct {
 	/// the name
 	Name string `json:"name"`
-	// the value from the label "app=NAME", corresponds to config.LabelKeyLink
+	// the value of the first link label the object has, e.g. "app=NAME", see config.LinkKeys
 	LinkedName string `json:"linkedName"`
 	// the namespace
 	Namespace string `json:"namespace"`
+	// the pods matched by the selector, unset for services without a selector
+	Pods []ServicePod `json:"pods,omitempty"`
+	// the EndpointSlices of the service
+	EndpointSlices []EndpointSliceOverview `json:"endpointSlices,omitempty"`
+	// problems with the selector or port mappings, most severe first
+	Findings []Finding `json:"findings,omitempty"`
+	// the number of fields redacted, see config.Redaction
+	Redacted int `json:"redacted,omitempty"`
 	// the full Service
 	Service *v1.Service `json:"service,omitempty"`
 }
@@ -41,20 +52,7 @@ type ServiceOverview struct {
 // Service returns a Service given filter options
 func (k *Client) Service(options ServiceOptions) (overview *ServiceOverview, apiErr *errs.APIError) {
 
-	clientset, err := k.wrapper.GetClientSet()
-
-	if err != nil {
-		klog.Trace()
-		return nil, errs.InternalServerError(err.Error())
-	}
-
-	svcs := clientset.CoreV1().Services(options.Namespace)
-
-	if svcs == nil {
-		return nil, nil
-	}
-
-	list, err := svcs.List(options.Context, metav1.ListOptions{
+	list, err := k.listServices(options.Context, options.Namespace, metav1.ListOptions{
 		FieldSelector: fmt.Sprintf("metadata.name=%s", options.Name),
 	})
 
@@ -65,53 +63,74 @@ func (k *Client) Service(options ServiceOptions) (overview *ServiceOverview, api
 
 	if list != nil && len(list.Items) > 0 {
 		for _, item := range list.Items {
-			return &ServiceOverview{
+			overview = &ServiceOverview{
 				Name:       item.Name,
-				LinkedName: getLinkedName(item.Labels),
+				LinkedName: getLinkedName(item.Namespace, item.Labels),
 				Namespace:  item.Namespace,
+				Redacted:   redact(&item),
 				Service:    &item,
-			}, nil
+			}
+			k.serviceBackends(options.Context, item.Namespace).resolve(overview)
+
+			return overview, nil
 		}
 	}
 	return overview, nil
 }
 
 // Services returns a list ofServices given filter options
-func (k *Client) Services(options ServiceOptions) (overviews []ServiceOverview, apiErr *errs.APIError) {
+func (k *Client) Services(options ServiceOptions) (overviews []ServiceOverview, meta ListMeta, apiErr *errs.APIError) {
 	overviews = []ServiceOverview{}
-	clientset, err := k.wrapper.GetClientSet()
-
-	if err != nil {
-		klog.Trace()
-		return nil, errs.InternalServerError(err.Error())
-	}
+	lo, apiErr := options.listOptions(objectMetaFields(metav1.ObjectMeta{}))
 
-	svcs := clientset.CoreV1().Services(options.Namespace)
-
-	if svcs == nil {
-		return nil, nil
+	if apiErr != nil {
+		return nil, meta, apiErr
 	}
 
-	lo := metav1.ListOptions{}
-	if len(options.LinkedName) > 0 {
-		lo.LabelSelector = generateLabelSelector(options.LinkedName)
-	}
-	list, err := svcs.List(options.Context, lo)
+	obj, err := listLinked(options.LinkedName, lo, func(lo metav1.ListOptions) (runtime.Object, error) {
+		return k.listServices(options.Context, options.Namespace, lo)
+	})
 
 	if err != nil {
 		klog.Trace()
-		return nil, errs.InternalServerError(err.Error())
+		return nil, meta, errs.InternalServerError(err.Error())
 	}
 
+	list := obj.(*v1.ServiceList)
+
 	if list != nil && len(list.Items) > 0 {
+		backends := k.serviceBackends(options.Context, options.Namespace)
+
 		for _, item := range list.Items {
-			overviews = append(overviews, ServiceOverview{
+			// the overview keeps a pointer to item
+			item := item
+
+			overview := ServiceOverview{
 				Name:       item.Name,
-				LinkedName: getLinkedName(item.Labels),
+				LinkedName: getLinkedName(item.Namespace, item.Labels),
 				Namespace:  item.Namespace,
+				Redacted:   redact(&item),
 				Service:    &item,
-			})
+			}
+			backends.resolve(&overview)
+
+			overviews = append(overviews, overview)
 		}
 	}
-	return overviews, nil
+	return overviews, newListMeta(list.ListMeta), nil
+}
+
+// listServices lists services from the cache when it has synced, otherwise from the api server.
+func (k *Client) listServices(ctx context.Context, namespace string, lo metav1.ListOptions) (*v1.ServiceList, error) {
+	if k.cache.hasSynced(kindServices) {
+		return k.cache.listServices(namespace, lo)
+	}
+
+	clientset, err := k.wrapper.GetClientSet()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return clientset.CoreV1().Services(namespace).List(ctx, lo)
 }
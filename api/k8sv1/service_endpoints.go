@@ -0,0 +1,288 @@
+package k8sv1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	klog "github.com/kubelens/kubelens/api/log"
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// ServicePod is a pod matched by the selector of a service.
+type ServicePod struct {
+	// the name of the pod
+	Name string `json:"name"`
+	// the ip of the pod
+	IP string `json:"ip,omitempty"`
+	// the node the pod runs on
+	Node string `json:"node,omitempty"`
+	// true if the pod is ready, only ready pods receive traffic
+	Ready bool `json:"ready"`
+}
+
+// EndpointSliceOverview is one of the EndpointSlices of a service.
+type EndpointSliceOverview struct {
+	// the name of the slice
+	Name string `json:"name"`
+	// IPv4, IPv6 or FQDN
+	AddressType string `json:"addressType"`
+	// the ports of the slice, e.g. http:8080/TCP
+	Ports []string `json:"ports"`
+	// the endpoints of the slice
+	Endpoints []SliceEndpoint `json:"endpoints"`
+}
+
+// SliceEndpoint is an endpoint of an EndpointSlice.
+type SliceEndpoint struct {
+	// the addresses of the endpoint
+	Addresses []string `json:"addresses"`
+	// true if the endpoint receives traffic
+	Ready bool `json:"ready"`
+	// the pod backing the endpoint
+	Pod string `json:"pod,omitempty"`
+	// the node the endpoint runs on
+	Node string `json:"node,omitempty"`
+}
+
+// serviceBackends are the pods and endpointslices of a namespace, listed once for every service.
+type serviceBackends struct {
+	pods   []v1.Pod
+	slices []discoveryv1.EndpointSlice
+	// set if the pods can't be listed
+	podsErr error
+}
+
+// serviceBackends lists the pods and endpointslices services are resolved against.
+// Either is empty if it can't be listed, resolution is informational.
+func (k *Client) serviceBackends(ctx context.Context, namespace string) (backends serviceBackends) {
+	if pods, err := k.listPods(ctx, namespace, metav1.ListOptions{}); err != nil {
+		klog.Trace()
+		backends.podsErr = err
+	} else {
+		backends.pods = pods.Items
+	}
+
+	if slices, err := k.listEndpointSlices(ctx, namespace, metav1.ListOptions{}); err != nil {
+		klog.Trace()
+	} else {
+		backends.slices = slices.Items
+	}
+
+	return backends
+}
+
+// resolve sets the backing pods, EndpointSlices and findings of a service overview.
+func (b serviceBackends) resolve(overview *ServiceOverview) {
+	svc := overview.Service
+
+	for _, slice := range b.slices {
+		if slice.Namespace == svc.Namespace && slice.Labels[discoveryv1.LabelServiceName] == svc.Name {
+			overview.EndpointSlices = append(overview.EndpointSlices, newEndpointSliceOverview(slice))
+		}
+	}
+
+	// services without a selector have their endpoints managed by hand, there is nothing to check.
+	if len(svc.Spec.Selector) == 0 || svc.Spec.Type == v1.ServiceTypeExternalName {
+		return
+	}
+
+	// without the pods the selector and ports can't be checked
+	if b.podsErr != nil {
+		overview.Findings = []Finding{{
+			Severity: SeverityWarning,
+			Reason:   "ResolutionFailed",
+			Message:  fmt.Sprintf("the pods of the service can't be listed: %s", b.podsErr.Error()),
+		}}
+		return
+	}
+
+	selector := labels.SelectorFromSet(svc.Spec.Selector)
+	pods := []v1.Pod{}
+
+	for _, pod := range b.pods {
+		if pod.Namespace == svc.Namespace && selector.Matches(labels.Set(pod.Labels)) {
+			pods = append(pods, pod)
+		}
+	}
+
+	overview.Pods = []ServicePod{}
+	ready := 0
+
+	for _, pod := range pods {
+		sp := ServicePod{
+			Name:  pod.Name,
+			IP:    pod.Status.PodIP,
+			Node:  pod.Spec.NodeName,
+			Ready: isPodReady(&pod),
+		}
+		if sp.Ready {
+			ready++
+		}
+		overview.Pods = append(overview.Pods, sp)
+	}
+
+	overview.Findings = serviceFindings(svc, pods, ready)
+}
+
+// serviceFindings checks the selector and port mappings of a service against the pods it selects.
+func serviceFindings(svc *v1.Service, pods []v1.Pod, ready int) []Finding {
+	findings := []Finding{}
+
+	if len(pods) == 0 {
+		return append(findings, Finding{
+			Severity: SeverityCritical,
+			Reason:   "NoMatchingPods",
+			Message:  fmt.Sprintf("the selector %s matches no pods", labels.SelectorFromSet(svc.Spec.Selector)),
+		})
+	}
+
+	if ready == 0 {
+		findings = append(findings, Finding{
+			Severity: SeverityCritical,
+			Reason:   "NoReadyPods",
+			Message:  fmt.Sprintf("none of the %d selected pods are ready", len(pods)),
+		})
+	}
+
+	for _, port := range svc.Spec.Ports {
+		target := port.TargetPort
+
+		// an unset targetPort defaults to the port
+		if target.Type == intstr.Int && target.IntVal == 0 {
+			target = intstr.FromInt(int(port.Port))
+		}
+
+		if containerPortExists(pods, port.Protocol, target) {
+			continue
+		}
+
+		name := port.Name
+		if len(name) == 0 {
+			name = fmt.Sprint(port.Port)
+		}
+
+		// a named targetPort must be declared by the container to resolve, a numbered one
+		// only needs the container to listen on it.
+		if target.Type == intstr.String {
+			findings = append(findings, Finding{
+				Severity: SeverityCritical,
+				Reason:   "TargetPortNotFound",
+				Message:  fmt.Sprintf("port %s targets the named port %q, which no selected container declares", name, target.StrVal),
+			})
+		} else {
+			findings = append(findings, Finding{
+				Severity: SeverityWarning,
+				Reason:   "TargetPortNotDeclared",
+				Message:  fmt.Sprintf("port %s targets port %d, which no selected container declares, make sure the container listens on it", name, target.IntVal),
+			})
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].Severity == SeverityCritical && findings[j].Severity != SeverityCritical
+	})
+
+	return findings
+}
+
+// containerPortExists returns true if a container of any of the pods declares the target port.
+func containerPortExists(pods []v1.Pod, protocol v1.Protocol, target intstr.IntOrString) bool {
+	if len(protocol) == 0 {
+		protocol = v1.ProtocolTCP
+	}
+
+	for _, pod := range pods {
+		for _, c := range pod.Spec.Containers {
+			for _, cp := range c.Ports {
+				p := cp.Protocol
+				if len(p) == 0 {
+					p = v1.ProtocolTCP
+				}
+				if p != protocol {
+					continue
+				}
+				if target.Type == intstr.String && cp.Name == target.StrVal {
+					return true
+				}
+				if target.Type == intstr.Int && cp.ContainerPort == target.IntVal {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// isPodReady returns true if the pod has the Ready condition.
+func isPodReady(pod *v1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == v1.PodReady {
+			return c.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func newEndpointSliceOverview(slice discoveryv1.EndpointSlice) EndpointSliceOverview {
+	overview := EndpointSliceOverview{
+		Name:        slice.Name,
+		AddressType: string(slice.AddressType),
+		Ports:       []string{},
+		Endpoints:   []SliceEndpoint{},
+	}
+
+	for _, p := range slice.Ports {
+		port := []string{}
+		if p.Name != nil && len(*p.Name) > 0 {
+			port = append(port, *p.Name)
+		}
+		if p.Port != nil {
+			port = append(port, fmt.Sprint(*p.Port))
+		}
+
+		s := strings.Join(port, ":")
+		if p.Protocol != nil {
+			s = fmt.Sprintf("%s/%s", s, *p.Protocol)
+		}
+		overview.Ports = append(overview.Ports, s)
+	}
+
+	for _, e := range slice.Endpoints {
+		endpoint := SliceEndpoint{
+			Addresses: e.Addresses,
+			// a nil ready condition means ready, see discoveryv1.EndpointConditions
+			Ready: e.Conditions.Ready == nil || *e.Conditions.Ready,
+		}
+		if e.TargetRef != nil && e.TargetRef.Kind == "Pod" {
+			endpoint.Pod = e.TargetRef.Name
+		}
+		if e.NodeName != nil {
+			endpoint.Node = *e.NodeName
+		}
+		overview.Endpoints = append(overview.Endpoints, endpoint)
+	}
+
+	return overview
+}
+
+// listEndpointSlices lists endpointslices from the cache when it has synced, otherwise from the api server.
+func (k *Client) listEndpointSlices(ctx context.Context, namespace string, lo metav1.ListOptions) (*discoveryv1.EndpointSliceList, error) {
+	if k.cache.hasSynced(kindEndpointSlices) {
+		return k.cache.listEndpointSlices(namespace, lo)
+	}
+
+	clientset, err := k.wrapper.GetClientSet()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return clientset.DiscoveryV1().EndpointSlices(namespace).List(ctx, lo)
+}
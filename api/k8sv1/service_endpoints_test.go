@@ -0,0 +1,173 @@
+package k8sv1
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	logfakes "github.com/kubelens/kubelens/api/log/fakes"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func boolPtr(b bool) *bool { return &b }
+
+func serviceTestPod(name string, ready bool) *v1.Pod {
+	status := v1.ConditionFalse
+	if ready {
+		status = v1.ConditionTrue
+	}
+
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "testns", Labels: map[string]string{"tier": "web"}},
+		Spec: v1.PodSpec{
+			NodeName: "node-1",
+			Containers: []v1.Container{
+				{Name: "app", Ports: []v1.ContainerPort{{Name: "http", ContainerPort: 8080}}},
+			},
+		},
+		Status: v1.PodStatus{
+			PodIP:      "10.0.0.1",
+			Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: status}},
+		},
+	}
+}
+
+func serviceTestObjects() []runtime.Object {
+	port := int32(8080)
+	name := "http"
+	protocol := v1.ProtocolTCP
+
+	return []runtime.Object{
+		&v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "testns", Labels: map[string]string{"app": "web"}},
+			Spec: v1.ServiceSpec{
+				Selector: map[string]string{"tier": "web"},
+				Ports: []v1.ServicePort{
+					{Name: "http", Port: 80, TargetPort: intstr.FromString("http")},
+					{Name: "metrics", Port: 9090},
+					{Name: "admin", Port: 81, TargetPort: intstr.FromString("admin")},
+				},
+			},
+		},
+		serviceTestPod("web-a", true),
+		serviceTestPod("web-b", false),
+		&discoveryv1.EndpointSlice{
+			ObjectMeta:  metav1.ObjectMeta{Name: "web-x1", Namespace: "testns", Labels: map[string]string{discoveryv1.LabelServiceName: "web"}},
+			AddressType: discoveryv1.AddressTypeIPv4,
+			Ports:       []discoveryv1.EndpointPort{{Name: &name, Port: &port, Protocol: &protocol}},
+			Endpoints: []discoveryv1.Endpoint{
+				{Addresses: []string{"10.0.0.1"}, Conditions: discoveryv1.EndpointConditions{Ready: boolPtr(true)}, TargetRef: &v1.ObjectReference{Kind: "Pod", Name: "web-a"}},
+				{Addresses: []string{"10.0.0.2"}, Conditions: discoveryv1.EndpointConditions{Ready: boolPtr(false)}, TargetRef: &v1.ObjectReference{Kind: "Pod", Name: "web-b"}},
+			},
+		},
+	}
+}
+
+func TestServiceEndpoints(t *testing.T) {
+	c := setupFakeClient(serviceTestObjects()...)
+
+	s, err := c.Service(ServiceOptions{
+		Logger:    &logfakes.Logger{},
+		Namespace: "testns",
+		Name:      "web",
+		Context:   context.Background(),
+	})
+
+	assert.Nil(t, err)
+
+	assert.Equal(t, []ServicePod{
+		{Name: "web-a", IP: "10.0.0.1", Node: "node-1", Ready: true},
+		{Name: "web-b", IP: "10.0.0.1", Node: "node-1", Ready: false},
+	}, s.Pods)
+
+	assert.Len(t, s.EndpointSlices, 1)
+	assert.Equal(t, []string{"http:8080/TCP"}, s.EndpointSlices[0].Ports)
+	assert.Equal(t, "web-a", s.EndpointSlices[0].Endpoints[0].Pod)
+	assert.True(t, s.EndpointSlices[0].Endpoints[0].Ready)
+	assert.False(t, s.EndpointSlices[0].Endpoints[1].Ready)
+
+	assert.Len(t, s.Findings, 2)
+	assert.Equal(t, SeverityCritical, s.Findings[0].Severity)
+	assert.Equal(t, "TargetPortNotFound", s.Findings[0].Reason)
+	assert.Equal(t, `port admin targets the named port "admin", which no selected container declares`, s.Findings[0].Message)
+	assert.Equal(t, SeverityWarning, s.Findings[1].Severity)
+	assert.Equal(t, "TargetPortNotDeclared", s.Findings[1].Reason)
+}
+
+func TestCachedServicesEndpoints(t *testing.T) {
+	c := setupCachedClient(t, serviceTestObjects()...)
+
+	s, _, err := c.Services(ServiceOptions{
+		Logger:     &logfakes.Logger{},
+		Namespace:  "testns",
+		LinkedName: "web",
+		Context:    context.Background(),
+	})
+
+	assert.Nil(t, err)
+	assert.Len(t, s, 1)
+	assert.Len(t, s[0].Pods, 2)
+	assert.Len(t, s[0].EndpointSlices, 1)
+}
+
+func TestServiceFindings(t *testing.T) {
+	svc := &v1.Service{Spec: v1.ServiceSpec{
+		Selector: map[string]string{"tier": "web"},
+		Ports:    []v1.ServicePort{{Name: "http", Port: 80, TargetPort: intstr.FromInt(8080)}},
+	}}
+
+	findings := serviceFindings(svc, nil, 0)
+
+	assert.Len(t, findings, 1)
+	assert.Equal(t, "NoMatchingPods", findings[0].Reason)
+	assert.Equal(t, "the selector tier=web matches no pods", findings[0].Message)
+
+	findings = serviceFindings(svc, []v1.Pod{*serviceTestPod("web-a", false)}, 0)
+
+	assert.Len(t, findings, 1)
+	assert.Equal(t, "NoReadyPods", findings[0].Reason)
+	assert.Equal(t, "none of the 1 selected pods are ready", findings[0].Message)
+
+	// UDP doesn't match the TCP container port
+	svc.Spec.Ports[0].Protocol = v1.ProtocolUDP
+	findings = serviceFindings(svc, []v1.Pod{*serviceTestPod("web-a", true)}, 1)
+
+	assert.Len(t, findings, 1)
+	assert.Equal(t, "TargetPortNotDeclared", findings[0].Reason)
+}
+
+func TestServiceResolvePodsListFailed(t *testing.T) {
+	svc := serviceTestObjects()[0].(*v1.Service)
+	overview := &ServiceOverview{Service: svc}
+
+	serviceBackends{podsErr: errors.New("pods is forbidden")}.resolve(overview)
+
+	assert.Nil(t, overview.Pods)
+	assert.Equal(t, []Finding{{
+		Severity: SeverityWarning,
+		Reason:   "ResolutionFailed",
+		Message:  "the pods of the service can't be listed: pods is forbidden",
+	}}, overview.Findings)
+}
+
+func TestServiceWithoutSelector(t *testing.T) {
+	c := setupFakeClient(
+		&v1.Service{ObjectMeta: metav1.ObjectMeta{Name: "external", Namespace: "testns"}},
+	)
+
+	s, err := c.Service(ServiceOptions{
+		Logger:    &logfakes.Logger{},
+		Namespace: "testns",
+		Name:      "external",
+		Context:   context.Background(),
+	})
+
+	assert.Nil(t, err)
+	assert.Nil(t, s.Pods)
+	assert.Empty(t, s.Findings)
+}
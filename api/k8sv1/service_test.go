@@ -11,7 +11,7 @@ import (
 func TestGetServicesDefaultSuccess(t *testing.T) {
 	c := setupClient("test", "svc1", false, false)
 
-	s, err := c.Services(ServiceOptions{
+	s, _, err := c.Services(ServiceOptions{
 		Logger:     &logfakes.Logger{},
 		Namespace:  "test",
 		LinkedName: "svc1",
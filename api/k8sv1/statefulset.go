@@ -0,0 +1,244 @@
+package k8sv1
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kubelens/kubelens/api/errs"
+	klog "github.com/kubelens/kubelens/api/log"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// StatefulSetOptions contains fields used for filtering when retrieving stateful sets
+type StatefulSetOptions struct {
+	// the name of the statefulSet
+	Name string `json:"name"`
+	// the value of the first link label the object has, e.g. "app=NAME", see config.LinkKeys
+	LinkedName string `json:"linkedName"`
+	// namespace to filter on
+	Namespace string `json:"namespace"`
+	// selectors and pagination narrowing a list
+	ListOptions
+	// logger instance
+	Logger klog.Logger
+	// Context .
+	Context context.Context
+}
+
+// StatefulSetOverview .
+type StatefulSetOverview struct {
+	// the name
+	Name string `json:"name"`
+	// the value of the first link label the object has, e.g. "app=NAME", see config.LinkKeys
+	LinkedName string `json:"linkedName"`
+	// the namespace
+	Namespace string `json:"namespace"`
+	// the volume claim templates and the claims created from them
+	VolumeClaims []VolumeClaimOverview `json:"volumeClaims,omitempty"`
+	// the evaluated health
+	Health *Health `json:"health,omitempty"`
+	// the number of fields redacted, see config.Redaction
+	Redacted int `json:"redacted,omitempty"`
+	// the full statefulset
+	StatefulSet *appsv1.StatefulSet `json:"statefulSet,omitempty"`
+}
+
+// VolumeClaimOverview is a volume claim template of a statefulset
+// and the claims created from it for each replica.
+type VolumeClaimOverview struct {
+	// the name of the template
+	Template string `json:"template"`
+	// the storage class requested by the template
+	StorageClass string `json:"storageClass,omitempty"`
+	// the access modes requested by the template
+	AccessModes []v1.PersistentVolumeAccessMode `json:"accessModes,omitempty"`
+	// the storage requested by the template
+	Storage string `json:"storage,omitempty"`
+	// the claims created from the template, named "<template>-<statefulset>-<ordinal>".
+	// claims for ordinals >= replicas are left behind after scaling down.
+	Claims []VolumeClaim `json:"claims,omitempty"`
+}
+
+// VolumeClaim is a persistent volume claim created from a volume claim template.
+type VolumeClaim struct {
+	// the name of the claim
+	Name string `json:"name"`
+	// the ordinal of the replica the claim was created for
+	Ordinal int `json:"ordinal"`
+	// Pending, Bound or Lost
+	Phase v1.PersistentVolumeClaimPhase `json:"phase"`
+	// the name of the bound persistent volume
+	VolumeName string `json:"volumeName,omitempty"`
+	// the actual storage of the bound volume
+	Capacity string `json:"capacity,omitempty"`
+}
+
+// StatefulSet returns a statefulset given filter options
+func (k *Client) StatefulSet(options StatefulSetOptions) (overview *StatefulSetOverview, apiErr *errs.APIError) {
+	list, err := k.listStatefulSets(options.Context, options.Namespace, metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("metadata.name=%s", options.Name),
+	})
+
+	if err != nil {
+		klog.Trace()
+		return nil, errs.InternalServerError(err.Error())
+	}
+
+	if list != nil && len(list.Items) > 0 {
+		claims := k.statefulSetClaims(options.Context, list.Items[0].Namespace)
+
+		for _, item := range list.Items {
+			return &StatefulSetOverview{
+				Name:         item.Name,
+				LinkedName:   getLinkedName(item.Namespace, item.Labels),
+				Namespace:    item.Namespace,
+				VolumeClaims: volumeClaims(&item, claims),
+				Health:       statefulSetHealth(&item),
+				Redacted:     redact(&item),
+				StatefulSet:  &item,
+			}, nil
+		}
+	}
+	return overview, nil
+}
+
+// StatefulSets returns a list of statefulsets given filter options
+func (k *Client) StatefulSets(options StatefulSetOptions) (overviews []StatefulSetOverview, meta ListMeta, apiErr *errs.APIError) {
+	overviews = []StatefulSetOverview{}
+
+	lo, apiErr := options.listOptions(objectMetaFields(metav1.ObjectMeta{}))
+
+	if apiErr != nil {
+		return nil, meta, apiErr
+	}
+
+	obj, err := listLinked(options.LinkedName, lo, func(lo metav1.ListOptions) (runtime.Object, error) {
+		return k.listStatefulSets(options.Context, options.Namespace, lo)
+	})
+
+	if err != nil {
+		klog.Trace()
+		return nil, meta, errs.InternalServerError(err.Error())
+	}
+
+	list := obj.(*appsv1.StatefulSetList)
+
+	if list != nil && len(list.Items) > 0 {
+		claims := k.statefulSetClaims(options.Context, options.Namespace)
+
+		for _, item := range list.Items {
+			// the overview keeps a pointer to item
+			item := item
+
+			overviews = append(overviews, StatefulSetOverview{
+				Name:         item.Name,
+				LinkedName:   getLinkedName(item.Namespace, item.Labels),
+				Namespace:    item.Namespace,
+				VolumeClaims: volumeClaims(&item, claims),
+				Health:       statefulSetHealth(&item),
+				Redacted:     redact(&item),
+				StatefulSet:  &item,
+			})
+		}
+	}
+	return overviews, newListMeta(list.ListMeta), nil
+}
+
+// statefulSetClaims lists the persistent volume claims in a namespace. Claims are
+// informational, so if they can't be listed the statefulsets are returned without them.
+func (k *Client) statefulSetClaims(ctx context.Context, namespace string) []v1.PersistentVolumeClaim {
+	list, err := k.listPersistentVolumeClaims(ctx, namespace, metav1.ListOptions{})
+
+	if err != nil {
+		klog.Trace()
+		return nil
+	}
+
+	return list.Items
+}
+
+// volumeClaims matches the claims created by the statefulset controller
+// to the statefulset's volume claim templates.
+func volumeClaims(sts *appsv1.StatefulSet, claims []v1.PersistentVolumeClaim) (overviews []VolumeClaimOverview) {
+	for _, tmpl := range sts.Spec.VolumeClaimTemplates {
+		overview := VolumeClaimOverview{
+			Template:    tmpl.Name,
+			AccessModes: tmpl.Spec.AccessModes,
+			Claims:      []VolumeClaim{},
+		}
+
+		if tmpl.Spec.StorageClassName != nil {
+			overview.StorageClass = *tmpl.Spec.StorageClassName
+		}
+
+		if storage, ok := tmpl.Spec.Resources.Requests[v1.ResourceStorage]; ok {
+			overview.Storage = storage.String()
+		}
+
+		prefix := fmt.Sprintf("%s-%s-", tmpl.Name, sts.Name)
+
+		for _, claim := range claims {
+			if claim.Namespace != sts.Namespace || !strings.HasPrefix(claim.Name, prefix) {
+				continue
+			}
+
+			ordinal, err := strconv.Atoi(strings.TrimPrefix(claim.Name, prefix))
+
+			if err != nil || ordinal < 0 {
+				continue
+			}
+
+			vc := VolumeClaim{
+				Name:       claim.Name,
+				Ordinal:    ordinal,
+				Phase:      claim.Status.Phase,
+				VolumeName: claim.Spec.VolumeName,
+			}
+
+			if capacity, ok := claim.Status.Capacity[v1.ResourceStorage]; ok {
+				vc.Capacity = capacity.String()
+			}
+
+			overview.Claims = append(overview.Claims, vc)
+		}
+
+		overviews = append(overviews, overview)
+	}
+
+	return overviews
+}
+
+// listStatefulSets lists statefulsets from the cache when it has synced, otherwise from the api server.
+func (k *Client) listStatefulSets(ctx context.Context, namespace string, lo metav1.ListOptions) (*appsv1.StatefulSetList, error) {
+	if k.cache.hasSynced(kindStatefulSets) {
+		return k.cache.listStatefulSets(namespace, lo)
+	}
+
+	clientset, err := k.wrapper.GetClientSet()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return clientset.AppsV1().StatefulSets(namespace).List(ctx, lo)
+}
+
+// listPersistentVolumeClaims lists persistentvolumeclaims from the cache when it has synced, otherwise from the api server.
+func (k *Client) listPersistentVolumeClaims(ctx context.Context, namespace string, lo metav1.ListOptions) (*v1.PersistentVolumeClaimList, error) {
+	if k.cache.hasSynced(kindPersistentVolumeClaims) {
+		return k.cache.listPersistentVolumeClaims(namespace, lo)
+	}
+
+	clientset, err := k.wrapper.GetClientSet()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return clientset.CoreV1().PersistentVolumeClaims(namespace).List(ctx, lo)
+}
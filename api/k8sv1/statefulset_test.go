@@ -0,0 +1,157 @@
+package k8sv1
+
+import (
+	"context"
+	"testing"
+
+	logfakes "github.com/kubelens/kubelens/api/log/fakes"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func statefulSetTestObjects() []runtime.Object {
+	storageClass := "standard"
+
+	return []runtime.Object{
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "testns", Labels: map[string]string{"app": "db"}},
+			Spec: appsv1.StatefulSetSpec{
+				VolumeClaimTemplates: []v1.PersistentVolumeClaim{
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "data"},
+						Spec: v1.PersistentVolumeClaimSpec{
+							StorageClassName: &storageClass,
+							AccessModes:      []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce},
+							Resources: v1.ResourceRequirements{
+								Requests: v1.ResourceList{v1.ResourceStorage: resource.MustParse("1Gi")},
+							},
+						},
+					},
+				},
+			},
+		},
+		&v1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "data-db-0", Namespace: "testns"},
+			Spec:       v1.PersistentVolumeClaimSpec{VolumeName: "pv-0"},
+			Status: v1.PersistentVolumeClaimStatus{
+				Phase:    v1.ClaimBound,
+				Capacity: v1.ResourceList{v1.ResourceStorage: resource.MustParse("2Gi")},
+			},
+		},
+		&v1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "data-db-1", Namespace: "testns"},
+			Status:     v1.PersistentVolumeClaimStatus{Phase: v1.ClaimPending},
+		},
+		// not created from the template
+		&v1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "data-db-backup", Namespace: "testns"}},
+		&v1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "data-db-0", Namespace: "other"}},
+	}
+}
+
+func TestStatefulSetsSuccess(t *testing.T) {
+	c := setupFakeClient(statefulSetTestObjects()...)
+
+	s, _, err := c.StatefulSets(StatefulSetOptions{
+		Logger:     &logfakes.Logger{},
+		Namespace:  "testns",
+		LinkedName: "db",
+		Context:    context.Background(),
+	})
+
+	assert.Nil(t, err)
+	assert.Len(t, s, 1)
+	assert.Equal(t, "db", s[0].LinkedName)
+	assert.Len(t, s[0].VolumeClaims, 1)
+
+	vc := s[0].VolumeClaims[0]
+	assert.Equal(t, "data", vc.Template)
+	assert.Equal(t, "standard", vc.StorageClass)
+	assert.Equal(t, "1Gi", vc.Storage)
+	assert.Equal(t, []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce}, vc.AccessModes)
+	assert.Equal(t, []VolumeClaim{
+		{Name: "data-db-0", Ordinal: 0, Phase: v1.ClaimBound, VolumeName: "pv-0", Capacity: "2Gi"},
+		{Name: "data-db-1", Ordinal: 1, Phase: v1.ClaimPending},
+	}, vc.Claims)
+}
+
+func TestStatefulSetsFail(t *testing.T) {
+	c := setupClient("testns", "sttest1", true, true)
+
+	_, _, err := c.StatefulSets(StatefulSetOptions{
+		Logger:     &logfakes.Logger{},
+		Namespace:  "testns",
+		LinkedName: "sttest1",
+		Context:    context.Background(),
+	})
+
+	assert.NotNil(t, err)
+}
+
+func TestStatefulSetSuccess(t *testing.T) {
+	c := setupFakeClient(statefulSetTestObjects()...)
+
+	s, err := c.StatefulSet(StatefulSetOptions{
+		Logger:    &logfakes.Logger{},
+		Namespace: "testns",
+		Name:      "db",
+		Context:   context.Background(),
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "db", s.Name)
+	assert.Len(t, s.VolumeClaims[0].Claims, 2)
+}
+
+func TestStatefulSetCached(t *testing.T) {
+	c := setupCachedClient(t, statefulSetTestObjects()...)
+
+	s, err := c.StatefulSet(StatefulSetOptions{
+		Logger:    &logfakes.Logger{},
+		Namespace: "testns",
+		Name:      "db",
+		Context:   context.Background(),
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "db", s.Name)
+	assert.Len(t, s.VolumeClaims[0].Claims, 2)
+}
+
+func TestStatefulSetFail(t *testing.T) {
+	c := setupClient("testns", "sttest2", true, true)
+
+	_, err := c.StatefulSet(StatefulSetOptions{
+		Logger:    &logfakes.Logger{},
+		Namespace: "testns",
+		Name:      "sttest2",
+		Context:   context.Background(),
+	})
+
+	assert.NotNil(t, err)
+}
+
+func TestStatefulSetsMany(t *testing.T) {
+	c := setupCachedClient(t,
+		&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "cache", Namespace: "testns"}},
+		&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "testns"}},
+	)
+
+	s, _, err := c.StatefulSets(StatefulSetOptions{
+		Logger:    &logfakes.Logger{},
+		Namespace: "testns",
+		Context:   context.Background(),
+	})
+
+	assert.Nil(t, err)
+
+	names := []string{}
+	for _, o := range s {
+		names = append(names, o.StatefulSet.Name)
+	}
+
+	assert.ElementsMatch(t, []string{"cache", "db"}, names)
+}
@@ -0,0 +1,256 @@
+package k8sv1
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// Summarizer is an overview with a summary view.
+type Summarizer interface {
+	Summary() Summary
+}
+
+// Summary is the summary view of an object, the columns kubectl get shows.
+type Summary struct {
+	// the kind of the object
+	Kind string `json:"kind"`
+	// the name
+	Name string `json:"name"`
+	// the namespace, empty for nodes
+	Namespace string `json:"namespace,omitempty"`
+	// the value of the first link label the object has, e.g. "app=NAME", see config.LinkKeys
+	LinkedName string `json:"linkedName,omitempty"`
+	// the evaluated health, or the status of a pod or node
+	Status string `json:"status,omitempty"`
+	// ready out of desired, e.g. 2/3, pods or containers depending on the kind
+	Ready string `json:"ready,omitempty"`
+	// the restarts of the containers of a pod
+	Restarts *int32 `json:"restarts,omitempty"`
+	// how long ago the object was created, e.g. 3d4h
+	Age string `json:"age,omitempty"`
+	// the images of the containers
+	Images []string `json:"images,omitempty"`
+}
+
+// Summary returns the summary view of a pod.
+func (o PodOverview) Summary() Summary {
+	s := newSummary("Pod", o.Name, o.Namespace, o.LinkedName, nil)
+
+	if o.Pod == nil {
+		return s
+	}
+
+	ready, restarts := 0, int32(0)
+	for _, cs := range o.Pod.Status.ContainerStatuses {
+		if cs.Ready {
+			ready++
+		}
+		restarts += cs.RestartCount
+	}
+
+	s.Status = podStatus(o.Pod)
+	s.Age = age(o.Pod.CreationTimestamp)
+	s.Ready = fmt.Sprintf("%d/%d", ready, len(o.Pod.Spec.Containers))
+	s.Restarts = &restarts
+	s.Images = images(&o.Pod.Spec)
+
+	return s
+}
+
+// Summary returns the summary view of a deployment.
+func (o DeploymentOverview) Summary() Summary {
+	s := newSummary("Deployment", o.Name, o.Namespace, o.LinkedName, o.Health)
+
+	if d := o.Deployment; d != nil {
+		s.Age = age(d.CreationTimestamp)
+		s.Ready = fmt.Sprintf("%d/%d", d.Status.ReadyReplicas, replicas(d.Spec.Replicas))
+		s.Images = images(&d.Spec.Template.Spec)
+	}
+
+	return s
+}
+
+// Summary returns the summary view of a daemonset.
+func (o DaemonSetOverview) Summary() Summary {
+	s := newSummary("DaemonSet", o.Name, o.Namespace, o.LinkedName, o.Health)
+
+	if ds := o.DaemonSet; ds != nil {
+		s.Age = age(ds.CreationTimestamp)
+		s.Ready = fmt.Sprintf("%d/%d", ds.Status.NumberReady, ds.Status.DesiredNumberScheduled)
+		s.Images = images(&ds.Spec.Template.Spec)
+	}
+
+	return s
+}
+
+// Summary returns the summary view of a statefulset.
+func (o StatefulSetOverview) Summary() Summary {
+	s := newSummary("StatefulSet", o.Name, o.Namespace, o.LinkedName, o.Health)
+
+	if sts := o.StatefulSet; sts != nil {
+		s.Age = age(sts.CreationTimestamp)
+		s.Ready = fmt.Sprintf("%d/%d", sts.Status.ReadyReplicas, replicas(sts.Spec.Replicas))
+		s.Images = images(&sts.Spec.Template.Spec)
+	}
+
+	return s
+}
+
+// Summary returns the summary view of a replicaset.
+func (o ReplicaSetOverview) Summary() Summary {
+	s := newSummary("ReplicaSet", o.Name, o.Namespace, o.LinkedName, o.Health)
+
+	if rs := o.ReplicaSet; rs != nil {
+		s.Age = age(rs.CreationTimestamp)
+		s.Ready = fmt.Sprintf("%d/%d", rs.Status.ReadyReplicas, replicas(rs.Spec.Replicas))
+		s.Images = images(&rs.Spec.Template.Spec)
+	}
+
+	return s
+}
+
+// Summary returns the summary view of a job, ready is the succeeded out of the required completions.
+func (o JobOverview) Summary() Summary {
+	s := newSummary("Job", o.Name, o.Namespace, o.LinkedName, o.Health)
+
+	if j := o.Job; j != nil {
+		s.Age = age(j.CreationTimestamp)
+		s.Ready = fmt.Sprintf("%d/%d", j.Status.Succeeded, replicas(j.Spec.Completions))
+		s.Images = images(&j.Spec.Template.Spec)
+	}
+
+	return s
+}
+
+// Summary returns the summary view of a cronjob.
+func (o CronJobOverview) Summary() Summary {
+	s := newSummary("CronJob", o.Name, o.Namespace, o.LinkedName, o.Health)
+
+	if cj := o.CronJob; cj != nil {
+		s.Age = age(cj.CreationTimestamp)
+		s.Images = images(&cj.Spec.JobTemplate.Spec.Template.Spec)
+	}
+
+	return s
+}
+
+// Summary returns the summary view of a service, ready is the ready pods out of the selected pods.
+func (o ServiceOverview) Summary() Summary {
+	s := newSummary("Service", o.Name, o.Namespace, o.LinkedName, nil)
+
+	if o.Service != nil {
+		s.Age = age(o.Service.CreationTimestamp)
+	}
+
+	if o.Pods != nil {
+		ready := 0
+		for _, p := range o.Pods {
+			if p.Ready {
+				ready++
+			}
+		}
+		s.Ready = fmt.Sprintf("%d/%d", ready, len(o.Pods))
+	}
+
+	return s
+}
+
+// Summary returns the summary view of a configmap.
+func (o ConfigMapOverview) Summary() Summary {
+	s := newSummary("ConfigMap", o.Name, o.Namespace, o.LinkedName, nil)
+
+	if o.ConfigMap != nil {
+		s.Age = age(o.ConfigMap.CreationTimestamp)
+	}
+
+	return s
+}
+
+// Summary returns the summary view of a node, the status is the one kubectl get nodes shows.
+func (o NodeOverview) Summary() Summary {
+	s := newSummary("Node", o.Name, "", "", nil)
+
+	if o.Node != nil {
+		s.Age = age(o.Node.CreationTimestamp)
+	}
+
+	s.Status = "NotReady"
+	for _, c := range o.Conditions {
+		if c.Type == v1.NodeReady && c.Status == v1.ConditionTrue {
+			s.Status = "Ready"
+		}
+	}
+	if o.Unschedulable {
+		s.Status += ",SchedulingDisabled"
+	}
+
+	return s
+}
+
+func newSummary(kind, name, namespace, linkedName string, health *Health) Summary {
+	s := Summary{
+		Kind:       kind,
+		Name:       name,
+		Namespace:  namespace,
+		LinkedName: linkedName,
+	}
+
+	if health != nil {
+		s.Status = string(health.Status)
+	}
+
+	return s
+}
+
+// age returns how long ago an object was created, empty when it's unknown.
+func age(created metav1.Time) string {
+	if created.IsZero() {
+		return ""
+	}
+	return duration.HumanDuration(now().Sub(created.Time))
+}
+
+// podStatus returns the status kubectl get pods shows, the reason a container
+// isn't running when there is one, otherwise the phase.
+func podStatus(pod *v1.Pod) string {
+	if pod.DeletionTimestamp != nil {
+		return "Terminating"
+	}
+
+	for _, statuses := range [][]v1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+		for _, cs := range statuses {
+			if w := cs.State.Waiting; w != nil && len(w.Reason) > 0 {
+				return w.Reason
+			}
+			if t := cs.State.Terminated; t != nil && len(t.Reason) > 0 && t.Reason != "Completed" {
+				return t.Reason
+			}
+		}
+	}
+
+	if len(pod.Status.Reason) > 0 {
+		return pod.Status.Reason
+	}
+
+	return string(pod.Status.Phase)
+}
+
+// images returns the images of the containers of a pod spec.
+func images(spec *v1.PodSpec) []string {
+	images := []string{}
+	for _, c := range spec.Containers {
+		images = append(images, c.Image)
+	}
+	return images
+}
+
+// replicas returns the desired replicas, which default to 1.
+func replicas(r *int32) int32 {
+	if r == nil {
+		return 1
+	}
+	return *r
+}
@@ -0,0 +1,67 @@
+package k8sv1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var summaryTestTime = time.Date(2021, 6, 3, 12, 0, 0, 0, time.UTC)
+
+func TestPodSummary(t *testing.T) {
+	now = func() time.Time { return summaryTestTime }
+	t.Cleanup(func() { now = time.Now })
+
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-1", CreationTimestamp: metav1.NewTime(summaryTestTime.Add(-50 * time.Hour))},
+		Spec:       v1.PodSpec{Containers: []v1.Container{{Image: "web:1"}, {Image: "proxy:2"}}},
+		Status: v1.PodStatus{
+			Phase: v1.PodRunning,
+			ContainerStatuses: []v1.ContainerStatus{
+				{Ready: true, RestartCount: 1},
+				{RestartCount: 4, State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}},
+			},
+		},
+	}
+
+	s := PodOverview{Name: "web-1", Namespace: "one", LinkedName: "web", Pod: pod}.Summary()
+
+	assert.Equal(t, "Pod", s.Kind)
+	assert.Equal(t, "CrashLoopBackOff", s.Status)
+	assert.Equal(t, "1/2", s.Ready)
+	assert.Equal(t, int32(5), *s.Restarts)
+	assert.Equal(t, "2d2h", s.Age)
+	assert.Equal(t, []string{"web:1", "proxy:2"}, s.Images)
+}
+
+func TestDeploymentSummary(t *testing.T) {
+	three := int32(3)
+
+	s := DeploymentOverview{
+		Name:   "web",
+		Health: newHealth(HealthProgressing),
+		Deployment: &appsv1.Deployment{
+			Spec:   appsv1.DeploymentSpec{Replicas: &three},
+			Status: appsv1.DeploymentStatus{ReadyReplicas: 2},
+		},
+	}.Summary()
+
+	assert.Equal(t, string(HealthProgressing), s.Status)
+	assert.Equal(t, "2/3", s.Ready)
+	assert.Nil(t, s.Restarts)
+	assert.Empty(t, s.Age)
+}
+
+func TestNodeSummary(t *testing.T) {
+	s := NodeOverview{
+		Name:          "node-1",
+		Unschedulable: true,
+		Conditions:    []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionTrue}},
+	}.Summary()
+
+	assert.Equal(t, "Ready,SchedulingDisabled", s.Status)
+}
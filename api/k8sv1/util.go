@@ -26,28 +26,159 @@ package k8sv1
 
 import (
 	"fmt"
-	"strings"
+	"sort"
 
 	"github.com/kubelens/kubelens/api/config"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
-func getLinkedName(labels map[string]string) string {
-	return labels[config.C.LabelKeyLink]
+// getLinkedName returns the value of the first link label key set on an object,
+// see config.LinkKeys.
+func getLinkedName(namespace string, labels map[string]string) string {
+	if key := linkKey(namespace, labels); len(key) > 0 {
+		return labels[key]
+	}
+	return ""
+}
+
+// linkKey returns the first link label key set on an object.
+func linkKey(namespace string, labels map[string]string) string {
+	for _, key := range config.C.LinkKeys(namespace) {
+		if v := labels[key]; len(v) > 0 {
+			return key
+		}
+	}
+	return ""
 }
 
-func generateLabelSelector(value string) string {
-	return fmt.Sprintf("%s=%s", config.C.LabelKeyLink, value)
+// generateLabelSelectors returns a label selector per link label key, in any namespace,
+// that selects objects linked to value.
+func generateLabelSelectors(value string) []string {
+	selectors := []string{}
+	for _, key := range config.C.AllLinkKeys() {
+		selectors = append(selectors, fmt.Sprintf("%s=%s", key, value))
+	}
+	return selectors
 }
 
-// Hard coded check for now, but there really shouldn't be any
-// secrets exposed unless the application has them stored in an
-// insecure way such as a configMap or through environment variables.
-func stringContainsSensitiveInfo(toCheck string) bool {
-	l := strings.ToLower(toCheck)
-	if strings.Contains(l, "pass") ||
-		strings.Contains(l, "key") ||
-		strings.Contains(l, "secret") {
-		return true
-	}
-	return false
+// listLinked lists the objects linked to linkedName, or every object if it's empty.
+// Label selectors can't express "the first of these keys set equals value", so list is
+// called once per link label key, keeping only the objects that key links to linkedName.
+// With a limit, a page only lists one key and its continue token says which key, and
+// where in it, the next page carries on from.
+func listLinked(linkedName string, lo metav1.ListOptions, list func(lo metav1.ListOptions) (runtime.Object, error)) (runtime.Object, error) {
+	token, err := decodeContinue(lo.Continue)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []string{""}
+	if len(linkedName) > 0 {
+		keys = config.C.AllLinkKeys()
+	}
+
+	var result runtime.Object
+	var next *continueToken
+	var remaining *int64
+	items := []runtime.Object{}
+
+	for i := token.Key; i < len(keys); i++ {
+		l := lo
+		l.Continue = ""
+		if i == token.Key {
+			l.Continue = token.Continue
+		}
+
+		if len(keys[i]) > 0 {
+			selector := fmt.Sprintf("%s=%s", keys[i], linkedName)
+			if len(l.LabelSelector) > 0 {
+				selector = fmt.Sprintf("%s,%s", l.LabelSelector, selector)
+			}
+			l.LabelSelector = selector
+		}
+
+		obj, err := list(l)
+		if err != nil {
+			return nil, err
+		}
+
+		objects, err := apimeta.ExtractList(obj)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, o := range objects {
+			m, err := apimeta.Accessor(o)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(keys[i]) == 0 || linkKey(m.GetNamespace(), m.GetLabels()) == keys[i] {
+				items = append(items, o)
+			}
+		}
+
+		if result == nil {
+			result = obj
+		}
+
+		if lo.Limit > 0 {
+			m, err := apimeta.ListAccessor(obj)
+			if err != nil {
+				return nil, err
+			}
+
+			if c := m.GetContinue(); len(c) > 0 {
+				next = &continueToken{Key: i, Continue: c}
+			} else if i+1 < len(keys) {
+				next = &continueToken{Key: i + 1}
+			}
+
+			// only the last key knows how many items are left
+			if i == len(keys)-1 {
+				remaining = m.GetRemainingItemCount()
+			}
+			break
+		}
+	}
+
+	// no link label keys are configured, nothing can be linked.
+	if result == nil {
+		l := lo
+		l.Continue = ""
+
+		obj, err := list(l)
+		if err != nil {
+			return nil, err
+		}
+		result = obj
+	}
+
+	// the same order as the api server
+	if len(keys) > 1 && lo.Limit == 0 {
+		sort.SliceStable(items, func(i, j int) bool {
+			a, _ := apimeta.Accessor(items[i])
+			b, _ := apimeta.Accessor(items[j])
+			if a.GetNamespace() != b.GetNamespace() {
+				return a.GetNamespace() < b.GetNamespace()
+			}
+			return a.GetName() < b.GetName()
+		})
+	}
+
+	if err := apimeta.SetList(result, items); err != nil {
+		return nil, err
+	}
+
+	m, err := apimeta.ListAccessor(result)
+	if err != nil {
+		return nil, err
+	}
+
+	m.SetContinue(encodeContinue(next))
+	m.SetRemainingItemCount(remaining)
+
+	return result, nil
 }
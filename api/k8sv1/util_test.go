@@ -1,27 +1,127 @@
 package k8sv1
 
 import (
+	"context"
 	"testing"
 
+	"github.com/kubelens/kubelens/api/config"
+	logfakes "github.com/kubelens/kubelens/api/log/fakes"
 	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
-func TestGenerateLabelSelector(t *testing.T) {
-	selector := generateLabelSelector("value")
+func TestGenerateLabelSelectors(t *testing.T) {
+	config.Set("../testdata/mock_config.json")
 
-	assert.Equal(t, "app=value", selector)
+	selectors := generateLabelSelectors("value")
+
+	assert.Equal(t, []string{"app=value"}, selectors)
+}
+
+// setLinkKeys configures the link label keys for the duration of a test.
+func setLinkKeys(t *testing.T, keys []string, overrides map[string][]string) {
+	config.Set("../testdata/mock_config.json")
+
+	config.C.LabelKeysLink = keys
+	config.C.NamespaceLabelKeysLink = overrides
+
+	t.Cleanup(func() {
+		config.C.LabelKeysLink = nil
+		config.C.NamespaceLabelKeysLink = nil
+	})
+}
+
+func linkTestObjects() []runtime.Object {
+	return []runtime.Object{
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "one", Labels: map[string]string{"app": "web"}}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "one", Labels: map[string]string{"app.kubernetes.io/name": "web"}}},
+		// the first key set wins, this is "api" even though "app" is web
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "c", Namespace: "one", Labels: map[string]string{"app.kubernetes.io/name": "api", "app": "web"}}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "d", Namespace: "kube-system", Labels: map[string]string{"k8s-app": "web"}}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "e", Namespace: "kube-system", Labels: map[string]string{"app": "web"}}},
+	}
+}
+
+func TestGetLinkedName(t *testing.T) {
+	setLinkKeys(t, []string{"app.kubernetes.io/name", "app"}, map[string][]string{"kube-system": {"k8s-app"}})
+
+	assert.Equal(t, "web", getLinkedName("one", map[string]string{"app": "web"}))
+	assert.Equal(t, "api", getLinkedName("one", map[string]string{"app": "web", "app.kubernetes.io/name": "api"}))
+	assert.Equal(t, "", getLinkedName("kube-system", map[string]string{"app": "web"}))
+	assert.Equal(t, "dns", getLinkedName("kube-system", map[string]string{"k8s-app": "dns"}))
+}
+
+func TestListLinked(t *testing.T) {
+	c := setupFakeClient(linkTestObjects()...)
+
+	setLinkKeys(t, []string{"app.kubernetes.io/name", "app"}, map[string][]string{"kube-system": {"k8s-app"}})
+
+	d, _, err := c.Deployments(DeploymentOptions{
+		Logger:     &logfakes.Logger{},
+		LinkedName: "web",
+		Context:    context.Background(),
+	})
+
+	assert.Nil(t, err)
+
+	names := []string{}
+	for _, dp := range d {
+		names = append(names, dp.Namespace+"/"+dp.Name)
+	}
+
+	assert.Equal(t, []string{"kube-system/d", "one/a", "one/b"}, names)
+	assert.Equal(t, "web", d[0].LinkedName)
 }
 
-func TestStringContainsSensitiveInfo(t *testing.T) {
-	match := stringContainsSensitiveInfo("dbPassword")
-	assert.True(t, match)
+func TestListLinkedNoKeys(t *testing.T) {
+	c := setupFakeClient(linkTestObjects()...)
+
+	setLinkKeys(t, nil, nil)
+	config.C.LabelKeyLink = ""
+	t.Cleanup(func() { config.Set("../testdata/mock_config.json") })
+
+	d, _, err := c.Deployments(DeploymentOptions{
+		Logger:     &logfakes.Logger{},
+		LinkedName: "web",
+		Context:    context.Background(),
+	})
+
+	assert.Nil(t, err)
+	assert.Empty(t, d)
+}
+
+func TestListLinkedPages(t *testing.T) {
+	c := setupCachedClient(t, linkTestObjects()...)
+
+	setLinkKeys(t, []string{"app.kubernetes.io/name", "app"}, map[string][]string{"kube-system": {"k8s-app"}})
+
+	pages := [][]string{}
+	options := DeploymentOptions{
+		Logger:      &logfakes.Logger{},
+		LinkedName:  "web",
+		Context:     context.Background(),
+		ListOptions: ListOptions{Limit: 10},
+	}
+
+	for {
+		d, meta, err := c.Deployments(options)
+
+		assert.Nil(t, err)
 
-	match = stringContainsSensitiveInfo("apiKey")
-	assert.True(t, match)
+		page := []string{}
+		for _, o := range d {
+			page = append(page, o.Name)
+		}
+		pages = append(pages, page)
 
-	match = stringContainsSensitiveInfo("someSecret")
-	assert.True(t, match)
+		if len(meta.Continue) == 0 {
+			break
+		}
+		options.Continue = meta.Continue
+	}
 
-	match = stringContainsSensitiveInfo("random_thing")
-	assert.False(t, match)
+	// a page per link label key
+	assert.Equal(t, [][]string{{"b"}, {"a"}, {"d"}}, pages)
 }
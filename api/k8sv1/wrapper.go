@@ -25,26 +25,65 @@ SOFTWARE.
 package k8sv1
 
 import (
+	"os"
+	"sync"
+
+	"github.com/kubelens/kubelens/api/config"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	metrics "k8s.io/metrics/pkg/client/clientset/versioned"
+
+	// registers the gcp, azure, oidc & openstack auth-provider plugins
+	// so kubeconfig users relying on them can authenticate. exec plugins
+	// are handled by client-go directly.
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
 )
 
 // Wrapper interfaces wrap.
 type Wrapper interface {
 	GetClientSet() (clientset kubernetes.Interface, err error)
+	// GetMetricsClientSet returns a client for the metrics.k8s.io api served by metrics-server.
+	// Creating the client succeeds whether or not metrics-server is installed.
+	GetMetricsClientSet() (clientset metrics.Interface, err error)
 }
 
-type wrap struct{}
+type wrap struct {
+	mu        sync.Mutex
+	clientset kubernetes.Interface
+	metrics   metrics.Interface
+}
 
-// NewWrapper returns an instance of wrap
+// NewWrapper returns the Wrapper for the environment the api is running in.
+// If a kubeconfig path is set in config, or the api isn't running inside of
+// a pod, the kubeconfig wrapper is used, otherwise the in-cluster wrapper.
 func NewWrapper() Wrapper {
+	if len(config.C.KubeConfig) > 0 || !inCluster() {
+		return NewKubeConfigWrapper(config.C.KubeConfig, config.C.KubeContext)
+	}
 	return &wrap{}
 }
 
+// NewClusterWrapper returns the Wrapper for a cluster registered in config.Clusters.
+func NewClusterWrapper(c config.Cluster) Wrapper {
+	if c.InCluster {
+		return &wrap{}
+	}
+	return NewKubeConfigWrapper(c.KubeConfig, c.KubeContext)
+}
+
 // GetClientSet retrieves the client configuration for the k8 cluster
 // this is currently running in. This can only be called if running inside
 // a K8 cluster under a service account with appropriate permissions.
-func (wrap) GetClientSet() (clientset kubernetes.Interface, err error) {
+// The clientset is created once and reused for every call after.
+func (w *wrap) GetClientSet() (clientset kubernetes.Interface, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.clientset != nil {
+		return w.clientset, nil
+	}
+
 	// creates the in-cluster config
 	config, err := rest.InClusterConfig()
 	if err != nil {
@@ -52,5 +91,119 @@ func (wrap) GetClientSet() (clientset kubernetes.Interface, err error) {
 	}
 
 	// creates the clientset
-	return kubernetes.NewForConfig(config)
+	if w.clientset, err = kubernetes.NewForConfig(config); err != nil {
+		return nil, err
+	}
+
+	return w.clientset, nil
+}
+
+// GetMetricsClientSet retrieves the metrics client for the k8 cluster this is
+// currently running in, see GetClientSet.
+func (w *wrap) GetMetricsClientSet() (clientset metrics.Interface, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.metrics != nil {
+		return w.metrics, nil
+	}
+
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if w.metrics, err = metrics.NewForConfig(config); err != nil {
+		return nil, err
+	}
+
+	return w.metrics, nil
+}
+
+type kubeConfigWrap struct {
+	// path to the kubeconfig file, falls back to $KUBECONFIG then ~/.kube/config
+	path string
+	// context to use from the kubeconfig, falls back to current-context
+	context string
+
+	mu        sync.Mutex
+	clientset kubernetes.Interface
+	metrics   metrics.Interface
+}
+
+// NewKubeConfigWrapper returns an instance of kubeConfigWrap, which connects to
+// a cluster using a kubeconfig file rather than a service account. This allows
+// the api to run outside of a cluster, e.g. on a laptop against kind/minikube.
+func NewKubeConfigWrapper(path, context string) Wrapper {
+	return &kubeConfigWrap{
+		path:    path,
+		context: context,
+	}
+}
+
+// GetClientSet retrieves the client configuration from a kubeconfig file.
+// Any exec or auth-provider plugins configured for the user are honoured.
+// The clientset is created once and reused for every call after.
+func (k *kubeConfigWrap) GetClientSet() (clientset kubernetes.Interface, err error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k.clientset != nil {
+		return k.clientset, nil
+	}
+
+	config, err := k.restConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	// creates the clientset
+	if k.clientset, err = kubernetes.NewForConfig(config); err != nil {
+		return nil, err
+	}
+
+	return k.clientset, nil
+}
+
+// GetMetricsClientSet retrieves the metrics client from a kubeconfig file, see GetClientSet.
+func (k *kubeConfigWrap) GetMetricsClientSet() (clientset metrics.Interface, err error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k.metrics != nil {
+		return k.metrics, nil
+	}
+
+	config, err := k.restConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if k.metrics, err = metrics.NewForConfig(config); err != nil {
+		return nil, err
+	}
+
+	return k.metrics, nil
+}
+
+// restConfig loads the rest config for the context from the kubeconfig file.
+func (k *kubeConfigWrap) restConfig() (*rest.Config, error) {
+	// the default rules read $KUBECONFIG, then ~/.kube/config
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+
+	if len(k.path) > 0 {
+		rules.ExplicitPath = k.path
+	}
+
+	overrides := &clientcmd.ConfigOverrides{
+		CurrentContext: k.context,
+	}
+
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, overrides).ClientConfig()
+}
+
+// inCluster returns true if the api is running inside of a pod, determined the
+// same way rest.InClusterConfig does by the service env vars K8s injects.
+func inCluster() bool {
+	return len(os.Getenv("KUBERNETES_SERVICE_HOST")) > 0 && len(os.Getenv("KUBERNETES_SERVICE_PORT")) > 0
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"testing"
 	"time"
 
@@ -16,6 +17,8 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/tools/cache"
+	metrics "k8s.io/metrics/pkg/client/clientset/versioned"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
 )
 
 type mockWrapper struct {
@@ -25,6 +28,13 @@ type mockWrapper struct {
 	innerFail bool
 }
 
+func (m *mockWrapper) GetMetricsClientSet() (clientset metrics.Interface, err error) {
+	if m.fail {
+		return nil, errors.New("GetMetricsClientSet Test Error")
+	}
+	return metricsfake.NewSimpleClientset(), nil
+}
+
 func (m *mockWrapper) GetClientSet() (clientset kubernetes.Interface, err error) {
 	if m.fail {
 		return nil, errors.New("GetClientSet Test Error")
@@ -227,7 +237,7 @@ func (m *mockWrapper) GetClientSet() (clientset kubernetes.Interface, err error)
 			time.Sleep(100 * time.Millisecond)
 		}
 
-		a := &v1.Service{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+		a := &v1.Service{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: lbl}, Spec: v1.ServiceSpec{Selector: lbl}}
 		_, err = client.CoreV1().Services(namespace).Create(ctx, a, metav1.CreateOptions{})
 		if err != nil {
 			return nil, err
@@ -327,9 +337,60 @@ func (m *mockWrapper) GetClientSet() (clientset kubernetes.Interface, err error)
 }
 
 func TestGetClient(t *testing.T) {
+	// keep a local ~/.kube/config from being picked up.
+	os.Setenv("KUBECONFIG", "../testdata/missing_kubeconfig.yaml")
+	defer os.Unsetenv("KUBECONFIG")
+
 	w := NewWrapper()
 
 	_, err := w.GetClientSet()
 
 	assert.NotNil(t, err)
 }
+
+func TestKubeConfigWrapperCurrentContext(t *testing.T) {
+	w := NewKubeConfigWrapper("../testdata/mock_kubeconfig.yaml", "")
+
+	cs, err := w.GetClientSet()
+
+	assert.Nil(t, err)
+	assert.Equal(t, "127.0.0.1:6443", cs.CoreV1().RESTClient().Get().URL().Host)
+}
+
+func TestKubeConfigWrapperContextOverride(t *testing.T) {
+	w := NewKubeConfigWrapper("../testdata/mock_kubeconfig.yaml", "minikube")
+
+	cs, err := w.GetClientSet()
+
+	assert.Nil(t, err)
+	assert.Equal(t, "192.168.49.2:8443", cs.CoreV1().RESTClient().Get().URL().Host)
+}
+
+func TestKubeConfigWrapperEnvFallback(t *testing.T) {
+	os.Setenv("KUBECONFIG", "../testdata/mock_kubeconfig.yaml")
+	defer os.Unsetenv("KUBECONFIG")
+
+	w := NewKubeConfigWrapper("", "")
+
+	cs, err := w.GetClientSet()
+
+	assert.Nil(t, err)
+	assert.Equal(t, "127.0.0.1:6443", cs.CoreV1().RESTClient().Get().URL().Host)
+}
+
+func TestKubeConfigWrapperMissingContext(t *testing.T) {
+	w := NewKubeConfigWrapper("../testdata/mock_kubeconfig.yaml", "nope")
+
+	_, err := w.GetClientSet()
+
+	assert.NotNil(t, err)
+}
+
+func TestNewWrapperOutOfCluster(t *testing.T) {
+	os.Unsetenv("KUBERNETES_SERVICE_HOST")
+
+	w := NewWrapper()
+
+	_, ok := w.(*kubeConfigWrap)
+	assert.True(t, ok)
+}
@@ -44,11 +44,15 @@ import (
 // Hightest = 9 (slowest, most compression)
 const compression int = 1
 
-func setMiddleware(wsFactory io.SocketFactory, k8Client k8sv1.Clienter, next http.Handler) http.Handler {
+func setMiddleware(wsFactory io.SocketFactory, clusters k8sv1.Registry, next http.Handler) http.Handler {
 	logger := klog.NewMiddleware(logrus.New(), "kubelens-api")
 
 	// any route handler registered after this point will have auth
-	amw := kauth.SetMiddleware(websocketHandler(wsFactory, k8Client, next))
+	amw := kauth.SetMiddleware(websocketHandler(wsFactory, clusters, next))
+
+	// scope requests to a cluster before auth so /clusters/{cluster}/io/...
+	// and /clusters/{cluster}/health are handled the same as without the prefix.
+	cmw := clusterHandler(clusters, amw)
 
 	secOpts := secure.Options{
 		HostsProxyHeaders:     []string{"X-Forwarded-Host"},
@@ -73,16 +77,18 @@ func setMiddleware(wsFactory io.SocketFactory, k8Client k8sv1.Clienter, next htt
 	// gzip compression at level 1 will be fast, but not as compressed as up to 9.
 	// since I'm not sure how to handle pagination yet, speed might need to take a hit for
 	// larger datasets, but starting low for now to get some benefit.
-	return handlers.CompressHandlerLevel(securemw.Handler(logger.Set(amw)), compression)
+	return handlers.CompressHandlerLevel(securemw.Handler(logger.Set(cmw.ServeHTTP)), compression)
 }
 
-func websocketHandler(wsFactory io.SocketFactory, k8Client k8sv1.Clienter, next http.Handler) http.Handler {
+func websocketHandler(wsFactory io.SocketFactory, clusters k8sv1.Registry, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if strings.HasPrefix(r.URL.Path, "/io/") {
 			if r.Method != "GET" {
 				http.Error(w, fmt.Sprintf("%s - Websocket connection must be GET.", http.StatusText(http.StatusForbidden)), http.StatusForbidden)
 				return
 			}
+			// clusterHandler has already rejected unregistered clusters.
+			k8Client, _ := clusters.Get(k8sv1.ClusterFromContext(r.Context()))
 			// upgrade connection
 			w.Header().Set("Connection", "keep-alive")
 			wsFactory.Register(k8Client, w, r)
@@ -92,3 +98,34 @@ func websocketHandler(wsFactory io.SocketFactory, k8Client k8sv1.Clienter, next
 		next.ServeHTTP(w, r)
 	})
 }
+
+// clusterHandler scopes requests prefixed with /clusters/{cluster}/ to the named cluster. The prefix
+// is removed so the rest of the path is handled the same as a request to the default cluster.
+func clusterHandler(clusters k8sv1.Registry, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// "/clusters/{cluster}/pods/name" = []string{"", "clusters", "cluster", "pods/name"}
+		params := strings.SplitN(r.URL.Path, "/", 4)
+
+		if len(params) < 4 || params[1] != "clusters" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		name := params[2]
+
+		if _, ok := clusters.Get(name); !ok || len(name) == 0 {
+			http.Error(w, fmt.Sprintf("%s - Cluster %q is not registered.", http.StatusText(http.StatusNotFound), name), http.StatusNotFound)
+			return
+		}
+
+		// shallow copy the url so the original request is left untouched.
+		u := *r.URL
+		u.Path = "/" + params[3]
+		u.RawPath = ""
+
+		sr := r.WithContext(k8sv1.NewClusterContext(r.Context(), name))
+		sr.URL = &u
+
+		next.ServeHTTP(w, sr)
+	})
+}
@@ -10,10 +10,18 @@ import (
 	logfakes "github.com/kubelens/kubelens/api/log/fakes"
 
 	"github.com/kubelens/kubelens/api/config"
+	k8sv1 "github.com/kubelens/kubelens/api/k8sv1"
 	klog "github.com/kubelens/kubelens/api/log"
 	"github.com/stretchr/testify/assert"
 )
 
+func getClusters() k8sv1.Registry {
+	return k8sv1.NewRegistry(
+		k8sv1.Cluster{Name: "default", Client: &k8fakes.K8sV1{}},
+		k8sv1.Cluster{Name: "other", Client: &k8fakes.K8sV1{}},
+	)
+}
+
 func TestSetMiddleware(t *testing.T) {
 	config.C.EnableAuth = false
 	req := httptest.NewRequest("GET", "/io/", nil)
@@ -26,7 +34,7 @@ func TestSetMiddleware(t *testing.T) {
 		assert.Equal(t, "/io/", r.URL.Path)
 	})
 
-	mw := setMiddleware(&iofakes.SocketFactory{}, &k8fakes.K8sV1{}, tmw)
+	mw := setMiddleware(&iofakes.SocketFactory{}, getClusters(), tmw)
 
 	mw.ServeHTTP(w, req)
 }
@@ -43,6 +51,51 @@ func TestSetMiddlewareIncorrectSocketMethod(t *testing.T) {
 		assert.Equal(t, "/io/", r.URL.Path)
 	})
 
-	wsh := websocketHandler(&iofakes.SocketFactory{}, &k8fakes.K8sV1{}, tmw)
+	wsh := websocketHandler(&iofakes.SocketFactory{}, getClusters(), tmw)
 	wsh.ServeHTTP(w, req)
 }
+
+func TestClusterHandlerStripsPrefix(t *testing.T) {
+	req := httptest.NewRequest("GET", "/clusters/other/pods/test?namespace=default", nil)
+	w := httptest.NewRecorder()
+
+	called := false
+	tmw := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		assert.Equal(t, "/pods/test", r.URL.Path)
+		assert.Equal(t, "default", r.URL.Query().Get("namespace"))
+		assert.Equal(t, "other", k8sv1.ClusterFromContext(r.Context()))
+	})
+
+	clusterHandler(getClusters(), tmw).ServeHTTP(w, req)
+
+	assert.True(t, called)
+	assert.Equal(t, "/clusters/other/pods/test", req.URL.Path)
+}
+
+func TestClusterHandlerUnknownCluster(t *testing.T) {
+	req := httptest.NewRequest("GET", "/clusters/nope/pods", nil)
+	w := httptest.NewRecorder()
+
+	tmw := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Fail(t, "handler should not be called for an unknown cluster")
+	})
+
+	clusterHandler(getClusters(), tmw).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestClusterHandlerNoPrefix(t *testing.T) {
+	for _, path := range []string{"/clusters", "/pods/test"} {
+		req := httptest.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+
+		tmw := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, path, r.URL.Path)
+			assert.Equal(t, "", k8sv1.ClusterFromContext(r.Context()))
+		})
+
+		clusterHandler(getClusters(), tmw).ServeHTTP(w, req)
+	}
+}
@@ -52,13 +52,16 @@ func serve() {
 	}
 }
 
+// defaultCluster is the name of the cluster when none are configured.
+const defaultCluster = "default"
+
 // createServer creates the http server with middleware.
 func createServer(wsFactory io.SocketFactory) *http.Server {
 	rc := mux.NewRouter()
 
 	// v1 handlers
-	k8Client := k8sv1.New(k8sv1.NewWrapper())
-	creq := svc.New(k8Client)
+	clusters := newRegistry()
+	creq := svc.New(clusters)
 	creq.Register(rc)
 
 	address := fmt.Sprintf(":%v", config.C.ServerPort)
@@ -73,7 +76,7 @@ func createServer(wsFactory io.SocketFactory) *http.Server {
 			handlers.AllowedMethods(config.C.AllowedMethods),
 			handlers.AllowedHeaders(config.C.AllowedHeaders),
 			handlers.AllowedOrigins(config.C.AllowedOrigins),
-		)(setMiddleware(wsFactory, k8Client, rc)),
+		)(setMiddleware(wsFactory, clusters, rc)),
 	}
 
 	hostname, _ := os.Hostname()
@@ -82,3 +85,46 @@ func createServer(wsFactory io.SocketFactory) *http.Server {
 
 	return hs
 }
+
+// newRegistry creates a client for every cluster in config.C.Clusters. If there
+// aren't any, the cluster from config.C.KubeConfig/in-cluster is the only cluster.
+func newRegistry() k8sv1.Registry {
+	if len(config.C.Clusters) == 0 {
+		return k8sv1.NewRegistry(k8sv1.Cluster{
+			Name:   defaultCluster,
+			Client: newClient(defaultCluster, k8sv1.NewWrapper()),
+		})
+	}
+
+	clusters := []k8sv1.Cluster{}
+
+	for _, c := range config.C.Clusters {
+		clusters = append(clusters, k8sv1.Cluster{
+			Name:   c.Name,
+			Client: newClient(c.Name, k8sv1.NewClusterWrapper(c)),
+		})
+	}
+
+	return k8sv1.NewRegistry(clusters...)
+}
+
+// newClient returns a client for the cluster, reading from the informer cache if enabled.
+// If the cache can't be created the client falls back to reading from the api server.
+func newClient(name string, w k8sv1.Wrapper) k8sv1.Clienter {
+	if !config.C.EnableCache {
+		return k8sv1.New(w)
+	}
+
+	// informers run for the life of the process, so stop is never closed.
+	stop := make(chan struct{})
+	resync := time.Duration(config.C.CacheResyncSeconds) * time.Second
+
+	k8Client, err := k8sv1.NewCached(w, resync, stop)
+
+	if err != nil {
+		fmt.Printf("\ncluster %s - informer cache disabled: %s\n", name, err.Error())
+		return k8sv1.New(w)
+	}
+
+	return k8Client
+}
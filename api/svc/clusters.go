@@ -0,0 +1,46 @@
+/*
+MIT License
+
+Copyright (c) 2020 The KubeLens Authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package svc
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/kubelens/kubelens/api/errs"
+)
+
+// Clusters lists the registered clusters and whether each is reachable.
+func (h request) Clusters(w http.ResponseWriter, r *http.Request) {
+	res, err := json.Marshal(h.clusters.Clusters())
+
+	if err != nil {
+		e := errs.SerializationError(err.Error())
+		http.Error(w, e.Message, e.Code)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+}
@@ -0,0 +1,46 @@
+package svc
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http/httptest"
+	"testing"
+
+	k8sv1 "github.com/kubelens/kubelens/api/k8sv1"
+	"github.com/kubelens/kubelens/api/k8sv1/fakes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetClusters(t *testing.T) {
+	fail := true
+	h := &request{k8sv1.NewRegistry(
+		k8sv1.Cluster{Name: "one", Client: &fakes.K8sV1{}},
+		k8sv1.Cluster{Name: "two", Client: fakes.NewFailingK8sV1(&fail)},
+	)}
+	req := httptest.NewRequest("GET", "/clusters", nil)
+	w := httptest.NewRecorder()
+
+	h.Clusters(w, req)
+
+	resp := w.Result()
+
+	defer resp.Body.Close()
+
+	resBody, _ := ioutil.ReadAll(resp.Body)
+
+	var b []k8sv1.ClusterStatus
+	err := json.Unmarshal(resBody, &b)
+
+	if err != nil {
+		assert.Fail(t, err.Error())
+		return
+	}
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Len(t, b, 2)
+	assert.Equal(t, "one", b[0].Name)
+	assert.True(t, b[0].Default)
+	assert.True(t, b[0].Reachable)
+	assert.Equal(t, "two", b[1].Name)
+	assert.False(t, b[1].Reachable)
+	assert.NotEmpty(t, b[1].Error)
+}
@@ -0,0 +1,59 @@
+package svc
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http/httptest"
+	"testing"
+
+	k8sv1 "github.com/kubelens/kubelens/api/k8sv1"
+	klog "github.com/kubelens/kubelens/api/log"
+	logfakes "github.com/kubelens/kubelens/api/log/fakes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCronJobs(t *testing.T) {
+	h := getSvc()
+	req := httptest.NewRequest("GET", `/cronjobs?namespace=test&linkedName=appname"`, nil)
+	w := httptest.NewRecorder()
+
+	dctx := klog.NewContext(req.Context(), "", &logfakes.Logger{})
+	req = req.WithContext(dctx)
+
+	h.CronJobs(w, req)
+
+	resp := w.Result()
+
+	defer resp.Body.Close()
+
+	resBody, _ := ioutil.ReadAll(resp.Body)
+
+	var b struct {
+		Items []k8sv1.CronJobOverview `json:"items"`
+	}
+	err := json.Unmarshal(resBody, &b)
+
+	if err != nil {
+		assert.Fail(t, err.Error())
+		return
+	}
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.True(t, len(b.Items) > 0)
+}
+
+func TestGetCronJob(t *testing.T) {
+	h := getSvc()
+	req := httptest.NewRequest("GET", "/cronjobs/test?namespace=test", nil)
+	w := httptest.NewRecorder()
+
+	dctx := klog.NewContext(req.Context(), "", &logfakes.Logger{})
+	req = req.WithContext(dctx)
+
+	h.CronJob(w, req)
+
+	resp := w.Result()
+
+	defer resp.Body.Close()
+
+	assert.Equal(t, 200, resp.StatusCode)
+}
@@ -57,7 +57,7 @@ func (h request) Deployment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	overview, apiErr := h.k8Client.Deployment(k8sv1.DeploymentOptions{
+	overview, apiErr := h.k8Client(r).Deployment(k8sv1.DeploymentOptions{
 		Logger:    l,
 		Context:   r.Context(),
 		Name:      name,
@@ -70,11 +70,10 @@ func (h request) Deployment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	res, err := json.Marshal(overview)
+	res, apiErr := marshal(w, r, overview)
 
-	if err != nil {
-		e := errs.SerializationError(err.Error())
-		http.Error(w, e.Message, e.Code)
+	if apiErr != nil {
+		http.Error(w, apiErr.Message, apiErr.Code)
 		return
 	}
 
@@ -91,17 +90,123 @@ func (h request) Deployments(w http.ResponseWriter, r *http.Request) {
 	if err := httpreq.NewParsingMapPre(1).
 		ToString("namespace", &data.Namespace).
 		ToString("linkedName", &data.LinkedName).
+		ToString("labelSelector", &data.LabelSelector).
+		ToString("fieldSelector", &data.FieldSelector).
+		ToInt("limit", &data.Limit).
+		ToString("continue", &data.Continue).
 		Parse(r.URL.Query()); err != nil {
 		l.Error(err.Error())
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	overviews, apiErr := h.k8Client.Deployments(k8sv1.DeploymentOptions{
+	overviews, meta, apiErr := h.k8Client(r).Deployments(k8sv1.DeploymentOptions{
 		Logger:     l,
 		Context:    r.Context(),
 		LinkedName: data.LinkedName,
 		Namespace:  data.Namespace,
+		ListOptions: k8sv1.ListOptions{
+			LabelSelector: data.LabelSelector,
+			FieldSelector: data.FieldSelector,
+			Limit:         int64(data.Limit),
+			Continue:      data.Continue,
+		},
+	})
+
+	if apiErr != nil {
+		l.Error(apiErr)
+		http.Error(w, apiErr.Message, apiErr.Code)
+		return
+	}
+
+	res, apiErr := marshal(w, r, list{Items: overviews, ListMeta: meta})
+
+	if apiErr != nil {
+		http.Error(w, apiErr.Message, apiErr.Code)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+}
+
+// DeploymentHistory .
+func (h request) DeploymentHistory(w http.ResponseWriter, r *http.Request) {
+	l := klog.MustFromContext(r.Context())
+
+	var name string
+
+	// "/v1/deployments/{name}/history" = []string{"", deployments", "name", "history"}
+	if params := strings.Split(r.URL.Path, "/"); len(params) == 4 {
+		name = params[2]
+	}
+
+	// get query params
+	var data Req
+	if err := httpreq.NewParsingMapPre(1).
+		ToString("namespace", &data.Namespace).
+		Parse(r.URL.Query()); err != nil {
+		l.Error(err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	history, apiErr := h.k8Client(r).DeploymentHistory(k8sv1.DeploymentOptions{
+		Logger:    l,
+		Context:   r.Context(),
+		Name:      name,
+		Namespace: data.Namespace,
+	})
+
+	if apiErr != nil {
+		l.Error(apiErr)
+		http.Error(w, apiErr.Message, apiErr.Code)
+		return
+	}
+
+	res, err := json.Marshal(history)
+
+	if err != nil {
+		e := errs.SerializationError(err.Error())
+		http.Error(w, e.Message, e.Code)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+}
+
+// DeploymentDiff .
+func (h request) DeploymentDiff(w http.ResponseWriter, r *http.Request) {
+	l := klog.MustFromContext(r.Context())
+
+	var name string
+
+	// "/v1/deployments/{name}/diff" = []string{"", deployments", "name", "diff"}
+	if params := strings.Split(r.URL.Path, "/"); len(params) == 4 {
+		name = params[2]
+	}
+
+	// get query params
+	var data Req
+	var from, to int
+	if err := httpreq.NewParsingMapPre(3).
+		ToString("namespace", &data.Namespace).
+		ToInt("from", &from).
+		ToInt("to", &to).
+		Parse(r.URL.Query()); err != nil {
+		l.Error(err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	diff, apiErr := h.k8Client(r).DeploymentDiff(k8sv1.DeploymentDiffOptions{
+		Logger:    l,
+		Context:   r.Context(),
+		Name:      name,
+		Namespace: data.Namespace,
+		From:      int64(from),
+		To:        int64(to),
 	})
 
 	if apiErr != nil {
@@ -110,7 +215,7 @@ func (h request) Deployments(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	res, err := json.Marshal(overviews)
+	res, err := json.Marshal(diff)
 
 	if err != nil {
 		e := errs.SerializationError(err.Error())
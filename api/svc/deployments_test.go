@@ -28,7 +28,9 @@ func TestGetDeployments(t *testing.T) {
 
 	resBody, _ := ioutil.ReadAll(resp.Body)
 
-	var b []k8sv1.DeploymentOverview
+	var b struct {
+		Items []k8sv1.DeploymentOverview `json:"items"`
+	}
 	err := json.Unmarshal(resBody, &b)
 
 	if err != nil {
@@ -36,7 +38,7 @@ func TestGetDeployments(t *testing.T) {
 		return
 	}
 	assert.Equal(t, 200, resp.StatusCode)
-	assert.True(t, len(b) > 0)
+	assert.True(t, len(b.Items) > 0)
 }
 
 func TestGetDeployment(t *testing.T) {
@@ -55,3 +57,76 @@ func TestGetDeployment(t *testing.T) {
 
 	assert.Equal(t, 200, resp.StatusCode)
 }
+
+func TestGetDeploymentHistory(t *testing.T) {
+	h := getSvc()
+	req := httptest.NewRequest("GET", "/deployments/test/history?namespace=test", nil)
+	w := httptest.NewRecorder()
+
+	dctx := klog.NewContext(req.Context(), "", &logfakes.Logger{})
+	req = req.WithContext(dctx)
+
+	h.DeploymentHistory(w, req)
+
+	resp := w.Result()
+
+	defer resp.Body.Close()
+
+	resBody, _ := ioutil.ReadAll(resp.Body)
+
+	var b []k8sv1.DeploymentRevision
+	err := json.Unmarshal(resBody, &b)
+
+	if err != nil {
+		assert.Fail(t, err.Error())
+		return
+	}
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Len(t, b, 2)
+	assert.Equal(t, "test-2", b[0].ReplicaSet)
+}
+
+func TestGetDeploymentDiff(t *testing.T) {
+	h := getSvc()
+	req := httptest.NewRequest("GET", "/deployments/test/diff?namespace=test&from=1&to=2", nil)
+	w := httptest.NewRecorder()
+
+	dctx := klog.NewContext(req.Context(), "", &logfakes.Logger{})
+	req = req.WithContext(dctx)
+
+	h.DeploymentDiff(w, req)
+
+	resp := w.Result()
+
+	defer resp.Body.Close()
+
+	resBody, _ := ioutil.ReadAll(resp.Body)
+
+	var b k8sv1.DeploymentDiff
+	err := json.Unmarshal(resBody, &b)
+
+	if err != nil {
+		assert.Fail(t, err.Error())
+		return
+	}
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, int64(1), b.From)
+	assert.Equal(t, int64(2), b.To)
+}
+
+func TestGetDeploymentDiffBadRevision(t *testing.T) {
+	h := getSvc()
+	req := httptest.NewRequest("GET", "/deployments/test/diff?namespace=test&from=one", nil)
+	w := httptest.NewRecorder()
+
+	dctx := klog.NewContext(req.Context(), "", &logfakes.Logger{})
+	req = req.WithContext(dctx)
+
+	h.DeploymentDiff(w, req)
+
+	resp := w.Result()
+
+	defer resp.Body.Close()
+
+	assert.Equal(t, 400, resp.StatusCode)
+}
@@ -0,0 +1,87 @@
+/*
+MIT License
+
+Copyright (c) 2020 The KubeLens Authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package svc
+
+import (
+	"net/http"
+
+	k8sv1 "github.com/kubelens/kubelens/api/k8sv1"
+
+	"github.com/creack/httpreq"
+	klog "github.com/kubelens/kubelens/api/log"
+)
+
+// Events .
+func (h request) Events(w http.ResponseWriter, r *http.Request) {
+	l := klog.MustFromContext(r.Context())
+
+	// get query params
+	var data Req
+	if err := httpreq.NewParsingMapPre(8).
+		ToString("namespace", &data.Namespace).
+		ToString("kind", &data.Kind).
+		ToString("name", &data.Name).
+		ToString("type", &data.Type).
+		ToString("labelSelector", &data.LabelSelector).
+		ToString("fieldSelector", &data.FieldSelector).
+		ToInt("limit", &data.Limit).
+		ToString("continue", &data.Continue).
+		Parse(r.URL.Query()); err != nil {
+		l.Error(err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	overviews, meta, apiErr := h.k8Client(r).Events(k8sv1.EventOptions{
+		Logger:    l,
+		Context:   r.Context(),
+		Namespace: data.Namespace,
+		Kind:      data.Kind,
+		Name:      data.Name,
+		Type:      data.Type,
+		ListOptions: k8sv1.ListOptions{
+			LabelSelector: data.LabelSelector,
+			FieldSelector: data.FieldSelector,
+			Limit:         int64(data.Limit),
+			Continue:      data.Continue,
+		},
+	})
+
+	if apiErr != nil {
+		l.Error(apiErr)
+		http.Error(w, apiErr.Message, apiErr.Code)
+		return
+	}
+
+	res, apiErr := marshal(w, r, list{Items: overviews, ListMeta: meta})
+
+	if apiErr != nil {
+		http.Error(w, apiErr.Message, apiErr.Code)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+}
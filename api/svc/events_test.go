@@ -0,0 +1,61 @@
+package svc
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http/httptest"
+	"testing"
+
+	k8sv1 "github.com/kubelens/kubelens/api/k8sv1"
+	klog "github.com/kubelens/kubelens/api/log"
+	logfakes "github.com/kubelens/kubelens/api/log/fakes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetEvents(t *testing.T) {
+	h := getSvc()
+	req := httptest.NewRequest("GET", "/events?namespace=test&kind=Pod&name=web&type=Warning", nil)
+	w := httptest.NewRecorder()
+
+	dctx := klog.NewContext(req.Context(), "", &logfakes.Logger{})
+	req = req.WithContext(dctx)
+
+	h.Events(w, req)
+
+	resp := w.Result()
+
+	defer resp.Body.Close()
+
+	resBody, _ := ioutil.ReadAll(resp.Body)
+
+	var b struct {
+		Items []k8sv1.EventOverview `json:"items"`
+	}
+	err := json.Unmarshal(resBody, &b)
+
+	if err != nil {
+		assert.Fail(t, err.Error())
+		return
+	}
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Len(t, b.Items, 1)
+	assert.Equal(t, "Pod", b.Items[0].Kind)
+	assert.Equal(t, "web", b.Items[0].Name)
+}
+
+func TestGetEventsFail(t *testing.T) {
+	h := getSvc()
+	req := httptest.NewRequest("GET", "/events?namespace=bad", nil)
+	w := httptest.NewRecorder()
+
+	dctx := klog.NewContext(req.Context(), "", &logfakes.Logger{})
+	req = req.WithContext(dctx)
+
+	h.Events(w, req)
+
+	resp := w.Result()
+
+	defer resp.Body.Close()
+
+	assert.Equal(t, 500, resp.StatusCode)
+}
@@ -0,0 +1,311 @@
+/*
+MIT License
+
+Copyright (c) 2020 The KubeLens Authors
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package svc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/creack/httpreq"
+	"github.com/kubelens/kubelens/api/errs"
+	k8sv1 "github.com/kubelens/kubelens/api/k8sv1"
+)
+
+// viewSummary is the view returning the summary of each object, see k8sv1.Summarizer.
+const viewSummary = "summary"
+
+// managedFields are stripped from every object unless a field asks for them.
+const managedFields = "managedFields"
+
+// the formats a single object can be returned in
+const (
+	formatJSON = "json"
+	formatYAML = "yaml"
+)
+
+// marshal serializes an object, or the items of a list, shaped by the query:
+//   - view=summary returns the summary of each object
+//   - fields=a.b,c returns only the given fields, lists are walked through,
+//     e.g. pod.spec.containers.image returns the image of every container
+//   - format=yaml, or an Accept header preferring yaml, returns a single object
+//     as a manifest that can be applied, see k8sv1.Manifest
+func marshal(w http.ResponseWriter, r *http.Request, v interface{}) ([]byte, *errs.APIError) {
+	var view, fields, format string
+	if err := httpreq.NewParsingMapPre(3).
+		ToString("view", &view).
+		ToString("fields", &fields).
+		ToString("format", &format).
+		Parse(r.URL.Query()); err != nil {
+		return nil, errs.ValidationError(err.Error())
+	}
+
+	m, isManifester := v.(k8sv1.Manifester)
+
+	switch {
+	case format == formatYAML && !isManifester:
+		return nil, errs.ValidationError(fmt.Sprintf("format %s is only supported for a single object", formatYAML))
+	case len(format) > 0 && format != formatJSON && format != formatYAML:
+		return nil, errs.ValidationError(fmt.Sprintf("format must be %s or %s, got %s", formatJSON, formatYAML, format))
+	case len(format) == 0 && isManifester && acceptsYAML(r):
+		format = formatYAML
+	}
+
+	if format == formatYAML {
+		// a nil overview, or one without its object, has nothing to export
+		if isNil(m) || isNil(m.Object()) {
+			return nil, errs.NotFound(fmt.Sprintf("nothing to export for %s", r.URL.Path))
+		}
+
+		res, err := k8sv1.Manifest(m.Object())
+		if err != nil {
+			return nil, errs.SerializationError(err.Error())
+		}
+
+		w.Header().Set("Content-Type", "application/yaml")
+
+		return res, nil
+	}
+
+	l, isList := v.(list)
+	if isList {
+		v = l.Items
+	}
+
+	if len(view) > 0 {
+		if view != viewSummary {
+			return nil, errs.ValidationError(fmt.Sprintf("view must be %s, got %s", viewSummary, view))
+		}
+
+		summaries, ok := summarize(v)
+		if !ok {
+			return nil, errs.ValidationError(fmt.Sprintf("view %s is not supported for %s", viewSummary, r.URL.Path))
+		}
+		v = summaries
+	}
+
+	paths, apiErr := parseFields(fields)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	doc, err := toDocument(v)
+	if err != nil {
+		return nil, errs.SerializationError(err.Error())
+	}
+
+	if !paths.has(managedFields) {
+		stripManagedFields(doc)
+	}
+
+	if len(paths) > 0 {
+		doc = project(doc, paths)
+	}
+
+	if isList {
+		l.Items = doc
+		v = l
+	} else {
+		v = doc
+	}
+
+	res, err := json.Marshal(v)
+	if err != nil {
+		return nil, errs.SerializationError(err.Error())
+	}
+
+	return res, nil
+}
+
+// acceptsYAML returns true if the Accept header lists a yaml media type before json.
+func acceptsYAML(r *http.Request) bool {
+	for _, accept := range r.Header.Values("Accept") {
+		for _, mediaType := range strings.Split(accept, ",") {
+			switch strings.TrimSpace(strings.Split(mediaType, ";")[0]) {
+			case "application/yaml", "application/x-yaml", "text/yaml":
+				return true
+			case "application/json":
+				return false
+			}
+		}
+	}
+	return false
+}
+
+// isNil returns true if v is nil or a nil pointer.
+func isNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Ptr && rv.IsNil()
+}
+
+// summarize returns the summary of an object or of every object in a slice,
+// false if they don't have one.
+func summarize(v interface{}) (interface{}, bool) {
+	if s, ok := v.(k8sv1.Summarizer); ok {
+		// a nil pointer has no summary
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+			return nil, true
+		}
+		return s.Summary(), true
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice || !rv.Type().Elem().Implements(reflect.TypeOf((*k8sv1.Summarizer)(nil)).Elem()) {
+		return nil, false
+	}
+
+	summaries := []k8sv1.Summary{}
+	for i := 0; i < rv.Len(); i++ {
+		summaries = append(summaries, rv.Index(i).Interface().(k8sv1.Summarizer).Summary())
+	}
+
+	return summaries, true
+}
+
+// fieldTree is a set of dotted field paths, a nil subtree keeps the whole field.
+type fieldTree map[string]fieldTree
+
+// parseFields parses a comma separated list of dotted field paths, a leading $.
+// and [*] are accepted for those used to JSONPath.
+func parseFields(fields string) (fieldTree, *errs.APIError) {
+	tree := fieldTree{}
+
+	for _, path := range strings.Split(fields, ",") {
+		path = strings.TrimPrefix(strings.TrimSpace(path), "$")
+		path = strings.TrimPrefix(strings.ReplaceAll(path, "[*]", ""), ".")
+
+		if len(path) == 0 {
+			continue
+		}
+
+		node := tree
+		segments := strings.Split(path, ".")
+
+		for i, segment := range segments {
+			if len(segment) == 0 {
+				return nil, errs.ValidationError(fmt.Sprintf("invalid fields: %s", path))
+			}
+
+			sub, ok := node[segment]
+
+			// a shorter path already keeps the whole field
+			if ok && sub == nil {
+				break
+			}
+
+			if i == len(segments)-1 {
+				node[segment] = nil
+				break
+			}
+
+			if !ok {
+				sub = fieldTree{}
+				node[segment] = sub
+			}
+			node = sub
+		}
+	}
+
+	return tree, nil
+}
+
+// has returns true if any path has the field.
+func (t fieldTree) has(field string) bool {
+	for k, sub := range t {
+		if k == field || sub.has(field) {
+			return true
+		}
+	}
+	return false
+}
+
+// project keeps only the fields in the tree, applied to every item of a list.
+func project(doc interface{}, tree fieldTree) interface{} {
+	switch d := doc.(type) {
+	case []interface{}:
+		projected := []interface{}{}
+		for _, item := range d {
+			projected = append(projected, project(item, tree))
+		}
+		return projected
+	case map[string]interface{}:
+		projected := map[string]interface{}{}
+		for k, sub := range tree {
+			v, ok := d[k]
+			if !ok {
+				continue
+			}
+			if sub == nil {
+				projected[k] = v
+			} else {
+				projected[k] = project(v, sub)
+			}
+		}
+		return projected
+	}
+
+	// a scalar has none of the fields
+	return nil
+}
+
+// stripManagedFields removes managedFields from every object in a document.
+func stripManagedFields(doc interface{}) {
+	switch d := doc.(type) {
+	case []interface{}:
+		for _, item := range d {
+			stripManagedFields(item)
+		}
+	case map[string]interface{}:
+		delete(d, managedFields)
+		for _, v := range d {
+			stripManagedFields(v)
+		}
+	}
+}
+
+// toDocument returns the json document of a value, keeping numbers as they are.
+func toDocument(v interface{}) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc interface{}
+
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+
+	if err := d.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
@@ -0,0 +1,140 @@
+package svc
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	k8sv1 "github.com/kubelens/kubelens/api/k8sv1"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func fieldsTestPods() []k8sv1.PodOverview {
+	return []k8sv1.PodOverview{{
+		Name:      "web-1",
+		Namespace: "one",
+		Pod: &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:          "web-1",
+				ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubectl"}},
+			},
+			Spec:   v1.PodSpec{Containers: []v1.Container{{Name: "web", Image: "web:1"}, {Name: "proxy", Image: "proxy:2"}}},
+			Status: v1.PodStatus{Phase: v1.PodRunning},
+		},
+	}}
+}
+
+func TestMarshalStripsManagedFields(t *testing.T) {
+	req := httptest.NewRequest("GET", "/pods", nil)
+
+	res, err := marshal(httptest.NewRecorder(), req, list{Items: fieldsTestPods()})
+
+	assert.Nil(t, err)
+	assert.NotContains(t, string(res), "managedFields")
+	assert.Contains(t, string(res), `"items":[`)
+
+	req = httptest.NewRequest("GET", "/pods?fields=name,pod.metadata.managedFields", nil)
+
+	res, err = marshal(httptest.NewRecorder(), req, list{Items: fieldsTestPods()})
+
+	assert.Nil(t, err)
+	assert.Contains(t, string(res), `"managedFields":[{"manager":"kubectl"}]`)
+}
+
+func TestMarshalFields(t *testing.T) {
+	req := httptest.NewRequest("GET", "/pods?fields=name,$.pod.spec.containers[*].image,pod.status.phase", nil)
+
+	res, err := marshal(httptest.NewRecorder(), req, list{Items: fieldsTestPods()})
+
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{
+		"items": [{"name": "web-1", "pod": {"spec": {"containers": [{"image": "web:1"}, {"image": "proxy:2"}]}, "status": {"phase": "Running"}}}],
+		"continue": "",
+		"remainingItemCount": null
+	}`, string(res))
+}
+
+func TestMarshalSummary(t *testing.T) {
+	req := httptest.NewRequest("GET", "/pods?view=summary&fields=name,images", nil)
+
+	res, err := marshal(httptest.NewRecorder(), req, list{Items: fieldsTestPods()})
+
+	assert.Nil(t, err)
+
+	var b struct {
+		Items []k8sv1.Summary `json:"items"`
+	}
+	json.Unmarshal(res, &b)
+
+	assert.Equal(t, []k8sv1.Summary{{Name: "web-1", Images: []string{"web:1", "proxy:2"}}}, b.Items)
+
+	req = httptest.NewRequest("GET", "/pods/web-1?view=summary", nil)
+	overview := fieldsTestPods()[0]
+
+	res, err = marshal(httptest.NewRecorder(), req, &overview)
+
+	assert.Nil(t, err)
+	assert.Contains(t, string(res), `"status":"Running"`)
+}
+
+func TestMarshalInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		v    interface{}
+	}{
+		{"view", "/pods?view=wide", fieldsTestPods()},
+		{"view not supported", "/overviews?view=summary", []k8sv1.Overview{}},
+		{"fields", "/pods?fields=pod..name", fieldsTestPods()},
+		{"format", "/pods/web-1?format=xml", &fieldsTestPods()[0]},
+		{"format yaml of a list", "/pods?format=yaml", fieldsTestPods()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := marshal(httptest.NewRecorder(), httptest.NewRequest("GET", tt.url, nil), tt.v)
+
+			assert.Equal(t, 400, err.Code)
+		})
+	}
+}
+
+func TestMarshalYAMLNil(t *testing.T) {
+	var overview *k8sv1.PodOverview
+
+	_, err := marshal(httptest.NewRecorder(), httptest.NewRequest("GET", "/pods/web-1?format=yaml", nil), overview)
+
+	assert.Equal(t, 404, err.Code)
+
+	req := httptest.NewRequest("GET", "/pods/web-1", nil)
+	req.Header.Set("Accept", "application/yaml")
+
+	_, err = marshal(httptest.NewRecorder(), req, &k8sv1.PodOverview{Name: "web-1"})
+
+	assert.Equal(t, 404, err.Code)
+}
+
+func TestMarshalYAML(t *testing.T) {
+	overview := fieldsTestPods()[0]
+
+	w := httptest.NewRecorder()
+	res, err := marshal(w, httptest.NewRequest("GET", "/pods/web-1?format=yaml", nil), &overview)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "application/yaml", w.Header().Get("Content-Type"))
+	assert.Contains(t, string(res), "kind: Pod\n")
+	assert.NotContains(t, string(res), "managedFields")
+	assert.NotContains(t, string(res), "status")
+
+	req := httptest.NewRequest("GET", "/pods/web-1", nil)
+	req.Header.Set("Accept", "application/yaml, application/json;q=0.9")
+	w = httptest.NewRecorder()
+
+	res, err = marshal(w, req, &overview)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "application/yaml", w.Header().Get("Content-Type"))
+	assert.Contains(t, string(res), "apiVersion: v1\n")
+}
@@ -25,11 +25,9 @@ SOFTWARE.
 package svc
 
 import (
-	"encoding/json"
 	"net/http"
 	"strings"
 
-	"github.com/kubelens/kubelens/api/errs"
 	k8sv1 "github.com/kubelens/kubelens/api/k8sv1"
 
 	"github.com/creack/httpreq"
@@ -57,7 +55,7 @@ func (h request) Job(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	overview, apiErr := h.k8Client.Job(k8sv1.JobOptions{
+	overview, apiErr := h.k8Client(r).Job(k8sv1.JobOptions{
 		Logger:    l,
 		Context:   r.Context(),
 		Name:      name,
@@ -70,11 +68,10 @@ func (h request) Job(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	res, err := json.Marshal(overview)
+	res, apiErr := marshal(w, r, overview)
 
-	if err != nil {
-		e := errs.SerializationError(err.Error())
-		http.Error(w, e.Message, e.Code)
+	if apiErr != nil {
+		http.Error(w, apiErr.Message, apiErr.Code)
 		return
 	}
 
@@ -91,17 +88,27 @@ func (h request) Jobs(w http.ResponseWriter, r *http.Request) {
 	if err := httpreq.NewParsingMapPre(1).
 		ToString("namespace", &data.Namespace).
 		ToString("linkedName", &data.LinkedName).
+		ToString("labelSelector", &data.LabelSelector).
+		ToString("fieldSelector", &data.FieldSelector).
+		ToInt("limit", &data.Limit).
+		ToString("continue", &data.Continue).
 		Parse(r.URL.Query()); err != nil {
 		l.Error(err.Error())
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	overviews, apiErr := h.k8Client.Jobs(k8sv1.JobOptions{
+	overviews, meta, apiErr := h.k8Client(r).Jobs(k8sv1.JobOptions{
 		Logger:     l,
 		Context:    r.Context(),
 		LinkedName: data.LinkedName,
 		Namespace:  data.Namespace,
+		ListOptions: k8sv1.ListOptions{
+			LabelSelector: data.LabelSelector,
+			FieldSelector: data.FieldSelector,
+			Limit:         int64(data.Limit),
+			Continue:      data.Continue,
+		},
 	})
 
 	if apiErr != nil {
@@ -110,11 +117,10 @@ func (h request) Jobs(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	res, err := json.Marshal(overviews)
+	res, apiErr := marshal(w, r, list{Items: overviews, ListMeta: meta})
 
-	if err != nil {
-		e := errs.SerializationError(err.Error())
-		http.Error(w, e.Message, e.Code)
+	if apiErr != nil {
+		http.Error(w, apiErr.Message, apiErr.Code)
 		return
 	}
 
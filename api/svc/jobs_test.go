@@ -28,7 +28,9 @@ func TestGetJobs(t *testing.T) {
 
 	resBody, _ := ioutil.ReadAll(resp.Body)
 
-	var b []k8sv1.JobOverview
+	var b struct {
+		Items []k8sv1.JobOverview `json:"items"`
+	}
 	err := json.Unmarshal(resBody, &b)
 
 	if err != nil {
@@ -36,7 +38,7 @@ func TestGetJobs(t *testing.T) {
 		return
 	}
 	assert.Equal(t, 200, resp.StatusCode)
-	assert.True(t, len(b) > 0)
+	assert.True(t, len(b.Items) > 0)
 }
 
 func TestGetJob(t *testing.T) {
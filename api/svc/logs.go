@@ -45,10 +45,22 @@ func (h request) Logs(w http.ResponseWriter, r *http.Request) {
 
 	// get query params
 	var data Req
-	if err := httpreq.NewParsingMapPre(3).
+	if err := httpreq.NewParsingMapPre(15).
 		ToString("namespace", &data.Namespace).
 		ToInt("tail", &data.Tail).
 		ToString("containerName", &data.ContainerName).
+		ToBool("previous", &data.Previous).
+		ToRFC3339Time("sinceTime", &data.SinceTime).
+		ToInt("sinceSeconds", &data.SinceSeconds).
+		ToRFC3339Time("untilTime", &data.UntilTime).
+		ToBool("timestamps", &data.Timestamps).
+		ToString("include", &data.Include).
+		ToString("exclude", &data.Exclude).
+		ToBool("ignoreCase", &data.IgnoreCase).
+		ToInt("before", &data.Before).
+		ToInt("after", &data.After).
+		ToString("level", &data.Level).
+		ToCommaList("field", &data.Field).
 		Parse(r.URL.Query()); err != nil {
 		l.Error(err.Error())
 		http.Error(w, err.Error(), http.StatusBadRequest)
@@ -61,13 +73,103 @@ func (h request) Logs(w http.ResponseWriter, r *http.Request) {
 		tl = int64(data.Tail)
 	}
 
-	logs, apiErr := h.k8Client.Logs(k8sv1.LogOptions{
+	logs, apiErr := h.k8Client(r).Logs(k8sv1.LogOptions{
 		Logger:        l,
 		Namespace:     data.Namespace,
 		PodName:       podname,
 		ContainerName: data.ContainerName,
 		Tail:          tl,
 		Follow:        false,
+		Previous:      data.Previous,
+		SinceTime:     data.SinceTime,
+		SinceSeconds:  int64(data.SinceSeconds),
+		UntilTime:     data.UntilTime,
+		Timestamps:    data.Timestamps,
+		Include:       data.Include,
+		Exclude:       data.Exclude,
+		IgnoreCase:    data.IgnoreCase,
+		Before:        data.Before,
+		After:         data.After,
+		Level:         data.Level,
+		Fields:        data.Field,
+		Context:       r.Context(),
+	})
+
+	if apiErr != nil {
+		l.Error(apiErr)
+		http.Error(w, apiErr.Message, apiErr.Code)
+		return
+	}
+
+	res, err := json.Marshal(logs)
+
+	if err != nil {
+		l.Error(err)
+		e := errs.SerializationError(err.Error())
+		http.Error(w, e.Message, e.Code)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+}
+
+// LinkedLogs Retrieves the logs of every container of the pods linked to an application or selected by labels.
+func (h request) LinkedLogs(w http.ResponseWriter, r *http.Request) {
+	l := klog.MustFromContext(r.Context())
+
+	// get query params
+	var data Req
+	if err := httpreq.NewParsingMapPre(17).
+		ToString("namespace", &data.Namespace).
+		ToString("linkedName", &data.LinkedName).
+		ToString("labelSelector", &data.LabelSelector).
+		ToInt("tail", &data.Tail).
+		ToString("containerName", &data.ContainerName).
+		ToBool("previous", &data.Previous).
+		ToRFC3339Time("sinceTime", &data.SinceTime).
+		ToInt("sinceSeconds", &data.SinceSeconds).
+		ToRFC3339Time("untilTime", &data.UntilTime).
+		ToBool("timestamps", &data.Timestamps).
+		ToString("include", &data.Include).
+		ToString("exclude", &data.Exclude).
+		ToBool("ignoreCase", &data.IgnoreCase).
+		ToInt("before", &data.Before).
+		ToInt("after", &data.After).
+		ToString("level", &data.Level).
+		ToCommaList("field", &data.Field).
+		Parse(r.URL.Query()); err != nil {
+		l.Error(err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var tl int64 = 100
+
+	if data.Tail > 0 {
+		tl = int64(data.Tail)
+	}
+
+	logs, apiErr := h.k8Client(r).LinkedLogs(k8sv1.LogOptions{
+		Logger:        l,
+		Namespace:     data.Namespace,
+		LinkedName:    data.LinkedName,
+		LabelSelector: data.LabelSelector,
+		ContainerName: data.ContainerName,
+		Tail:          tl,
+		Follow:        false,
+		Previous:      data.Previous,
+		SinceTime:     data.SinceTime,
+		SinceSeconds:  int64(data.SinceSeconds),
+		UntilTime:     data.UntilTime,
+		Timestamps:    data.Timestamps,
+		Include:       data.Include,
+		Exclude:       data.Exclude,
+		IgnoreCase:    data.IgnoreCase,
+		Before:        data.Before,
+		After:         data.After,
+		Level:         data.Level,
+		Fields:        data.Field,
 		Context:       r.Context(),
 	})
 
@@ -38,3 +38,70 @@ func TestPodLogs(t *testing.T) {
 	assert.Equal(t, 200, resp.StatusCode)
 	assert.True(t, len(b.Output) > 0)
 }
+
+func TestPodLogsPrevious(t *testing.T) {
+	h := getSvc()
+	req := httptest.NewRequest("GET", "/logs/test?namespace=default&previous=true", nil)
+	w := httptest.NewRecorder()
+
+	dctx := klog.NewContext(req.Context(), "", &logfakes.Logger{})
+	req = req.WithContext(dctx)
+
+	h.Logs(w, req)
+
+	assert.Equal(t, 200, w.Result().StatusCode)
+}
+
+func TestPodLogsInvalidTime(t *testing.T) {
+	h := getSvc()
+	req := httptest.NewRequest("GET", "/logs/test?namespace=default&sinceTime=yesterday", nil)
+	w := httptest.NewRecorder()
+
+	dctx := klog.NewContext(req.Context(), "", &logfakes.Logger{})
+	req = req.WithContext(dctx)
+
+	h.Logs(w, req)
+
+	assert.Equal(t, 400, w.Result().StatusCode)
+}
+
+func TestLinkedLogs(t *testing.T) {
+	h := getSvc()
+	req := httptest.NewRequest("GET", "/logs?namespace=default&linkedName=test&tail=1000", nil)
+	w := httptest.NewRecorder()
+
+	dctx := klog.NewContext(req.Context(), "", &logfakes.Logger{})
+	req = req.WithContext(dctx)
+
+	h.LinkedLogs(w, req)
+
+	resp := w.Result()
+
+	defer resp.Body.Close()
+
+	resBody, _ := ioutil.ReadAll(resp.Body)
+
+	var b k8sv1.Log
+	err := json.Unmarshal(resBody, &b)
+
+	if err != nil {
+		assert.Fail(t, err.Error())
+		return
+	}
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, []string{"test/test"}, b.Sources)
+	assert.Equal(t, []k8sv1.LogLine{{Text: "[test/test] some output"}}, b.Output)
+}
+
+func TestLinkedLogsFail(t *testing.T) {
+	h := getSvc()
+	req := httptest.NewRequest("GET", "/logs?namespace=bad2&linkedName=test", nil)
+	w := httptest.NewRecorder()
+
+	dctx := klog.NewContext(req.Context(), "", &logfakes.Logger{})
+	req = req.WithContext(dctx)
+
+	h.LinkedLogs(w, req)
+
+	assert.Equal(t, 500, w.Result().StatusCode)
+}
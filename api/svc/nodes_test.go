@@ -0,0 +1,82 @@
+package svc
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http/httptest"
+	"testing"
+
+	k8sv1 "github.com/kubelens/kubelens/api/k8sv1"
+	klog "github.com/kubelens/kubelens/api/log"
+	logfakes "github.com/kubelens/kubelens/api/log/fakes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetNodes(t *testing.T) {
+	h := getSvc()
+	req := httptest.NewRequest("GET", "/nodes", nil)
+	w := httptest.NewRecorder()
+
+	dctx := klog.NewContext(req.Context(), "", &logfakes.Logger{})
+	req = req.WithContext(dctx)
+
+	h.Nodes(w, req)
+
+	resp := w.Result()
+
+	defer resp.Body.Close()
+
+	resBody, _ := ioutil.ReadAll(resp.Body)
+
+	var b struct {
+		Items []k8sv1.NodeOverview `json:"items"`
+	}
+	err := json.Unmarshal(resBody, &b)
+
+	if err != nil {
+		assert.Fail(t, err.Error())
+		return
+	}
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.True(t, len(b.Items) > 0)
+}
+
+func TestGetNode(t *testing.T) {
+	h := getSvc()
+	req := httptest.NewRequest("GET", "/nodes/node-1", nil)
+	w := httptest.NewRecorder()
+
+	dctx := klog.NewContext(req.Context(), "", &logfakes.Logger{})
+	req = req.WithContext(dctx)
+
+	h.Node(w, req)
+
+	resp := w.Result()
+
+	defer resp.Body.Close()
+
+	resBody, _ := ioutil.ReadAll(resp.Body)
+
+	var b k8sv1.NodeOverview
+	json.Unmarshal(resBody, &b)
+
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "node-1", b.Name)
+}
+
+func TestGetNodeFail(t *testing.T) {
+	h := getSvc()
+	req := httptest.NewRequest("GET", "/nodes/bad", nil)
+	w := httptest.NewRecorder()
+
+	dctx := klog.NewContext(req.Context(), "", &logfakes.Logger{})
+	req = req.WithContext(dctx)
+
+	h.Node(w, req)
+
+	resp := w.Result()
+
+	defer resp.Body.Close()
+
+	assert.Equal(t, 500, resp.StatusCode)
+}
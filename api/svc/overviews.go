@@ -26,6 +26,7 @@ package svc
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strings"
 
@@ -50,9 +51,37 @@ Name string `json:"name"`
 func (h request) Overviews(w http.ResponseWriter, r *http.Request) {
 	l := klog.MustFromContext(r.Context())
 
-	overviews, apiErr := h.k8Client.Overviews(k8sv1.OverviewOptions{
-		Logger:  l,
-		Context: r.Context(),
+	// get query params
+	var groupBy string
+	var data Req
+	if err := httpreq.NewParsingMapPre(3).
+		ToString("groupBy", &groupBy).
+		ToInt("limit", &data.Limit).
+		ToString("continue", &data.Continue).
+		Parse(r.URL.Query()); err != nil {
+		l.Error(err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if len(groupBy) > 0 && groupBy != "partOf" {
+		e := errs.ValidationError(fmt.Sprintf("groupBy must be partOf, got %s", groupBy))
+		http.Error(w, e.Message, e.Code)
+		return
+	}
+
+	// a system could span pages
+	if len(groupBy) > 0 && (data.Limit > 0 || len(data.Continue) > 0) {
+		e := errs.ValidationError("groupBy can't be combined with limit or continue")
+		http.Error(w, e.Message, e.Code)
+		return
+	}
+
+	overviews, meta, apiErr := h.k8Client(r).Overviews(k8sv1.OverviewOptions{
+		Logger:   l,
+		Context:  r.Context(),
+		Limit:    int64(data.Limit),
+		Continue: data.Continue,
 	})
 
 	if apiErr != nil {
@@ -61,11 +90,17 @@ func (h request) Overviews(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	res, err := json.Marshal(overviews)
+	body := list{Items: overviews, ListMeta: meta}
 
-	if err != nil {
-		e := errs.SerializationError(err.Error())
-		http.Error(w, e.Message, e.Code)
+	// applications sharing the app.kubernetes.io/part-of label are grouped into systems
+	if groupBy == "partOf" {
+		body.Items = k8sv1.GroupByPartOf(overviews)
+	}
+
+	res, apiErr := marshal(w, r, body)
+
+	if apiErr != nil {
+		http.Error(w, apiErr.Message, apiErr.Code)
 		return
 	}
 
@@ -94,7 +129,52 @@ func (h request) Overview(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	overviews, apiErr := h.k8Client.Overview(k8sv1.OverviewOptions{
+	overviews, apiErr := h.k8Client(r).Overview(k8sv1.OverviewOptions{
+		Logger:     l,
+		Context:    r.Context(),
+		Namespace:  data.Namespace,
+		LinkedName: linkedName,
+	})
+
+	if apiErr != nil {
+		l.Error(apiErr)
+		http.Error(w, apiErr.Message, apiErr.Code)
+		return
+	}
+
+	res, apiErr := marshal(w, r, overviews)
+
+	if apiErr != nil {
+		http.Error(w, apiErr.Message, apiErr.Code)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+}
+
+// OverviewGraph retrieves the ownership graph of a given linked name.
+func (h request) OverviewGraph(w http.ResponseWriter, r *http.Request) {
+	l := klog.MustFromContext(r.Context())
+
+	var linkedName string
+
+	// "/overviews/{linkedName}/graph" = []string{"", "overviews", "name", "graph"}
+	if params := strings.Split(r.URL.Path, "/"); len(params) == 4 {
+		linkedName = params[2]
+	}
+
+	// get query params
+	var data Req
+	if err := httpreq.NewParsingMapPre(1).
+		ToString("namespace", &data.Namespace).
+		Parse(r.URL.Query()); err != nil {
+		l.Error(err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	graph, apiErr := h.k8Client(r).Graph(k8sv1.GraphOptions{
 		Logger:     l,
 		Context:    r.Context(),
 		Namespace:  data.Namespace,
@@ -107,7 +187,7 @@ func (h request) Overview(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	res, err := json.Marshal(overviews)
+	res, err := json.Marshal(graph)
 
 	if err != nil {
 		e := errs.SerializationError(err.Error())
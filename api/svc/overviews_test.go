@@ -28,7 +28,9 @@ func TestGetOverviews(t *testing.T) {
 
 	resBody, _ := ioutil.ReadAll(resp.Body)
 
-	var b []k8sv1.Overview
+	var b struct {
+		Items []k8sv1.Overview `json:"items"`
+	}
 	err := json.Unmarshal(resBody, &b)
 
 	if err != nil {
@@ -36,7 +38,45 @@ func TestGetOverviews(t *testing.T) {
 		return
 	}
 	assert.Equal(t, 200, resp.StatusCode)
-	assert.True(t, len(b) > 0)
+	assert.True(t, len(b.Items) > 0)
+}
+
+func TestGetOverviewsPage(t *testing.T) {
+	h := getSvc()
+	req := httptest.NewRequest("GET", `/overviews?limit=1`, nil)
+	w := httptest.NewRecorder()
+
+	dctx := klog.NewContext(req.Context(), "", &logfakes.Logger{})
+	req = req.WithContext(dctx)
+
+	h.Overviews(w, req)
+
+	resp := w.Result()
+
+	defer resp.Body.Close()
+
+	var b map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&b)
+
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "next", b["continue"])
+	assert.Contains(t, b, "items")
+	assert.Contains(t, b, "remainingItemCount")
+}
+
+func TestGetOverviewsGroupByPage(t *testing.T) {
+	h := getSvc()
+	req := httptest.NewRequest("GET", `/overviews?groupBy=partOf&limit=1`, nil)
+	w := httptest.NewRecorder()
+
+	dctx := klog.NewContext(req.Context(), "", &logfakes.Logger{})
+	req = req.WithContext(dctx)
+
+	h.Overviews(w, req)
+
+	resp := w.Result()
+
+	assert.Equal(t, 400, resp.StatusCode)
 }
 
 func TestGetAppOverviewDefault(t *testing.T) {
@@ -70,3 +110,92 @@ func TestGetAppOverviewDefault(t *testing.T) {
 	assert.True(t, len(b.ReplicaSets) > 0)
 	assert.True(t, len(b.Services) > 0)
 }
+
+func TestGetOverviewGraph(t *testing.T) {
+	h := getSvc()
+	req := httptest.NewRequest("GET", `/overviews/appname/graph?namespace=default`, nil)
+	w := httptest.NewRecorder()
+
+	dctx := klog.NewContext(req.Context(), "", &logfakes.Logger{})
+	req = req.WithContext(dctx)
+
+	h.OverviewGraph(w, req)
+
+	resp := w.Result()
+
+	defer resp.Body.Close()
+
+	resBody, _ := ioutil.ReadAll(resp.Body)
+
+	var b k8sv1.Graph
+	err := json.Unmarshal(resBody, &b)
+
+	if err != nil {
+		assert.Fail(t, err.Error())
+		return
+	}
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "appname", b.LinkedName)
+	assert.Len(t, b.Nodes, 2)
+	assert.Len(t, b.Edges, 1)
+}
+
+func TestGetOverviewGraphFail(t *testing.T) {
+	h := getSvc()
+	req := httptest.NewRequest("GET", `/overviews/appname/graph?namespace=bad`, nil)
+	w := httptest.NewRecorder()
+
+	dctx := klog.NewContext(req.Context(), "", &logfakes.Logger{})
+	req = req.WithContext(dctx)
+
+	h.OverviewGraph(w, req)
+
+	resp := w.Result()
+
+	assert.Equal(t, 500, resp.StatusCode)
+}
+
+func TestGetOverviewsGroupByPartOf(t *testing.T) {
+	h := getSvc()
+	req := httptest.NewRequest("GET", `/overviews?groupBy=partOf`, nil)
+	w := httptest.NewRecorder()
+
+	dctx := klog.NewContext(req.Context(), "", &logfakes.Logger{})
+	req = req.WithContext(dctx)
+
+	h.Overviews(w, req)
+
+	resp := w.Result()
+
+	defer resp.Body.Close()
+
+	resBody, _ := ioutil.ReadAll(resp.Body)
+
+	var b struct {
+		Items []k8sv1.System `json:"items"`
+	}
+	err := json.Unmarshal(resBody, &b)
+
+	if err != nil {
+		assert.Fail(t, err.Error())
+		return
+	}
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Len(t, b.Items, 1)
+	assert.Len(t, b.Items[0].Overviews, 1)
+}
+
+func TestGetOverviewsGroupByInvalid(t *testing.T) {
+	h := getSvc()
+	req := httptest.NewRequest("GET", `/overviews?groupBy=team`, nil)
+	w := httptest.NewRecorder()
+
+	dctx := klog.NewContext(req.Context(), "", &logfakes.Logger{})
+	req = req.WithContext(dctx)
+
+	h.Overviews(w, req)
+
+	resp := w.Result()
+
+	assert.Equal(t, 400, resp.StatusCode)
+}
@@ -57,7 +57,7 @@ func (h request) Pod(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	overview, apiErr := h.k8Client.Pod(k8sv1.PodOptions{
+	overview, apiErr := h.k8Client(r).Pod(k8sv1.PodOptions{
 		Logger:    l,
 		Context:   r.Context(),
 		Name:      name,
@@ -70,11 +70,10 @@ func (h request) Pod(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	res, err := json.Marshal(overview)
+	res, apiErr := marshal(w, r, overview)
 
-	if err != nil {
-		e := errs.SerializationError(err.Error())
-		http.Error(w, e.Message, e.Code)
+	if apiErr != nil {
+		http.Error(w, apiErr.Message, apiErr.Code)
 		return
 	}
 
@@ -91,17 +90,72 @@ func (h request) Pods(w http.ResponseWriter, r *http.Request) {
 	if err := httpreq.NewParsingMapPre(1).
 		ToString("namespace", &data.Namespace).
 		ToString("linkedName", &data.LinkedName).
+		ToString("labelSelector", &data.LabelSelector).
+		ToString("fieldSelector", &data.FieldSelector).
+		ToInt("limit", &data.Limit).
+		ToString("continue", &data.Continue).
 		Parse(r.URL.Query()); err != nil {
 		l.Error(err.Error())
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	overviews, apiErr := h.k8Client.Pods(k8sv1.PodOptions{
+	overviews, meta, apiErr := h.k8Client(r).Pods(k8sv1.PodOptions{
 		Logger:     l,
 		Context:    r.Context(),
 		LinkedName: data.LinkedName,
 		Namespace:  data.Namespace,
+		ListOptions: k8sv1.ListOptions{
+			LabelSelector: data.LabelSelector,
+			FieldSelector: data.FieldSelector,
+			Limit:         int64(data.Limit),
+			Continue:      data.Continue,
+		},
+	})
+
+	if apiErr != nil {
+		l.Error(apiErr)
+		http.Error(w, apiErr.Message, apiErr.Code)
+		return
+	}
+
+	res, apiErr := marshal(w, r, list{Items: overviews, ListMeta: meta})
+
+	if apiErr != nil {
+		http.Error(w, apiErr.Message, apiErr.Code)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+}
+
+// PodDiagnosis .
+func (h request) PodDiagnosis(w http.ResponseWriter, r *http.Request) {
+	l := klog.MustFromContext(r.Context())
+
+	var name string
+
+	// "/v1/pods/{name}/diagnosis" = []string{"", pods", "name", "diagnosis"}
+	if params := strings.Split(r.URL.Path, "/"); len(params) == 4 {
+		name = params[2]
+	}
+
+	// get query params
+	var data Req
+	if err := httpreq.NewParsingMapPre(1).
+		ToString("namespace", &data.Namespace).
+		Parse(r.URL.Query()); err != nil {
+		l.Error(err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	diagnosis, apiErr := h.k8Client(r).PodDiagnosis(k8sv1.PodOptions{
+		Logger:    l,
+		Context:   r.Context(),
+		Name:      name,
+		Namespace: data.Namespace,
 	})
 
 	if apiErr != nil {
@@ -110,7 +164,7 @@ func (h request) Pods(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	res, err := json.Marshal(overviews)
+	res, err := json.Marshal(diagnosis)
 
 	if err != nil {
 		e := errs.SerializationError(err.Error())
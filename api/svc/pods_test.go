@@ -28,7 +28,9 @@ func TestGetPods(t *testing.T) {
 
 	resBody, _ := ioutil.ReadAll(resp.Body)
 
-	var b []k8sv1.PodOverview
+	var b struct {
+		Items []k8sv1.PodOverview `json:"items"`
+	}
 	err := json.Unmarshal(resBody, &b)
 
 	if err != nil {
@@ -36,7 +38,45 @@ func TestGetPods(t *testing.T) {
 		return
 	}
 	assert.Equal(t, 200, resp.StatusCode)
-	assert.True(t, len(b) > 0)
+	assert.True(t, len(b.Items) > 0)
+}
+
+func TestGetPodsInvalidSelector(t *testing.T) {
+	h := getSvc()
+	req := httptest.NewRequest("GET", `/pods?namespace=test&labelSelector=tier%3Dweb&fieldSelector=bad`, nil)
+	w := httptest.NewRecorder()
+
+	dctx := klog.NewContext(req.Context(), "", &logfakes.Logger{})
+	req = req.WithContext(dctx)
+
+	h.Pods(w, req)
+
+	resp := w.Result()
+
+	assert.Equal(t, 400, resp.StatusCode)
+}
+
+func TestGetPodsPage(t *testing.T) {
+	h := getSvc()
+	req := httptest.NewRequest("GET", `/pods?namespace=test&limit=1`, nil)
+	w := httptest.NewRecorder()
+
+	dctx := klog.NewContext(req.Context(), "", &logfakes.Logger{})
+	req = req.WithContext(dctx)
+
+	h.Pods(w, req)
+
+	resp := w.Result()
+
+	defer resp.Body.Close()
+
+	var b map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&b)
+
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "next", b["continue"])
+	assert.Contains(t, b, "items")
+	assert.Contains(t, b, "remainingItemCount")
 }
 
 func TestGetPod(t *testing.T) {
@@ -55,3 +95,48 @@ func TestGetPod(t *testing.T) {
 
 	assert.Equal(t, 200, resp.StatusCode)
 }
+
+func TestGetPodDiagnosis(t *testing.T) {
+	h := getSvc()
+	req := httptest.NewRequest("GET", "/pods/test/diagnosis?namespace=test", nil)
+	w := httptest.NewRecorder()
+
+	dctx := klog.NewContext(req.Context(), "", &logfakes.Logger{})
+	req = req.WithContext(dctx)
+
+	h.PodDiagnosis(w, req)
+
+	resp := w.Result()
+
+	defer resp.Body.Close()
+
+	resBody, _ := ioutil.ReadAll(resp.Body)
+
+	var b k8sv1.Diagnosis
+	err := json.Unmarshal(resBody, &b)
+
+	if err != nil {
+		assert.Fail(t, err.Error())
+		return
+	}
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "test", b.Pod)
+	assert.Len(t, b.Findings, 1)
+}
+
+func TestGetPodDiagnosisFail(t *testing.T) {
+	h := getSvc()
+	req := httptest.NewRequest("GET", "/pods/test/diagnosis?namespace=bad", nil)
+	w := httptest.NewRecorder()
+
+	dctx := klog.NewContext(req.Context(), "", &logfakes.Logger{})
+	req = req.WithContext(dctx)
+
+	h.PodDiagnosis(w, req)
+
+	resp := w.Result()
+
+	defer resp.Body.Close()
+
+	assert.Equal(t, 500, resp.StatusCode)
+}
@@ -25,9 +25,12 @@ SOFTWARE.
 package svc
 
 import (
+	"encoding/json"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/kubelens/kubelens/api/errs"
 	k8sv1 "github.com/kubelens/kubelens/api/k8sv1"
 )
 
@@ -37,19 +40,34 @@ type Requestor interface {
 	Health(w http.ResponseWriter, r *http.Request)
 	Overview(w http.ResponseWriter, r *http.Request)
 	Overviews(w http.ResponseWriter, r *http.Request)
+	OverviewGraph(w http.ResponseWriter, r *http.Request)
 	Deployment(w http.ResponseWriter, r *http.Request)
 	Deployments(w http.ResponseWriter, r *http.Request)
+	DeploymentHistory(w http.ResponseWriter, r *http.Request)
+	DeploymentDiff(w http.ResponseWriter, r *http.Request)
 	DaemonSet(w http.ResponseWriter, r *http.Request)
 	DaemonSets(w http.ResponseWriter, r *http.Request)
+	StatefulSet(w http.ResponseWriter, r *http.Request)
+	StatefulSets(w http.ResponseWriter, r *http.Request)
+	Events(w http.ResponseWriter, r *http.Request)
 	Job(w http.ResponseWriter, r *http.Request)
 	Jobs(w http.ResponseWriter, r *http.Request)
+	ConfigMap(w http.ResponseWriter, r *http.Request)
+	ConfigMaps(w http.ResponseWriter, r *http.Request)
+	CronJob(w http.ResponseWriter, r *http.Request)
+	CronJobs(w http.ResponseWriter, r *http.Request)
+	Node(w http.ResponseWriter, r *http.Request)
+	Nodes(w http.ResponseWriter, r *http.Request)
 	Pod(w http.ResponseWriter, r *http.Request)
 	Pods(w http.ResponseWriter, r *http.Request)
+	PodDiagnosis(w http.ResponseWriter, r *http.Request)
 	ReplicaSet(w http.ResponseWriter, r *http.Request)
 	ReplicaSets(w http.ResponseWriter, r *http.Request)
 	Service(w http.ResponseWriter, r *http.Request)
 	Services(w http.ResponseWriter, r *http.Request)
 	Logs(w http.ResponseWriter, r *http.Request)
+	LinkedLogs(w http.ResponseWriter, r *http.Request)
+	Clusters(w http.ResponseWriter, r *http.Request)
 }
 
 // Req .
@@ -62,28 +80,83 @@ type Req struct {
 	ContainerName string `json:"containerName,omitempty"`
 	// the namespace to filter
 	Namespace string `json:"namespace,omitempty"`
+	// the kind of the object an event is about, example: ?kind=Pod
+	Kind string `json:"kind,omitempty"`
+	// the name of the object an event is about, example: ?name=web-1
+	Name string `json:"name,omitempty"`
+	// the type of an event, Normal or Warning, example: ?type=Warning
+	Type string `json:"type,omitempty"`
 	// the label selector to search, example: ?labels="app=some-app,app.kubernetes.io/name=app"
 	LinkedName string `json:"linkedName"`
+	// a label selector narrowing a list, example: ?labelSelector=tier=web,env!=dev
+	LabelSelector string `json:"labelSelector,omitempty"`
+	// a field selector narrowing a list, example: ?fieldSelector=status.phase!=Running
+	FieldSelector string `json:"fieldSelector,omitempty"`
+	// the maximum number of items in a page of a list, example: ?limit=50
+	Limit int `json:"limit,omitempty"`
+	// the continue token of the previous page of a list
+	Continue string `json:"continue,omitempty"`
 	// the number of lines to grab from the output
 	Tail int `json:"tail,omitempty"`
+	// get the logs of the previous instance of a container, example: ?previous=true
+	Previous bool `json:"previous,omitempty"`
+	// only logs after this time, example: ?sinceTime=2021-06-01T10:00:00Z
+	SinceTime *time.Time `json:"sinceTime,omitempty"`
+	// only logs from the last number of seconds, example: ?sinceSeconds=300
+	SinceSeconds int `json:"sinceSeconds,omitempty"`
+	// only logs up to this time, example: ?untilTime=2021-06-01T10:30:00Z
+	UntilTime *time.Time `json:"untilTime,omitempty"`
+	// include the timestamp of every log line, example: ?timestamps=true
+	Timestamps bool `json:"timestamps,omitempty"`
+	// only log lines matching a regular expression, example: ?include=error|warn
+	Include string `json:"include,omitempty"`
+	// no log lines matching a regular expression, example: ?exclude=healthz
+	Exclude string `json:"exclude,omitempty"`
+	// match include and exclude ignoring case, example: ?ignoreCase=true
+	IgnoreCase bool `json:"ignoreCase,omitempty"`
+	// lines of context before every matching log line, example: ?before=3
+	Before int `json:"before,omitempty"`
+	// lines of context after every matching log line, example: ?after=3
+	After int `json:"after,omitempty"`
+	// only json log lines at a level or a more severe one, example: ?level=warn
+	Level string `json:"level,omitempty"`
+	// only json log lines with every field, example: ?field=request_id=abc,http.status=500
+	Field []string `json:"field,omitempty"`
+}
+
+// list is the response of a list route, a page of items and how to get the next one.
+type list struct {
+	Items interface{} `json:"items"`
+	k8sv1.ListMeta
 }
 
 // request registers route handlers and dependencies.
 type request struct {
-	k8Client k8sv1.Clienter
+	clusters k8sv1.Registry
 }
 
 // New creates a new request instance
-func New(k8Client k8sv1.Clienter) Requestor {
+func New(clusters k8sv1.Registry) Requestor {
 	return &request{
-		k8Client,
+		clusters,
+	}
+}
+
+// k8Client returns the client for the cluster the request is scoped to,
+// see k8sv1.ClusterFromContext. Requests for unregistered clusters are rejected
+// before reaching a handler, so this falls back to the default cluster.
+func (rq request) k8Client(r *http.Request) k8sv1.Clienter {
+	if k8Client, ok := rq.clusters.Get(k8sv1.ClusterFromContext(r.Context())); ok {
+		return k8Client
 	}
+	k8Client, _ := rq.clusters.Get("")
+	return k8Client
 }
 
 // Health checks the health of the API. Should try
 // to run commands to ensure proper permissions.
 func (rq request) Health(w http.ResponseWriter, r *http.Request) {
-	err := rq.k8Client.SanityCheck()
+	err := rq.k8Client(r).SanityCheck()
 	if err != nil {
 		w.WriteHeader(err.Code)
 		w.Write([]byte(err.Message))
@@ -95,9 +168,48 @@ func (rq request) Health(w http.ResponseWriter, r *http.Request) {
 }
 
 // Ready is just a means to indicate the API is up and running and ready for traffic.
+// When the informer cache is enabled, the API isn't ready until the cache of every registered
+// cluster has synced, see k8sv1.CacheStatus, and the sync status of each kind is returned by
+// cluster. /clusters/{cluster}/ready only checks the named cluster.
 func (rq request) Ready(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusOK)
-	w.Write([]byte(http.StatusText(http.StatusOK)))
+	names := rq.clusters.Names()
+
+	if name := k8sv1.ClusterFromContext(r.Context()); len(name) > 0 {
+		names = []string{name}
+	}
+
+	statuses := map[string]k8sv1.CacheStatus{}
+	synced := true
+
+	for _, name := range names {
+		k8Client, _ := rq.clusters.Get(name)
+
+		if status := k8Client.CacheStatus(); status.Enabled {
+			statuses[name] = status
+			synced = synced && status.Synced
+		}
+	}
+
+	if len(statuses) == 0 {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(http.StatusText(http.StatusOK)))
+		return
+	}
+
+	res, err := json.Marshal(statuses)
+
+	if err != nil {
+		e := errs.SerializationError(err.Error())
+		http.Error(w, e.Message, e.Code)
+		return
+	}
+
+	if !synced {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	} else {
+		w.WriteHeader(http.StatusOK)
+	}
+	w.Write(res)
 }
 
 // Register registers all routes with the v1 sub router.
@@ -105,9 +217,14 @@ func (rq request) Register(router *mux.Router) {
 	router.HandleFunc("/ready", rq.Ready).Methods("GET")
 	router.HandleFunc("/health", rq.Health).Methods("GET")
 
+	// /clusters, every other route is also served for a
+	// given cluster under the /clusters/{cluster}/ prefix.
+	router.HandleFunc("/clusters", rq.Clusters).Methods("GET")
+
 	// /overviews
 	router.HandleFunc("/overviews", rq.Overviews).Methods("GET")
 	router.HandleFunc("/overviews/{linkedName}", rq.Overview).Methods("GET")
+	router.HandleFunc("/overviews/{linkedName}/graph", rq.OverviewGraph).Methods("GET")
 
 	// /daemonsets
 	router.HandleFunc("/daemonsets", rq.DaemonSets).Methods("GET")
@@ -116,23 +233,46 @@ func (rq request) Register(router *mux.Router) {
 	// /deployments
 	router.HandleFunc("/deployments", rq.Deployments).Methods("GET")
 	router.HandleFunc("/deployments/{name}", rq.Deployment).Methods("GET")
+	router.HandleFunc("/deployments/{name}/history", rq.DeploymentHistory).Methods("GET")
+	router.HandleFunc("/deployments/{name}/diff", rq.DeploymentDiff).Methods("GET")
+
+	// /events
+	router.HandleFunc("/events", rq.Events).Methods("GET")
 
 	// /jobs
 	router.HandleFunc("/jobs", rq.Jobs).Methods("GET")
 	router.HandleFunc("/jobs/{name}", rq.Job).Methods("GET")
 
+	// /configmaps
+	router.HandleFunc("/configmaps", rq.ConfigMaps).Methods("GET")
+	router.HandleFunc("/configmaps/{name}", rq.ConfigMap).Methods("GET")
+
+	// /cronjobs
+	router.HandleFunc("/cronjobs", rq.CronJobs).Methods("GET")
+	router.HandleFunc("/cronjobs/{name}", rq.CronJob).Methods("GET")
+
+	// /nodes
+	router.HandleFunc("/nodes", rq.Nodes).Methods("GET")
+	router.HandleFunc("/nodes/{name}", rq.Node).Methods("GET")
+
 	// /pods
 	router.HandleFunc("/pods", rq.Pods).Methods("GET")
 	router.HandleFunc("/pods/{name}", rq.Pod).Methods("GET")
+	router.HandleFunc("/pods/{name}/diagnosis", rq.PodDiagnosis).Methods("GET")
 
 	// /replicasets
 	router.HandleFunc("/replicasets", rq.ReplicaSets).Methods("GET")
 	router.HandleFunc("/replicasets/{name}", rq.ReplicaSet).Methods("GET")
 
+	// /statefulsets
+	router.HandleFunc("/statefulsets", rq.StatefulSets).Methods("GET")
+	router.HandleFunc("/statefulsets/{name}", rq.StatefulSet).Methods("GET")
+
 	// /services
 	router.HandleFunc("/services", rq.Services).Methods("GET")
 	router.HandleFunc("/services/{name}", rq.Service).Methods("GET")
 
 	// /logs
+	router.HandleFunc("/logs", rq.LinkedLogs).Methods("GET")
 	router.HandleFunc("/logs/{pod}", rq.Logs).Methods("GET")
 }
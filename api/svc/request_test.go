@@ -1,6 +1,7 @@
 package svc
 
 import (
+	"encoding/json"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -8,18 +9,26 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/kubelens/kubelens/api/config"
+	k8sv1 "github.com/kubelens/kubelens/api/k8sv1"
 	"github.com/kubelens/kubelens/api/k8sv1/fakes"
 	"github.com/stretchr/testify/assert"
 )
 
+func getClusters() k8sv1.Registry {
+	return k8sv1.NewRegistry(k8sv1.Cluster{
+		Name:   "default",
+		Client: &fakes.K8sV1{},
+	})
+}
+
 func getSvc() *request {
-	return &request{&fakes.K8sV1{}}
+	return &request{getClusters()}
 }
 
 func TestRegister(t *testing.T) {
 	rc := mux.NewRouter()
 
-	rq := New(&fakes.K8sV1{})
+	rq := New(getClusters())
 
 	p := func() {
 		rq.Register(rc)
@@ -32,7 +41,7 @@ func TestHealthHandler(t *testing.T) {
 	config.Set("../config/config.json")
 	rc := mux.NewRouter()
 
-	rq := New(&fakes.K8sV1{})
+	rq := New(getClusters())
 	rq.Register(rc)
 
 	ts := httptest.NewServer(rc)
@@ -51,3 +60,82 @@ func TestHealthHandler(t *testing.T) {
 	}
 	assert.Equal(t, http.StatusText(http.StatusOK), string(r1))
 }
+
+func TestReadyCacheDisabled(t *testing.T) {
+	h := getSvc()
+	req := httptest.NewRequest("GET", "/ready", nil)
+	w := httptest.NewRecorder()
+
+	h.Ready(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, http.StatusText(http.StatusOK), w.Body.String())
+}
+
+func TestReadyCacheNotSynced(t *testing.T) {
+	h := &request{k8sv1.NewRegistry(k8sv1.Cluster{
+		Name: "default",
+		Client: fakes.NewCachedK8sV1(k8sv1.CacheStatus{
+			Enabled: true,
+			Synced:  false,
+			Kinds:   map[string]bool{"pods": true, "services": false},
+		}),
+	})}
+	req := httptest.NewRequest("GET", "/ready", nil)
+	w := httptest.NewRecorder()
+
+	h.Ready(w, req)
+
+	var b map[string]k8sv1.CacheStatus
+	err := json.Unmarshal(w.Body.Bytes(), &b)
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.False(t, b["default"].Kinds["services"])
+}
+
+func TestReadyCacheSynced(t *testing.T) {
+	h := &request{k8sv1.NewRegistry(k8sv1.Cluster{
+		Name:   "default",
+		Client: fakes.NewCachedK8sV1(k8sv1.CacheStatus{Enabled: true, Synced: true}),
+	})}
+	req := httptest.NewRequest("GET", "/ready", nil)
+	w := httptest.NewRecorder()
+
+	h.Ready(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestReadyClusters(t *testing.T) {
+	h := &request{k8sv1.NewRegistry(
+		k8sv1.Cluster{Name: "default", Client: fakes.NewCachedK8sV1(k8sv1.CacheStatus{Enabled: true, Synced: true})},
+		k8sv1.Cluster{Name: "other", Client: fakes.NewCachedK8sV1(k8sv1.CacheStatus{Enabled: true, Synced: false})},
+	)}
+
+	// every cluster
+	w := httptest.NewRecorder()
+	h.Ready(w, httptest.NewRequest("GET", "/ready", nil))
+
+	var b map[string]k8sv1.CacheStatus
+	err := json.Unmarshal(w.Body.Bytes(), &b)
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.True(t, b["default"].Synced)
+	assert.False(t, b["other"].Synced)
+
+	// /clusters/default/ready
+	req := httptest.NewRequest("GET", "/ready", nil)
+	req = req.WithContext(k8sv1.NewClusterContext(req.Context(), "default"))
+	w = httptest.NewRecorder()
+
+	h.Ready(w, req)
+
+	b = nil
+	err = json.Unmarshal(w.Body.Bytes(), &b)
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Len(t, b, 1)
+}
@@ -28,7 +28,9 @@ func TestGetServicesDefault(t *testing.T) {
 
 	resBody, _ := ioutil.ReadAll(resp.Body)
 
-	var b []k8sv1.ServiceOverview
+	var b struct {
+		Items []k8sv1.ServiceOverview `json:"items"`
+	}
 	err := json.Unmarshal(resBody, &b)
 
 	if err != nil {
@@ -36,7 +38,7 @@ func TestGetServicesDefault(t *testing.T) {
 		return
 	}
 	assert.Equal(t, 200, resp.StatusCode)
-	assert.Equal(t, "-service", b[0].Name)
+	assert.Equal(t, "-service", b.Items[0].Name)
 }
 
 func TestGetServicesError(t *testing.T) {
@@ -35,7 +35,7 @@ describe('getApps should', () => {
         OverviewActionTypes.GET_OVERVIEWS,
         LoadingActionTypes.LOADING
     ];
-    sinon.stub(adapter, 'get').resolves({data: res});
+    sinon.stub(adapter, 'get').resolves({data: {items: res}});
   
     return store.dispatch(getOverviews("appname", encodeURIComponent('{"key":"value"}'), "minikube", ""))
       .then(() => {
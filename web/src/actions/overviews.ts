@@ -109,7 +109,7 @@ export const getOverviews: ActionCreator<
       });
 
       const response = await adapter.get('overviews', cluster, jwt);
-      const data = response.data as Overview[];
+      const data = response.data.items as Overview[];
       const overviews = _.orderBy(data, 'linkedName');
 
       dispatch({
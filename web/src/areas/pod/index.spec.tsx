@@ -45,7 +45,7 @@ const setup = () => {
     },
     logs: {
       pod: 'podname',
-      output: 'some log text'
+      output: [{ text: 'some log text' }]
     },
     envBody: {
       key: 'value'
@@ -53,7 +53,7 @@ const setup = () => {
     toggleModalType: jest.fn(),
     logs: {
       pod: 'podname',
-      output: 'some log text'
+      output: [{ text: 'some log text' }]
     },
     openLogStream: jest.fn(),
     closeLogStream: jest.fn(),
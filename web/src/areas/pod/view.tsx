@@ -87,6 +87,8 @@ const PodPage = ({
     });
   }
 
+  const logText: string = logs ? _.map(logs.output, line => line.text).join('\n') : '';
+
   return (
     <div className="pod-container">
       {!_.isEmpty(podDetail) ?
@@ -168,13 +170,13 @@ const PodPage = ({
                 <br />
                 {hasLogAccess
                   ? <div>
-                    <h4><CopyClipboard labelText={`Log Stdout ${logStream ? 'Stream' : ''}`} value={logStream ? logStream : (logs ? logs.output : '')} size={22} /></h4> 
+                    <h4><CopyClipboard labelText={`Log Stdout ${logStream ? 'Stream' : ''}`} value={logStream ? logStream : logText} size={22} /></h4> 
                     <hr />
                     {logStream || logs
                       ? <div style={{ backgroundColor: 'rgb(39, 40, 34)', padding: '10px', overflow: 'auto', maxHeight: '300px' }}>
                         <pre style={{ whiteSpace: 'pre-line', color: 'white', fontSize: '12px' }}>
                           {logStream && logStream}
-                          {logs && !logStream && logs.output}
+                          {logs && !logStream && logText}
                         </pre>
                       </div>
                       : null
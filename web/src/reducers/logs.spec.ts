@@ -14,7 +14,7 @@ describe('logs reducer', () => {
         type: LogsActionTypes.GET_LOGS,
         logs: {
           pod: 'podname',
-          output: 'log output'
+          output: [{ text: 'log output' }]
         }
       })
     ).toEqual({
@@ -22,7 +22,7 @@ describe('logs reducer', () => {
       logsError: undefined,
       logs: {
         pod: 'podname',
-        output: 'log output'
+        output: [{ text: 'log output' }]
       }
     })
   })
@@ -99,9 +99,17 @@ export type Service = {
 	service:      any
 }
 
+export type LogLine = {
+  timestamp?: string,
+  text:       string,
+  level?:     string,
+  message?:   string,
+  fields?:    any
+}
+
 export type Log = {
   pod:    string,
-  output: string
+  output: LogLine[]
 }
 
 export type ConfigMap = {